@@ -2,6 +2,8 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,12 +13,20 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/grokify/product-artifacts/prd"
+	"github.com/grokify/product-artifacts/tracker"
 )
 
 // Interactive PRD creation wizard
 func createInteractivePRD(filename string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
 	fmt.Println(color.CyanString("🚀 Welcome to the PRD Creation Wizard!"))
 	fmt.Println("Let's create a comprehensive Product Requirements Document.")
 
@@ -38,22 +48,14 @@ func createInteractivePRD(filename string) error {
 
 	// Owner Information
 	fmt.Println(color.YellowString("\n👤 Owner Information"))
-	fmt.Print("Owner Name: ")
-	ownerName, _ := reader.ReadString('\n')
-	ownerName = strings.TrimSpace(ownerName)
-
-	fmt.Print("Owner Email: ")
-	ownerEmail, _ := reader.ReadString('\n')
-	ownerEmail = strings.TrimSpace(ownerEmail)
-
-	fmt.Print("Owner Team: ")
-	ownerTeam, _ := reader.ReadString('\n')
-	ownerTeam = strings.TrimSpace(ownerTeam)
+	ownerName := promptWithDefault(reader, "Owner Name", cfg.Defaults.Owner)
+	ownerEmail := promptWithDefault(reader, "Owner Email", cfg.Defaults.Email)
+	ownerTeam := promptWithDefault(reader, "Owner Team", cfg.Defaults.Team)
 
 	// Status and Priority
 	fmt.Println(color.YellowString("\n📊 Status & Priority"))
-	status := selectFromOptions("Status", []string{"draft", "review", "approved", "in_development", "completed"})
-	priority := selectFromOptions("Priority", []string{"critical", "high", "medium", "low"})
+	status := selectEnum("Status", cfg.AllowedStatuses())
+	priority := selectEnum("Priority", prd.Priority("").Values())
 
 	// Overview
 	fmt.Println(color.YellowString("\n📝 Product Overview"))
@@ -75,7 +77,7 @@ func createInteractivePRD(filename string) error {
 
 	// Functional Requirements
 	fmt.Println(color.YellowString("\n⚙️ Functional Requirements"))
-	functionalReqs := collectFunctionalRequirements()
+	functionalReqs := collectFunctionalRequirements(cfg.IDs.Requirement)
 
 	// Create PRD object
 	now := time.Now()
@@ -83,7 +85,7 @@ func createInteractivePRD(filename string) error {
 		ID:          id,
 		Title:       title,
 		Version:     version,
-		CreatedDate: now.Format("2006-01-02"),
+		CreatedDate: prd.DateOf(now),
 		LastUpdated: &now,
 		Owner: prd.Owner{
 			Name:  ownerName,
@@ -120,32 +122,33 @@ func createInteractivePRD(filename string) error {
 
 // Create basic PRD with minimal input
 func createBasicPRD(filename string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Print("PRD Title: ")
 	title, _ := reader.ReadString('\n')
 	title = strings.TrimSpace(title)
 
-	fmt.Print("Your Name: ")
-	name, _ := reader.ReadString('\n')
-	name = strings.TrimSpace(name)
-
-	fmt.Print("Your Email: ")
-	email, _ := reader.ReadString('\n')
-	email = strings.TrimSpace(email)
+	name := promptWithDefault(reader, "Your Name", cfg.Defaults.Owner)
+	email := promptWithDefault(reader, "Your Email", cfg.Defaults.Email)
 
 	now := time.Now()
 	prdDoc := &prd.PRD{
-		ID:          fmt.Sprintf("PRD-%d", now.Unix()),
+		ID:          fmt.Sprintf("%s%d", cfg.IDs.PRD, now.Unix()),
 		Title:       title,
 		Version:     "1.0.0",
-		CreatedDate: now.Format("2006-01-02"),
+		CreatedDate: prd.DateOf(now),
 		LastUpdated: &now,
 		Owner: prd.Owner{
 			Name:  name,
 			Email: email,
+			Team:  cfg.Defaults.Team,
 		},
-		Status: "draft",
+		Status:   cfg.AllowedStatuses()[0],
+		Priority: cfg.Defaults.Priority,
 		Overview: prd.Overview{
 			ProblemStatement: "TODO: Define the problem this product/feature solves",
 			SolutionSummary:  "TODO: Describe the proposed solution",
@@ -156,9 +159,9 @@ func createBasicPRD(filename string) error {
 		Requirements: prd.Requirements{
 			Functional: []prd.FunctionalRequirement{
 				{
-					ID:          "FR-001",
+					ID:          prd.NewID(cfg.IDs.Requirement, 1),
 					Description: "TODO: Define functional requirements",
-					Priority:    "must_have",
+					Priority:    prd.MustHave,
 				},
 			},
 		},
@@ -175,20 +178,20 @@ func createBasicPRD(filename string) error {
 
 // Create from template
 func createFromTemplate(filename, templateType string) error {
-	templates := map[string]*prd.PRD{
-		"basic":   createBasicTemplate(),
-		"feature": createFeatureTemplate(),
-		"epic":    createEpicTemplate(),
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
-
-	template, exists := templates[templateType]
-	if !exists {
-		return fmt.Errorf("template '%s' not found. Available: basic, feature, epic", templateType)
+	template, err := loadTemplate(cfg, templateType)
+	if err != nil {
+		return err
 	}
+	cfg.ApplyDefaults(template)
+	cfg.ApplyIDPrefixes(template)
 
 	now := time.Now()
 	template.LastUpdated = &now
-	template.CreatedDate = now.Format("2006-01-02")
+	template.CreatedDate = prd.DateOf(now)
 
 	if err := template.SaveToFile(filename); err != nil {
 		return fmt.Errorf("failed to save PRD: %w", err)
@@ -198,51 +201,117 @@ func createFromTemplate(filename, templateType string) error {
 	return nil
 }
 
+// listOptions are the list command's filter, sort and output flags
+type listOptions struct {
+	format     string
+	recursive  bool
+	statuses   []string
+	priorities []string
+	teams      []string
+	owner      string
+	sortKey    string
+	reverse    bool
+	days       int
+}
+
 // List PRDs in directory
-func listPRDs(dir string) error {
-	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+func listPRDs(dir string, opts listOptions) error {
+	filter := prd.PortfolioFilter{Teams: opts.teams, Owner: opts.owner}
+	for _, s := range opts.statuses {
+		status := prd.Status(strings.ToLower(strings.TrimSpace(s)))
+		if !status.Valid() {
+			return fmt.Errorf("invalid status '%s': expected one of %s", s, strings.Join(prd.EnumStrings(status.Values()), ", "))
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+	for _, p := range opts.priorities {
+		priority := prd.Priority(strings.ToLower(strings.TrimSpace(p)))
+		if !priority.Valid() {
+			return fmt.Errorf("invalid priority '%s': expected one of %s", p, strings.Join(prd.EnumStrings(priority.Values()), ", "))
+		}
+		filter.Priorities = append(filter.Priorities, priority)
+	}
+
+	loadOpts := prd.DefaultPortfolioOptions()
+	loadOpts.Recursive = opts.recursive
+	portfolio, err := prd.LoadPortfolio(dir, loadOpts)
 	if err != nil {
 		return err
 	}
-
-	if len(files) == 0 {
-		fmt.Println("No PRD files found in directory.")
-		return nil
+	portfolio = portfolio.Filter(filter)
+	if err := portfolio.Sort(opts.sortKey, opts.reverse); err != nil {
+		return err
 	}
+	summary := portfolio.Summary(time.Now(), opts.days)
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("File", "ID", "Title", "Status", "Owner", "Last Updated")
-
-	for _, file := range files {
-		prdDoc, err := prd.LoadFromFile(file)
+	switch opts.format {
+	case "json":
+		data, err := json.MarshalIndent(struct {
+			*prd.Portfolio
+			Summary prd.PortfolioSummary `json:"summary"`
+		}{portfolio, summary}, "", "  ")
 		if err != nil {
-			continue // Skip invalid files
+			return fmt.Errorf("failed to marshal portfolio: %w", err)
 		}
+		fmt.Println(string(data))
+		return nil
+	case "csv":
+		return prd.WritePortfolioCSV(os.Stdout, portfolio)
+	case "markdown", "md":
+		fmt.Print(prd.PortfolioMarkdown(portfolio, summary, opts.days))
+		return nil
+	case "table":
+	default:
+		return fmt.Errorf("list format '%s' not supported", opts.format)
+	}
 
-		lastUpdated := "N/A"
-		if prdDoc.LastUpdated != nil {
-			lastUpdated = prdDoc.LastUpdated.Format("2006-01-02 15:04")
+	if len(portfolio.Items) == 0 {
+		fmt.Println("No PRD files found in directory.")
+	} else {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header("File", "ID", "Title", "Status", "Priority", "Owner", "Team", "Launch", "Last Updated")
+		for _, item := range portfolio.Items {
+			lastUpdated := "N/A"
+			if item.LastUpdated != nil {
+				lastUpdated = item.LastUpdated.Format("2006-01-02 15:04")
+			}
+			err := table.Append([]string{
+				item.Path,
+				item.ID,
+				truncateString(item.Title, 30),
+				getStatusWithColor(item.Status),
+				getPriorityWithColor(item.Priority),
+				item.Owner,
+				item.Team,
+				item.LaunchDate.String(),
+				lastUpdated,
+			})
+			if err != nil {
+				return err
+			}
 		}
 
-		err = table.Append([]string{
-			filepath.Base(file),
-			prdDoc.ID,
-			truncateString(prdDoc.Title, 30),
-			prdDoc.Status,
-			prdDoc.Owner.Name,
-			lastUpdated,
-		})
-		if err != nil {
+		fmt.Println(color.CyanString("📋 PRD Documents"))
+		if err := table.Render(); err != nil {
 			return err
 		}
+		displayPortfolioSummary(summary, opts.days)
 	}
 
-	fmt.Println(color.CyanString("📋 PRD Documents"))
-	return table.Render()
+	if len(portfolio.Errors) > 0 {
+		fmt.Printf(color.RedString("\n❌ %d files could not be loaded:\n"), len(portfolio.Errors))
+		for _, e := range portfolio.Errors {
+			fmt.Printf("  • %s: %s\n", e.Path, e.Error)
+		}
+	}
+	if len(portfolio.Ignored) > 0 {
+		fmt.Printf(color.HiBlackString("\nIgnored %d JSON files that are not PRDs\n"), len(portfolio.Ignored))
+	}
+	return nil
 }
 
 // View PRD content
-func viewPRD(filename, format, section string) error {
+func viewPRD(filename, format, section, workspaceDir string) error {
 	prdDoc, err := prd.LoadFromFile(filename)
 	if err != nil {
 		return err
@@ -259,17 +328,56 @@ func viewPRD(filename, format, section string) error {
 		return displayPRDTable(prdDoc, section)
 	default: // pretty
 		displayPRDPretty(prdDoc, section)
+		if section == "" || section == "references" {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			workspace, path, err := loadWorkspaceFor(cfg, filename, workspaceDir)
+			if err != nil {
+				return err
+			}
+			displayReferences(workspace.Outbound(prdDoc, path), workspace.Inbound(prdDoc.ID))
+			displayHierarchyLinks(workspace.Hierarchy(time.Now()), prdDoc.ID)
+		}
 	}
 
 	return nil
 }
 
+// loadWorkspaceFor loads the workspace of a PRD file with the configured PRD
+// ID prefix, returning the file's path within it
+func loadWorkspaceFor(cfg *prd.Config, filename, workspaceDir string) (*prd.Workspace, string, error) {
+	if workspaceDir == "" {
+		workspaceDir = prd.FindWorkspaceRoot(filename)
+	}
+	workspace, err := prd.LoadWorkspace(workspaceDir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load workspace: %w", err)
+	}
+	workspace.IDPrefix = cfg.IDs.PRD
+
+	path := filename
+	if abs, err := filepath.Abs(filename); err == nil {
+		if root, err := filepath.Abs(workspaceDir); err == nil {
+			if rel, err := filepath.Rel(root, abs); err == nil && !strings.HasPrefix(rel, "..") {
+				path = filepath.ToSlash(rel)
+			}
+		}
+	}
+	return workspace, path, nil
+}
+
 // Edit PRD
 func editPRD(filename, section string) error {
 	prdDoc, err := prd.LoadFromFile(filename)
 	if err != nil {
 		return err
 	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
 
 	fmt.Printf(color.CyanString("📝 Editing PRD: %s\n"), prdDoc.Title)
 
@@ -281,13 +389,13 @@ func editPRD(filename, section string) error {
 
 	switch section {
 	case "basic":
-		editBasicInfo(prdDoc)
+		editBasicInfo(prdDoc, cfg)
 	case "overview":
 		editOverview(prdDoc)
 	case "objectives":
 		editObjectives(prdDoc)
 	case "requirements":
-		editRequirements(prdDoc)
+		editRequirements(prdDoc, cfg)
 	default:
 		return fmt.Errorf("section '%s' not supported for editing", section)
 	}
@@ -303,7 +411,7 @@ func editPRD(filename, section string) error {
 }
 
 // Validate PRD
-func validatePRD(filename string, strict bool) error {
+func validatePRD(filename string, strict bool, openapiFile, workspaceDir string) error {
 	prdDoc, err := prd.LoadFromFile(filename)
 	if err != nil {
 		return err
@@ -317,30 +425,95 @@ func validatePRD(filename string, strict bool) error {
 		return err
 	}
 
-	// Additional checks for strict mode
-	warnings := []string{}
-	if strict {
-		if len(prdDoc.UserStories) == 0 {
-			warnings = append(warnings, "No user stories defined")
+	// Workspace configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.CheckStatus(prdDoc.Status); err != nil {
+		fmt.Printf(color.RedString("❌ Validation failed: %v\n"), err)
+		return err
+	}
+
+	// Cross-PRD references
+	workspace, path, err := loadWorkspaceFor(cfg, filename, workspaceDir)
+	if err != nil {
+		return err
+	}
+	if problems := workspace.CheckReferences(prdDoc, path); len(problems) > 0 {
+		fmt.Printf(color.RedString("❌ %d invalid references to other PRDs:\n"), len(problems))
+		for _, problem := range problems {
+			fmt.Printf("  • %s\n", problem)
+		}
+		return fmt.Errorf("%d invalid references to other PRDs", len(problems))
+	}
+	if problems := workspace.CheckHierarchy(prdDoc, path, time.Now()); len(problems) > 0 {
+		fmt.Printf(color.RedString("❌ %d hierarchy problems:\n"), len(problems))
+		for _, problem := range problems {
+			fmt.Printf("  • %s\n", problem)
+		}
+		return fmt.Errorf("%d hierarchy problems", len(problems))
+	}
+
+	// Lint rules enabled by the configuration, or all of them in strict mode
+	now := time.Now()
+	var warnings []string
+	if cfg.LintEnabled("timeline-review", strict) {
+		warnings = append(warnings, prdDoc.CheckTimeline(now)...)
+	}
+	if cfg.LintEnabled("risk-review", strict) {
+		warnings = append(warnings, prdDoc.CheckRisks(now)...)
+	}
+	if cfg.LintEnabled("assumption-review", strict) {
+		warnings = append(warnings, prdDoc.CheckAssumptions()...)
+	}
+	if cfg.LintEnabled("user-stories", strict) && len(prdDoc.UserStories) == 0 {
+		warnings = append(warnings, "No user stories defined")
+	}
+	if cfg.LintEnabled("timeline", strict) && prdDoc.Timeline == nil {
+		warnings = append(warnings, "No timeline specified")
+	}
+	if cfg.LintEnabled("non-functional", strict) && len(prdDoc.Requirements.NonFunctional) == 0 {
+		warnings = append(warnings, "No non-functional requirements")
+	}
+
+	if openapiFile != "" {
+		doc, err := prd.LoadOpenAPI(openapiFile)
+		if err != nil {
+			return err
+		}
+
+		report := doc.Check(prdDoc)
+		for _, op := range report.Uncovered {
+			warnings = append(warnings, fmt.Sprintf("%s %s is in the OpenAPI spec but not in the PRD", op.Method, op.Endpoint))
 		}
-		if prdDoc.Timeline == nil {
-			warnings = append(warnings, "No timeline specified")
+		for _, m := range report.Mismatches {
+			warnings = append(warnings, fmt.Sprintf("%s %s description differs: PRD %q, spec %q", m.Method, m.Endpoint, m.PRD, m.Spec))
 		}
-		if len(prdDoc.Requirements.NonFunctional) == 0 {
-			warnings = append(warnings, "No non-functional requirements")
+
+		if !report.Valid() {
+			fmt.Printf(color.RedString("❌ %d API specifications not found in %s:\n"), len(report.Missing), openapiFile)
+			for _, op := range report.Missing {
+				fmt.Printf("  • %s %s\n", op.Method, op.Endpoint)
+			}
+			printWarnings(warnings)
+			return fmt.Errorf("%d API specifications not found in OpenAPI spec", len(report.Missing))
 		}
 	}
 
 	fmt.Println(color.GreenString("✅ PRD validation passed"))
+	printWarnings(warnings)
 
+	return nil
+}
+
+func printWarnings(warnings []string) {
 	if len(warnings) > 0 {
 		fmt.Println(color.YellowString("\n⚠️ Warnings:"))
 		for _, warning := range warnings {
 			fmt.Printf("  • %s\n", warning)
 		}
 	}
-
-	return nil
 }
 
 // Show PRD status
@@ -375,11 +548,107 @@ func showPRDStatus(filename string) error {
 		fmt.Printf("• Milestones: %d\n", len(prdDoc.Timeline.Milestones))
 	}
 
+	displayDeliveryProgress(prdDoc.DeliveryProgress(time.Now()))
+	displayOKRProgress(prdDoc)
+	displayMetricSummary(prdDoc.MetricReports(time.Now()))
+
+	return nil
+}
+
+// Sync PRD user stories and requirements with GitHub Issues
+func syncPRD(filename, repo, token, baseURL string, dryRun, update bool) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	gh, err := tracker.NewGitHubFromRepo(repo, token)
+	if err != nil {
+		return err
+	}
+	gh.BaseURL = baseURL
+
+	fmt.Printf(color.CyanString("🔄 Syncing PRD %s with %s\n"), prdDoc.ID, repo)
+
+	result, err := tracker.Sync(context.Background(), gh, prdDoc, tracker.SyncOptions{
+		DryRun:         dryRun,
+		UpdateExisting: update,
+	})
+	if err != nil {
+		// Keep the links to issues created before the failure, so the next
+		// run does not create them again
+		if !dryRun && len(result.Items) > 0 {
+			prdDoc.UpdateLastModified()
+			if saveErr := prdDoc.SaveToFile(filename); saveErr != nil {
+				return fmt.Errorf("sync failed: %w (and failed to save PRD: %v)", err, saveErr)
+			}
+			fmt.Printf(color.YellowString("⚠️  Saved links for %d issues synced before the failure\n"), len(result.Items))
+		}
+		return fmt.Errorf("sync failed: %w", err)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Type", "Issue", "Status", "Action")
+	for _, item := range result.Items {
+		action := ""
+		switch {
+		case item.Created && dryRun:
+			action = "would create"
+		case item.Created:
+			action = "created"
+		case item.Updated:
+			action = "updated"
+		case item.Missing:
+			action = "missing"
+		}
+		key := item.Key
+		if key != "" {
+			key = "#" + key
+		}
+		if err := table.Append([]string{item.ID, item.Kind, key, string(item.Status), action}); err != nil {
+			return err
+		}
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	done, total := result.Progress()
+	if total > 0 {
+		fmt.Printf("\n📦 Delivery progress: %d/%d done (%.0f%%)\n", done, total, float64(done)*100/float64(total))
+	}
+
+	if dryRun {
+		fmt.Println(color.YellowString("Dry run: no issues created and PRD not modified"))
+		return nil
+	}
+
+	prdDoc.UpdateLastModified()
+	if err := prdDoc.SaveToFile(filename); err != nil {
+		return fmt.Errorf("failed to save PRD: %w", err)
+	}
+
+	fmt.Printf(color.GreenString("✅ Sync complete: %d issues created\n"), result.Created())
 	return nil
 }
 
+// exportOptions holds format-specific export settings
+type exportOptions struct {
+	markdown       prd.MarkdownOptions
+	backlogMapping string
+	// outputDir is where default output names are placed
+	outputDir string
+}
+
 // Export PRD to different formats
-func exportPRD(filename, format, output string) error {
+func exportPRD(filename, format, output string, opts exportOptions) error {
+	if format == "site" {
+		if output == "" {
+			output = filepath.Join(opts.outputDir, "site")
+		}
+		return exportToSite(filename, output)
+	}
+
 	prdDoc, err := prd.LoadFromFile(filename)
 	if err != nil {
 		return err
@@ -387,24 +656,144 @@ func exportPRD(filename, format, output string) error {
 
 	if output == "" {
 		ext := map[string]string{
-			"markdown": ".md",
-			"html":     ".html",
-			"pdf":      ".pdf",
+			"markdown":     ".md",
+			"html":         ".html",
+			"docx":         ".docx",
+			"confluence":   ".xhtml",
+			"jira-wiki":    ".jira.txt",
+			"backlog-csv":  ".backlog.csv",
+			"backlog-json": ".backlog.json",
+			"gherkin":      "-features",
+			"openapi":      ".openapi.json",
+			"gantt":        ".gantt.svg",
 		}
 		output = strings.TrimSuffix(filename, ".json") + ext[format]
+		if opts.outputDir != "" {
+			if err := os.MkdirAll(opts.outputDir, 0750); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			output = filepath.Join(opts.outputDir, filepath.Base(output))
+		}
 	}
 
 	switch format {
 	case "markdown":
-		return exportToMarkdown(prdDoc, output)
+		return exportToMarkdown(prdDoc, output, opts.markdown)
 	case "html":
 		return exportToHTML(prdDoc, output)
+	case "docx":
+		return exportToDOCX(prdDoc, output)
+	case "confluence":
+		return exportToConfluence(prdDoc, output)
+	case "jira-wiki":
+		return exportToJiraWiki(prdDoc, output)
+	case "backlog-csv", "backlog-json":
+		return exportToBacklog(prdDoc, output, format, opts.backlogMapping)
+	case "gherkin":
+		return exportToGherkin(prdDoc, output)
+	case "openapi":
+		return exportToOpenAPI(prdDoc, output)
+	case "gantt":
+		return exportToGantt(prdDoc, output)
 	default:
 		return fmt.Errorf("export format '%s' not supported", format)
 	}
 }
 
+// Build export options from export command flags
+func exportOptionsFromFlags(cmd *cobra.Command, cfg *prd.Config) (exportOptions, error) {
+	mdOpts, err := markdownOptionsFromFlags(cmd)
+	if err != nil {
+		return exportOptions{}, err
+	}
+
+	mapping, _ := cmd.Flags().GetString("mapping")
+
+	return exportOptions{
+		markdown:       mdOpts,
+		backlogMapping: mapping,
+		outputDir:      cfg.Export.OutputDir,
+	}, nil
+}
+
+// applyExportConfig gives the export flags the user did not set the
+// configured defaults
+func applyExportConfig(cmd *cobra.Command, cfg *prd.Config) error {
+	defaults := map[string]string{
+		"format": cfg.Export.Format,
+		"style":  cfg.Export.Style,
+		"flavor": cfg.Export.Flavor,
+	}
+	for name, value := range defaults {
+		if value == "" || cmd.Flags().Changed(name) {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid export.%s in configuration: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Build Markdown rendering options from export command flags
+func markdownOptionsFromFlags(cmd *cobra.Command) (prd.MarkdownOptions, error) {
+	opts := prd.DefaultMarkdownOptions()
+
+	opts.IncludeTOC, _ = cmd.Flags().GetBool("toc")
+	opts.HeadingOffset, _ = cmd.Flags().GetInt("heading-offset")
+
+	sections, _ := cmd.Flags().GetString("sections")
+	include, err := prd.ParseSections(sections)
+	if err != nil {
+		return opts, err
+	}
+	opts.Sections = include
+
+	excludeSections, _ := cmd.Flags().GetString("exclude-sections")
+	exclude, err := prd.ParseSections(excludeSections)
+	if err != nil {
+		return opts, err
+	}
+	opts.ExcludeSections = exclude
+
+	style, _ := cmd.Flags().GetString("style")
+	switch prd.MarkdownStyle(style) {
+	case prd.MarkdownStyleTable, prd.MarkdownStyleList:
+		opts.Style = prd.MarkdownStyle(style)
+	default:
+		return opts, fmt.Errorf("invalid markdown style '%s'. Available: table, list", style)
+	}
+
+	flavor, _ := cmd.Flags().GetString("flavor")
+	switch prd.MarkdownFlavor(flavor) {
+	case prd.MarkdownFlavorGFM, prd.MarkdownFlavorCommonMark:
+		opts.Flavor = prd.MarkdownFlavor(flavor)
+	default:
+		return opts, fmt.Errorf("invalid markdown flavor '%s'. Available: gfm, commonmark", flavor)
+	}
+
+	if footer, _ := cmd.Flags().GetBool("footer"); footer {
+		now := time.Now()
+		opts.GeneratedAt = &now
+	}
+
+	return opts, nil
+}
+
 // Helper functions
+func promptWithDefault(reader *bufio.Reader, prompt, def string) string {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", prompt, def)
+	} else {
+		fmt.Printf("%s: ", prompt)
+	}
+	input, _ := reader.ReadString('\n')
+	if input = strings.TrimSpace(input); input != "" {
+		return input
+	}
+	return def
+}
+
 func selectFromOptions(prompt string, options []string) string {
 	fmt.Printf("%s:\n", prompt)
 	for i, option := range options {
@@ -423,6 +812,11 @@ func selectFromOptions(prompt string, options []string) string {
 	return options[0] // Default to first option
 }
 
+// selectEnum prompts for one of an enum's values
+func selectEnum[T ~string](prompt string, values []T) T {
+	return T(selectFromOptions(prompt, prd.EnumStrings(values)))
+}
+
 func collectMultipleInputs(itemType string, maxItems int) []string {
 	var items []string
 	reader := bufio.NewReader(os.Stdin)
@@ -441,7 +835,7 @@ func collectMultipleInputs(itemType string, maxItems int) []string {
 	return items
 }
 
-func collectFunctionalRequirements() []prd.FunctionalRequirement {
+func collectFunctionalRequirements(idPrefix string) []prd.FunctionalRequirement {
 	var requirements []prd.FunctionalRequirement
 	reader := bufio.NewReader(os.Stdin)
 
@@ -454,10 +848,10 @@ func collectFunctionalRequirements() []prd.FunctionalRequirement {
 			break
 		}
 
-		priority := selectFromOptions("Priority", []string{"must_have", "should_have", "could_have", "wont_have"})
+		priority := selectEnum("Priority", prd.MoSCoW("").Values())
 
 		requirements = append(requirements, prd.FunctionalRequirement{
-			ID:          fmt.Sprintf("FR-%03d", i+1),
+			ID:          prd.NewID(idPrefix, i+1),
 			Description: desc,
 			Priority:    priority,
 		})
@@ -473,32 +867,703 @@ func truncateString(s string, length int) string {
 	return s[:length-3] + "..."
 }
 
-func getStatusWithColor(status string) string {
-	colors := map[string]*color.Color{
-		"draft":          color.New(color.FgYellow),
-		"review":         color.New(color.FgCyan),
-		"approved":       color.New(color.FgGreen),
-		"in_development": color.New(color.FgBlue),
-		"completed":      color.New(color.FgGreen, color.Bold),
-		"archived":       color.New(color.FgHiBlack),
+var (
+	statusColors = map[prd.Status]*color.Color{
+		prd.StatusDraft:         color.New(color.FgYellow),
+		prd.StatusReview:        color.New(color.FgCyan),
+		prd.StatusApproved:      color.New(color.FgGreen),
+		prd.StatusInDevelopment: color.New(color.FgBlue),
+		prd.StatusCompleted:     color.New(color.FgGreen, color.Bold),
+		prd.StatusArchived:      color.New(color.FgHiBlack),
+	}
+	priorityColors = map[prd.Priority]*color.Color{
+		prd.PriorityCritical: color.New(color.FgRed, color.Bold),
+		prd.PriorityHigh:     color.New(color.FgRed),
+		prd.PriorityMedium:   color.New(color.FgYellow),
+		prd.PriorityLow:      color.New(color.FgGreen),
+	}
+	moscowColors = map[prd.MoSCoW]*color.Color{
+		prd.MustHave:   color.New(color.FgRed),
+		prd.ShouldHave: color.New(color.FgYellow),
+		prd.CouldHave:  color.New(color.FgCyan),
+		prd.WontHave:   color.New(color.FgHiBlack),
+	}
+	riskLevelColors = map[prd.RiskLevel]*color.Color{
+		prd.RiskLow:      color.New(color.FgGreen),
+		prd.RiskMedium:   color.New(color.FgYellow),
+		prd.RiskHigh:     color.New(color.FgRed),
+		prd.RiskCritical: color.New(color.FgHiRed, color.Bold),
+	}
+	heatMapColors = map[prd.RiskLevel]*color.Color{
+		prd.RiskLow:      color.New(color.BgGreen, color.FgBlack),
+		prd.RiskMedium:   color.New(color.BgYellow, color.FgBlack),
+		prd.RiskHigh:     color.New(color.BgHiRed, color.FgBlack),
+		prd.RiskCritical: color.New(color.BgRed, color.FgWhite, color.Bold),
+	}
+	assumptionResultColors = map[prd.AssumptionResult]*color.Color{
+		prd.AssumptionUntested:     color.New(color.FgYellow),
+		prd.AssumptionValidated:    color.New(color.FgGreen),
+		prd.AssumptionInvalidated:  color.New(color.FgRed),
+		prd.AssumptionInconclusive: color.New(color.FgMagenta),
+	}
+	confidenceColors = map[prd.Confidence]*color.Color{
+		prd.ConfidenceLow:    color.New(color.FgRed),
+		prd.ConfidenceMedium: color.New(color.FgYellow),
+		prd.ConfidenceHigh:   color.New(color.FgGreen),
+	}
+	metricStatusColors = map[prd.MetricStatus]*color.Color{
+		prd.MetricNoData:   color.New(color.FgWhite),
+		prd.MetricOnTrack:  color.New(color.FgGreen),
+		prd.MetricOffTrack: color.New(color.FgYellow),
+		prd.MetricMet:      color.New(color.FgGreen, color.Bold),
+		prd.MetricMissed:   color.New(color.FgRed),
+	}
+	milestoneStateColors = map[prd.MilestoneState]*color.Color{
+		prd.MilestonePlanned:    color.New(color.FgCyan),
+		prd.MilestoneInProgress: color.New(color.FgBlue),
+		prd.MilestoneDone:       color.New(color.FgGreen),
+		prd.MilestoneAtRisk:     color.New(color.FgYellow),
+		prd.MilestoneSlipped:    color.New(color.FgRed),
+	}
+)
+
+func getStatusWithColor(status prd.Status) string {
+	return withColor(status, statusColors)
+}
+
+func getPriorityWithColor(priority prd.Priority) string {
+	return withColor(priority, priorityColors)
+}
+
+func getMoSCoWWithColor(priority prd.MoSCoW) string {
+	return withColor(priority, moscowColors)
+}
+
+func getRiskLevelWithColor(level prd.RiskLevel) string {
+	return withColor(level, riskLevelColors)
+}
+
+func getAssumptionResultWithColor(result prd.AssumptionResult) string {
+	if result == "" {
+		result = prd.AssumptionUntested
+	}
+	return withColor(result, assumptionResultColors)
+}
+
+func getConfidenceWithColor(confidence prd.Confidence) string {
+	return withColor(confidence, confidenceColors)
+}
+
+func getMetricStatusWithColor(status prd.MetricStatus) string {
+	return withColor(status, metricStatusColors)
+}
+
+func getMilestoneStateWithColor(state prd.MilestoneState) string {
+	return withColor(state, milestoneStateColors)
+}
+
+func withColor[T ~string](value T, colors map[T]*color.Color) string {
+	if c, exists := colors[value]; exists {
+		return c.Sprint(value)
+	}
+	return string(value)
+}
+
+// Build and display a requirements traceability matrix
+func tracePRD(filename, source, format, output string, failUntested bool) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	opts := prd.DefaultTraceOptions()
+	opts.SkipFiles = []string{filename}
+
+	// IDs defined by the other PRDs in the workspace are not orphans
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	workspace, path, err := loadWorkspaceFor(cfg, filename, "")
+	if err != nil {
+		return err
+	}
+	for _, item := range workspace.Portfolio.Items {
+		if item.Path == path {
+			continue
+		}
+		for _, story := range item.PRD.UserStories {
+			opts.ExternalIDs = append(opts.ExternalIDs, story.ID)
+		}
+		for _, req := range item.PRD.Requirements.Functional {
+			opts.ExternalIDs = append(opts.ExternalIDs, req.ID)
+		}
+		for _, req := range item.PRD.Requirements.NonFunctional {
+			opts.ExternalIDs = append(opts.ExternalIDs, req.ID)
+		}
+	}
+
+	matrix, err := prd.Trace(source, prdDoc, opts)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "table":
+		if err := displayTraceMatrix(matrix); err != nil {
+			return err
+		}
+	case "csv", "html":
+		if output == "" {
+			output = strings.TrimSuffix(filename, ".json") + ".trace." + format
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create trace file: %w", err)
+		}
+
+		if format == "csv" {
+			err = prd.WriteTraceCSV(f, matrix)
+		} else {
+			err = prd.WriteTraceHTML(f, matrix)
+		}
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write trace file: %w", err)
+		}
+		fmt.Printf(color.GreenString("✅ Traceability matrix exported: %s\n"), output)
+	default:
+		return fmt.Errorf("trace format '%s' not supported", format)
+	}
+
+	if untested := matrix.UntestedMustHaves(); failUntested && len(untested) > 0 {
+		return fmt.Errorf("%d must-have requirements are untested", len(untested))
+	}
+	return nil
+}
+
+// Import content from an external format into an existing PRD
+func importIntoPRD(filename, from, source string) error {
+	if from != "openapi" {
+		return fmt.Errorf("import source '%s' not supported", from)
+	}
+
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	doc, err := prd.LoadOpenAPI(source)
+	if err != nil {
+		return err
+	}
+
+	added, updated := prdDoc.MergeAPISpecifications(doc.APISpecifications())
+
+	prdDoc.UpdateLastModified()
+	if err := prdDoc.SaveToFile(filename); err != nil {
+		return fmt.Errorf("failed to save PRD: %w", err)
+	}
+
+	fmt.Printf(color.GreenString("✅ Imported API specifications from %s: %d added, %d updated\n"), source, added, updated)
+	return nil
+}
+
+func analyzeTimeline(filename string, slips []string) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	analysis, err := prdDoc.AnalyzeTimeline(time.Now())
+	if err != nil {
+		return err
+	}
+	if len(analysis.Milestones) == 0 {
+		fmt.Println(color.YellowString("⚠️ No dated milestones to analyze"))
+		return nil
+	}
+
+	if err := displayTimelineAnalysis(analysis); err != nil {
+		return err
+	}
+
+	for _, slip := range slips {
+		name, days, err := parseSlip(analysis, slip)
+		if err != nil {
+			return err
+		}
+		projection, err := analysis.ProjectSlip(name, days)
+		if err != nil {
+			return err
+		}
+		displaySlipProjection(projection)
+	}
+	return nil
+}
+
+// parseSlip parses "<milestone>=<N>d", "<milestone>=<N>w" or
+// "<milestone>=<date>" into a milestone name and a shift in days
+func parseSlip(analysis *prd.TimelineAnalysis, slip string) (string, int, error) {
+	i := strings.LastIndex(slip, "=")
+	if i < 0 {
+		return "", 0, fmt.Errorf("invalid slip '%s': expected <milestone>=<days>d, <weeks>w or <date>", slip)
+	}
+	name, value := strings.TrimSpace(slip[:i]), strings.TrimSpace(slip[i+1:])
+
+	milestone, ok := analysis.Milestone(name)
+	if !ok {
+		return "", 0, fmt.Errorf("milestone '%s' not found or has no target date", name)
+	}
+
+	if date, err := prd.ParseDate(value); err == nil {
+		return milestone.Name, int(date.End().Sub(milestone.TargetDate.End()).Hours() / 24), nil
+	}
+
+	unit := 1
+	switch {
+	case strings.HasSuffix(value, "w"):
+		unit = 7
+		value = strings.TrimSuffix(value, "w")
+	case strings.HasSuffix(value, "d"):
+		value = strings.TrimSuffix(value, "d")
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid slip '%s': expected <milestone>=<days>d, <weeks>w or <date>", slip)
+	}
+	return milestone.Name, n * unit, nil
+}
+
+func showRisks(filename string) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if prdDoc.RisksAndAssumptions == nil || len(prdDoc.RisksAndAssumptions.Risks) == 0 {
+		fmt.Println(color.YellowString("⚠️ No risks recorded"))
+		return nil
+	}
+
+	if err := displayRiskRegister(prdDoc); err != nil {
+		return err
+	}
+
+	printWarnings(append(prdDoc.RisksAndAssumptions.Problems(), prdDoc.CheckRisks(time.Now())...))
+	return nil
+}
+
+func showAssumptions(filename string, failUnvalidated bool) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if prdDoc.RisksAndAssumptions == nil || len(prdDoc.RisksAndAssumptions.Assumptions) == 0 {
+		fmt.Println(color.YellowString("⚠️ No assumptions recorded"))
+		return nil
+	}
+
+	if err := displayAssumptions(prdDoc); err != nil {
+		return err
+	}
+
+	unvalidated := prdDoc.UnvalidatedAssumptions()
+	if len(unvalidated) == 0 {
+		fmt.Println(color.GreenString("\n✅ All assumptions validated"))
+		return nil
+	}
+
+	fmt.Printf(color.RedString("\n🚧 %d unvalidated assumptions block approval:\n"), len(unvalidated))
+	for _, a := range unvalidated {
+		method := a.ValidationMethod
+		if method == "" {
+			method = color.YellowString("no validation method")
+		}
+		fmt.Printf("  • %s: %s (%s)\n", a.ID, truncateString(a.Statement, 60), method)
+	}
+
+	if failUnvalidated {
+		return fmt.Errorf("%d assumptions are not validated", len(unvalidated))
+	}
+	return nil
+}
+
+// Record a check-in for an OKR key result
+func updateKeyResult(filename, id, value, confidence, note, date string) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	kr, err := prdDoc.Objectives.KeyResult(id)
+	if err != nil {
+		return err
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, kr.Unit)), 64)
+	if err != nil {
+		return fmt.Errorf("invalid value '%s': %w", value, err)
+	}
+
+	checkIn := prd.CheckIn{Date: prd.DateOf(time.Now()), Value: v, Confidence: prd.Confidence(confidence), Note: note}
+	if checkIn.Confidence != "" && !checkIn.Confidence.Valid() {
+		return fmt.Errorf("invalid confidence '%s': expected low, medium or high", confidence)
+	}
+	if date != "" {
+		if checkIn.Date, err = prd.ParseDate(date); err != nil {
+			return err
+		}
+	}
+
+	kr.RecordCheckIn(checkIn)
+
+	prdDoc.UpdateLastModified()
+	if err := prdDoc.SaveToFile(filename); err != nil {
+		return fmt.Errorf("failed to save PRD: %w", err)
+	}
+
+	fmt.Printf(color.GreenString("✅ Recorded %s on %s\n"), kr.FormatValue(v), checkIn.Date)
+	fmt.Printf("• %s\n", kr.Description)
+	if kr.Measurable() {
+		fmt.Printf("  %s %s\n", progressBar(kr.Progress(), 20), kr.ProgressLabel())
+	}
+	if len(kr.CheckIns) > 1 {
+		var history []string
+		for _, c := range kr.CheckIns {
+			history = append(history, fmt.Sprintf("%s %s", c.Date, kr.FormatValue(c.Value)))
+		}
+		fmt.Printf("  History: %s\n", strings.Join(history, " → "))
+	}
+	return nil
+}
+
+// Ingest success metric measurements from a data file, or from each
+// metric's recorded data source
+func ingestMetrics(filename, source, metric string) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filename)
+	var results []prd.IngestResult
+	if source != "" {
+		if results, err = ingestMetricSource(prdDoc, source, metric); err != nil {
+			return err
+		}
+		// Record the source relative to the PRD so it can be re-ingested
+		rel, err := filepath.Rel(dir, source)
+		if err != nil {
+			rel = source
+		}
+		for _, result := range results {
+			m, _ := prdDoc.Objectives.SuccessMetric(result.Metric)
+			m.DataSource = filepath.ToSlash(rel)
+		}
+	} else {
+		// A data source shared by several metrics names each row's metric,
+		// so it is read once
+		ingestedSources := map[string]bool{}
+		for _, m := range prdDoc.Objectives.SuccessMetrics {
+			if m.DataSource == "" || ingestedSources[m.DataSource] {
+				continue
+			}
+			ingestedSources[m.DataSource] = true
+			ingested, err := ingestMetricSource(prdDoc, filepath.Join(dir, filepath.FromSlash(m.DataSource)), m.Metric)
+			if err != nil {
+				return err
+			}
+			results = append(results, ingested...)
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("no success metric has a data source: pass a data file to ingest")
+		}
+	}
+
+	// Only the updated metrics are checked, so unrelated problems elsewhere
+	// in the PRD do not block recording measurements
+	for _, result := range results {
+		m, _ := prdDoc.Objectives.SuccessMetric(result.Metric)
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("ingested measurements are invalid: %w", err)
+		}
+	}
+	prdDoc.UpdateLastModified()
+	if err := prdDoc.SaveToFile(filename); err != nil {
+		return fmt.Errorf("failed to save PRD: %w", err)
 	}
 
-	if c, exists := colors[status]; exists {
-		return c.Sprint(status)
+	for _, result := range results {
+		fmt.Printf(color.GreenString("✅ %s: %d added, %d updated\n"), result.Metric, result.Added, result.Updated)
 	}
-	return status
+	fmt.Println()
+	return displayMetricReports(prdDoc.MetricReports(time.Now()))
 }
 
-func getPriorityWithColor(priority string) string {
-	colors := map[string]*color.Color{
-		"critical": color.New(color.FgRed, color.Bold),
-		"high":     color.New(color.FgRed),
-		"medium":   color.New(color.FgYellow),
-		"low":      color.New(color.FgGreen),
+func ingestMetricSource(prdDoc *prd.PRD, source, metric string) ([]prd.IngestResult, error) {
+	samples, err := prd.LoadMeasurements(source)
+	if err != nil {
+		return nil, err
+	}
+	results, err := prdDoc.IngestMeasurements(samples, metric)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ingest %s: %w", source, err)
 	}
+	return results, nil
+}
 
-	if c, exists := colors[priority]; exists {
-		return c.Sprint(priority)
+// Report success metrics against their goals
+func showMetrics(filename string) error {
+	prdDoc, err := prd.LoadFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if len(prdDoc.Objectives.SuccessMetrics) == 0 {
+		fmt.Println(color.YellowString("⚠️ No success metrics defined"))
+		return nil
+	}
+
+	fmt.Printf(color.CyanString("📏 Success Metrics: %s\n"), prdDoc.Title)
+	return displayMetricReports(prdDoc.MetricReports(time.Now()))
+}
+
+// Query PRDs in a file or directory
+func queryPRDs(expression, path, format string, elements, recursive bool) error {
+	query, err := prd.ParseQuery(expression)
+	if err != nil {
+		return err
+	}
+
+	loadOpts := prd.DefaultPortfolioOptions()
+	loadOpts.Recursive = recursive
+	portfolio, err := prd.LoadPortfolio(path, loadOpts)
+	if err != nil {
+		return err
+	}
+	results, err := query.Search(portfolio)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		if results == nil {
+			results = []prd.QueryResult{}
+		}
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal query results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case "table":
+	default:
+		return fmt.Errorf("query format '%s' not supported", format)
+	}
+
+	if len(results) == 0 {
+		fmt.Println(color.YellowString("No PRDs match the query"))
+	} else if elements {
+		if err := displayQueryElements(results); err != nil {
+			return err
+		}
+	} else if err := displayQueryResults(results); err != nil {
+		return err
+	}
+
+	for _, e := range portfolio.Errors {
+		fmt.Printf(color.RedString("❌ Skipped %s: %s\n"), e.Path, e.Error)
+	}
+	return nil
+}
+
+// Search PRDs in a directory using its full-text index
+func searchPRDs(text, dir, indexPath, format string, limit int, rebuild bool) error {
+	if format != "table" && format != "json" {
+		return fmt.Errorf("search format '%s' not supported", format)
+	}
+	if info, err := os.Stat(dir); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("search requires a directory, got %s", dir)
+	}
+	if indexPath == "" {
+		indexPath = prd.DefaultSearchIndexPath(dir)
+	}
+
+	index, err := prd.OpenSearchIndex(indexPath)
+	if err != nil {
+		return err
+	}
+	if rebuild {
+		index.Reset()
+	}
+	update, err := index.Update(dir, prd.DefaultPortfolioOptions())
+	if err != nil {
+		return err
+	}
+	if err := index.Save(); err != nil {
+		return err
+	}
+
+	results := index.Search(text, limit)
+	if format == "json" {
+		if results == nil {
+			results = []prd.SearchResult{}
+		}
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal search results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if changed := update.Added + update.Updated + update.Removed; changed > 0 {
+		fmt.Printf(color.HiBlackString("Indexed %d added, %d updated, %d removed (%s)\n"), update.Added, update.Updated, update.Removed, indexPath)
+	}
+	for _, e := range update.Errors {
+		fmt.Printf(color.RedString("❌ Not indexed %s: %s\n"), e.Path, e.Error)
+	}
+	if len(results) == 0 {
+		fmt.Printf(color.YellowString("No PRDs match '%s'\n"), text)
+		return nil
+	}
+	displaySearchResults(results)
+	return nil
+}
+
+// Show the parent/child hierarchy of PRDs in a directory
+func showTree(dir, rootID, format string) error {
+	workspace, err := prd.LoadWorkspace(dir)
+	if err != nil {
+		return err
+	}
+	hierarchy := workspace.Hierarchy(time.Now())
+
+	roots := hierarchy.Roots
+	if rootID != "" {
+		node := hierarchy.Node(rootID)
+		if node == nil {
+			return fmt.Errorf("PRD '%s' not found in %s", rootID, dir)
+		}
+		roots = []*prd.HierarchyNode{node}
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(struct {
+			Roots    []*prd.HierarchyNode   `json:"roots"`
+			Problems []prd.HierarchyProblem `json:"problems,omitempty"`
+		}{roots, hierarchy.Problems}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal hierarchy: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case "text":
+	default:
+		return fmt.Errorf("tree format '%s' not supported", format)
+	}
+
+	if len(roots) == 0 {
+		fmt.Println("No PRD files found in directory.")
+		return nil
+	}
+	fmt.Println(color.CyanString("🌳 PRD Hierarchy"))
+	displayHierarchy(roots)
+
+	if len(hierarchy.Problems) > 0 {
+		fmt.Printf(color.RedString("\n❌ %d hierarchy problems:\n"), len(hierarchy.Problems))
+		for _, problem := range hierarchy.Problems {
+			fmt.Printf("  • %s\n", problem.Message)
+		}
+	}
+	return nil
+}
+
+// loadConfig returns the effective configuration for the working directory
+func loadConfig() (*prd.Config, error) {
+	cfg, err := prd.LoadConfig(".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// Print the effective value of a configuration setting
+func getConfig(key string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	value, err := cfg.Get(key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+// Change a setting in the nearest configuration file
+func setConfig(key, value string) error {
+	path := prd.FindConfig(".")
+	if path == "" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+		path = filepath.Join(dir, prd.ConfigDir, prd.ConfigFile)
+	}
+	cfg, err := prd.ReadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Set(key, value); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if value == "" {
+		fmt.Printf(color.GreenString("✅ Removed %s from %s\n"), key, path)
+	} else {
+		fmt.Printf(color.GreenString("✅ Set %s in %s\n"), key, path)
+	}
+	return nil
+}
+
+// List all configuration settings with their effective values
+func listConfig(format string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "yaml":
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(cfg); err != nil {
+			return fmt.Errorf("failed to marshal configuration: %w", err)
+		}
+		return enc.Close()
+	case "table":
+		file := &prd.Config{}
+		if cfg.Path != "" {
+			if file, err = prd.ReadConfigFile(cfg.Path); err != nil {
+				return err
+			}
+		}
+		return displayConfig(cfg, file)
+	default:
+		return fmt.Errorf("unsupported format '%s'. Available: table, yaml", format)
 	}
-	return priority
 }
@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
@@ -100,6 +102,9 @@ func displayObjectives(prdDoc *prd.PRD) {
 		fmt.Printf("📊 Success Metrics:\n")
 		for _, metric := range prdDoc.Objectives.SuccessMetrics {
 			fmt.Printf("  • %s: %s\n", color.CyanString(metric.Metric), metric.Target)
+			if metric.Goal != nil {
+				fmt.Printf("    Goal: %s\n", metric.Goal)
+			}
 			if metric.MeasurementMethod != "" {
 				fmt.Printf("    Method: %s\n", metric.MeasurementMethod)
 			}
@@ -112,7 +117,10 @@ func displayObjectives(prdDoc *prd.PRD) {
 		for i, okr := range prdDoc.Objectives.OKRs {
 			fmt.Printf("  %s %s\n", color.CyanString(fmt.Sprintf("O%d:", i+1)), okr.Objective)
 			for j, kr := range okr.KeyResults {
-				fmt.Printf("    %s %s\n", color.GreenString(fmt.Sprintf("KR%d.%d:", i+1, j+1)), kr)
+				fmt.Printf("    %s %s\n", color.GreenString(prdDoc.Objectives.KeyResultID(i, j)+":"), kr.Description)
+				if kr.Measurable() {
+					fmt.Printf("      %s\n", kr.ProgressLabel())
+				}
 			}
 		}
 		fmt.Println()
@@ -128,7 +136,7 @@ func displayRequirements(prdDoc *prd.PRD) {
 		for _, req := range prdDoc.Requirements.Functional {
 			priority := ""
 			if req.Priority != "" {
-				priority = fmt.Sprintf(" [%s]", getPriorityWithColor(req.Priority))
+				priority = fmt.Sprintf(" [%s]", getMoSCoWWithColor(req.Priority))
 			}
 			fmt.Printf("  • %s%s\n", color.CyanString(req.ID), priority)
 			fmt.Printf("    %s\n", wrapText(req.Description, 58))
@@ -163,7 +171,7 @@ func displayUserStories(prdDoc *prd.PRD) {
 	for _, story := range prdDoc.UserStories {
 		priority := ""
 		if story.Priority != "" {
-			priority = fmt.Sprintf(" [%s]", getPriorityWithColor(story.Priority))
+			priority = fmt.Sprintf(" [%s]", getMoSCoWWithColor(story.Priority))
 		}
 		estimate := ""
 		if story.EffortEstimate != "" {
@@ -191,14 +199,18 @@ func displayTimeline(prdDoc *prd.PRD) {
 	fmt.Printf("%s\n", color.YellowString("📅 TIMELINE"))
 	fmt.Printf("─────────────────────────────────────────────────────────────\n")
 
-	if prdDoc.Timeline.LaunchDate != "" {
-		fmt.Printf("🚀 Launch Date: %s\n\n", color.GreenString(prdDoc.Timeline.LaunchDate))
+	if !prdDoc.Timeline.LaunchDate.IsZero() {
+		fmt.Printf("🚀 Launch Date: %s\n\n", color.GreenString(prdDoc.Timeline.LaunchDate.String()))
 	}
 
 	if len(prdDoc.Timeline.Milestones) > 0 {
+		now := time.Now()
 		fmt.Printf("🏁 Milestones:\n")
-		for _, milestone := range prdDoc.Timeline.Milestones {
-			fmt.Printf("  • %s - %s\n", color.CyanString(milestone.TargetDate), milestone.Name)
+		for _, milestone := range prdDoc.Timeline.SortedMilestones() {
+			fmt.Printf("  • %s - %s\n", color.CyanString(milestone.TargetDate.String()), milestone.Name)
+			if milestone.State != "" || milestone.PercentComplete != nil || !milestone.ActualStart.IsZero() || milestone.Done() {
+				fmt.Printf("    %s (%.0f%%)\n", getMilestoneStateWithColor(milestone.StateAt(now)), prdDoc.MilestoneProgress(milestone))
+			}
 			if milestone.Description != "" {
 				fmt.Printf("    %s\n", milestone.Description)
 			}
@@ -261,16 +273,9 @@ func displayRisks(prdDoc *prd.PRD) {
 	if len(prdDoc.RisksAndAssumptions.Risks) > 0 {
 		fmt.Printf("⚠️ Risks:\n")
 		for _, risk := range prdDoc.RisksAndAssumptions.Risks {
-			impactColor := color.New(color.FgYellow)
-			if risk.Impact == "high" || risk.Impact == "critical" {
-				impactColor = color.New(color.FgRed)
-			} else if risk.Impact == "low" {
-				impactColor = color.New(color.FgGreen)
-			}
-
 			fmt.Printf("  • %s [Impact: %s, Probability: %s]\n",
 				risk.Description,
-				impactColor.Sprint(risk.Impact),
+				getRiskLevelWithColor(risk.Impact),
 				risk.Probability)
 
 			if risk.MitigationStrategy != "" {
@@ -283,10 +288,104 @@ func displayRisks(prdDoc *prd.PRD) {
 	if len(prdDoc.RisksAndAssumptions.Assumptions) > 0 {
 		fmt.Printf("📋 Assumptions:\n")
 		for _, assumption := range prdDoc.RisksAndAssumptions.Assumptions {
-			fmt.Printf("  • %s\n", assumption)
+			fmt.Printf("  • %s [%s]\n", assumption.Statement, getAssumptionResultWithColor(assumption.Result))
+		}
+		fmt.Println()
+	}
+}
+
+func displayReferences(outbound, inbound []prd.Link) {
+	if len(outbound) == 0 && len(inbound) == 0 {
+		return
+	}
+
+	fmt.Printf("%s\n", color.CyanString("🔗 REFERENCES"))
+	fmt.Printf("─────────────────────────────────────────────────────────────\n")
+
+	if len(outbound) > 0 {
+		fmt.Printf("➡️ Depends on:\n")
+		for _, link := range outbound {
+			target := color.RedString("❌ %s", link.Problem)
+			if link.Problem == "" {
+				target = fmt.Sprintf("%s [%s]", link.TargetTitle, getStatusWithColor(link.TargetStatus))
+			}
+			fmt.Printf("  • %s → %s: %s\n", link.Source, link.Target, target)
 		}
 		fmt.Println()
 	}
+
+	if len(inbound) > 0 {
+		fmt.Printf("⬅️ Used by:\n")
+		for _, link := range inbound {
+			element := ""
+			if link.Target.Element != "" {
+				element = " → " + link.Target.Element
+			}
+			fmt.Printf("  • %s %s %s%s %s\n", link.From, link.Kind, link.Source, element, color.HiBlackString("(%s)", link.FromPath))
+		}
+		fmt.Println()
+	}
+}
+
+func displayHierarchy(roots []*prd.HierarchyNode) {
+	var walk func(nodes []*prd.HierarchyNode, prefix string, top bool)
+	walk = func(nodes []*prd.HierarchyNode, prefix string, top bool) {
+		for i, n := range nodes {
+			branch, indent := "├── ", "│   "
+			if i == len(nodes)-1 {
+				branch, indent = "└── ", "    "
+			}
+			if top {
+				branch, indent = "", ""
+			}
+			fmt.Printf("%s%s%s %s [%s] %s\n", prefix, branch, color.New(color.Bold).Sprint(n.ID), n.Title,
+				getStatusWithColor(n.Status), color.HiBlackString(hierarchyRollupLabel(n)))
+			walk(n.Children, prefix+indent, false)
+		}
+	}
+	walk(roots, "", true)
+}
+
+// hierarchyRollupLabel summarises a node's rollup, counting descendants
+// only for PRDs that have them
+func hierarchyRollupLabel(n *prd.HierarchyNode) string {
+	var parts []string
+	if len(n.Children) > 0 {
+		parts = append(parts, fmt.Sprintf("%d PRDs", n.Rollup.PRDs))
+	}
+	parts = append(parts, fmt.Sprintf("%d requirements", n.Rollup.Requirements))
+	if n.Rollup.Measured > 0 {
+		parts = append(parts, fmt.Sprintf("%.0f%% delivered", n.Rollup.Progress))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func displayHierarchyLinks(hierarchy *prd.Hierarchy, id string) {
+	node := hierarchy.Node(id)
+	if node == nil {
+		return
+	}
+	parent := hierarchy.Parent(id)
+	if parent == nil && len(node.Children) == 0 {
+		return
+	}
+
+	fmt.Printf("%s\n", color.CyanString("🌳 HIERARCHY"))
+	fmt.Printf("─────────────────────────────────────────────────────────────\n")
+	if parent != nil {
+		fmt.Printf("⬆️ Parent: %s %s [%s]\n", parent.ID, parent.Title, getStatusWithColor(parent.Status))
+	}
+	if len(node.Children) > 0 {
+		fmt.Printf("⬇️ Children:\n")
+		for _, child := range node.Children {
+			fmt.Printf("  • %s %s [%s] %s\n", child.ID, child.Title, getStatusWithColor(child.Status), color.HiBlackString(hierarchyRollupLabel(child)))
+		}
+		fmt.Printf("📊 Rollup: %s\n", hierarchyRollupLabel(node))
+	}
+	for _, problem := range hierarchy.ProblemsFor(id) {
+		fmt.Printf(color.RedString("❌ %s\n"), problem)
+	}
+	fmt.Println()
 }
 
 // Display PRD in table format
@@ -321,7 +420,7 @@ func displayRequirementsTable(prdDoc *prd.PRD) error {
 		err := table.Append([]string{
 			req.ID,
 			truncateString(req.Description, 40),
-			req.Priority,
+			string(req.Priority),
 			deps,
 		})
 		if err != nil {
@@ -346,7 +445,7 @@ func displayUserStoriesTable(prdDoc *prd.PRD) error {
 		err := table.Append([]string{
 			story.ID,
 			truncateString(story.Story, 35),
-			story.Priority,
+			string(story.Priority),
 			story.EffortEstimate,
 		})
 		if err != nil {
@@ -365,9 +464,10 @@ func displayMilestonesTable(prdDoc *prd.PRD) error {
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("Name", "Target Date", "Description", "Dependencies")
+	table.Header("Name", "Target Date", "State", "Progress", "Description", "Dependencies")
 
-	for _, milestone := range prdDoc.Timeline.Milestones {
+	now := time.Now()
+	for _, milestone := range prdDoc.Timeline.SortedMilestones() {
 		deps := strings.Join(milestone.Dependencies, ", ")
 		if deps == "" {
 			deps = "None"
@@ -375,7 +475,9 @@ func displayMilestonesTable(prdDoc *prd.PRD) error {
 
 		err := table.Append([]string{
 			milestone.Name,
-			milestone.TargetDate,
+			milestone.TargetDate.String(),
+			getMilestoneStateWithColor(milestone.StateAt(now)),
+			fmt.Sprintf("%.0f%%", prdDoc.MilestoneProgress(milestone)),
 			truncateString(milestone.Description, 25),
 			deps,
 		})
@@ -401,16 +503,16 @@ func displayOverviewTable(prdDoc *prd.PRD) error {
 	if err := table.Append([]string{"Version", prdDoc.Version}); err != nil {
 		return err
 	}
-	if err := table.Append([]string{"Status", prdDoc.Status}); err != nil {
+	if err := table.Append([]string{"Status", string(prdDoc.Status)}); err != nil {
 		return err
 	}
-	if err := table.Append([]string{"Priority", prdDoc.Priority}); err != nil {
+	if err := table.Append([]string{"Priority", string(prdDoc.Priority)}); err != nil {
 		return err
 	}
 	if err := table.Append([]string{"Owner", fmt.Sprintf("%s (%s)", prdDoc.Owner.Name, prdDoc.Owner.Email)}); err != nil {
 		return err
 	}
-	if err := table.Append([]string{"Created", prdDoc.CreatedDate}); err != nil {
+	if err := table.Append([]string{"Created", prdDoc.CreatedDate.String()}); err != nil {
 		return err
 	}
 
@@ -424,7 +526,467 @@ func displayOverviewTable(prdDoc *prd.PRD) error {
 	return table.Render()
 }
 
+func displayTraceMatrix(matrix *prd.TraceMatrix) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Requirement", "Priority", "Stories", "Tests", "Files")
+
+	tested := 0
+	for _, row := range matrix.Rows {
+		tests := fmt.Sprintf("%d", len(row.Tests))
+		if row.Tested() {
+			tested++
+		} else {
+			tests = color.RedString("0")
+		}
+
+		err := table.Append([]string{
+			row.Requirement,
+			string(row.Priority),
+			strings.Join(row.Stories, ", "),
+			tests,
+			fmt.Sprintf("%d", len(row.Files)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf(color.CyanString("🔗 Traceability Matrix: %s\n"), matrix.PRDID)
+	if err := table.Render(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d of %d requirements have tests\n", tested, len(matrix.Rows))
+
+	if untested := matrix.UntestedMustHaves(); len(untested) > 0 {
+		fmt.Println(color.RedString("\n❌ Untested must-have requirements:"))
+		for _, row := range untested {
+			fmt.Printf("  • %s: %s\n", row.Requirement, truncateString(row.Description, 60))
+		}
+	}
+
+	if len(matrix.Orphans) > 0 {
+		fmt.Println(color.YellowString("\n⚠️ References to unknown IDs:"))
+		for _, ref := range matrix.Orphans {
+			fmt.Printf("  • %s at %s:%d\n", ref.ID, ref.File, ref.Line)
+		}
+	}
+
+	return nil
+}
+
 // Utility functions
+func displayRiskRegister(prdDoc *prd.PRD) error {
+	ra := prdDoc.RisksAndAssumptions
+	fmt.Printf(color.CyanString("⚠️ Risk Register: %s\n\n"), prdDoc.Title)
+
+	// Probability×impact heat map, highest probability first
+	const cellWidth = 12
+	rows := ra.HeatMap()
+	fmt.Printf("%-13s", "Probability")
+	for _, cell := range rows[0].Cells {
+		fmt.Printf(" %-*s", cellWidth, cell.Impact)
+	}
+	fmt.Println()
+	for _, row := range rows {
+		fmt.Printf("%-13s", row.Probability)
+		for _, cell := range row.Cells {
+			text := fmt.Sprintf(" %-*s", cellWidth-1, truncateString(strings.Join(cell.Risks, ","), cellWidth-1))
+			fmt.Print(" " + heatMapColors[cell.Rating].Sprint(text))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%13s %s\n\n", "", color.HiBlackString("Impact →"))
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Risk", "Score", "Owner", "Status", "Review", "Trigger")
+	for i, risk := range ra.Risks {
+		score := ""
+		if risk.Score() > 0 {
+			score = fmt.Sprintf("%d %s", risk.Score(), getRiskLevelWithColor(risk.Rating()))
+		}
+		err := table.Append([]string{
+			ra.RiskID(i),
+			truncateString(risk.Description, 40),
+			score,
+			risk.Owner,
+			string(risk.Status),
+			risk.ReviewDate.String(),
+			truncateString(risk.Trigger, 30),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return table.Render()
+}
+
+func displayAssumptions(prdDoc *prd.PRD) error {
+	ra := prdDoc.RisksAndAssumptions
+	fmt.Printf(color.CyanString("🧪 Assumptions: %s\n"), prdDoc.Title)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Assumption", "Confidence", "Validation Method", "Result", "Date")
+	for i, a := range ra.Assumptions {
+		err := table.Append([]string{
+			ra.AssumptionID(i),
+			truncateString(a.Statement, 45),
+			string(a.Confidence),
+			truncateString(a.ValidationMethod, 30),
+			getAssumptionResultWithColor(a.Result),
+			a.ValidatedDate.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return table.Render()
+}
+
+func displayDeliveryProgress(progress prd.DeliveryProgress) {
+	if progress.Milestones == 0 && progress.RequirementsTracked == 0 {
+		return
+	}
+
+	fmt.Printf("\n🚚 Delivery Progress: %s %.0f%%\n", progressBar(progress.Overall, 20), progress.Overall)
+	if progress.Milestones > 0 {
+		fmt.Printf("• Milestones: %d/%d done (%.0f%%)\n", progress.MilestonesDone, progress.Milestones, progress.MilestonePercent)
+		var states []string
+		for _, state := range prd.MilestoneState("").Values() {
+			if n := progress.States[state]; n > 0 {
+				states = append(states, fmt.Sprintf("%s %d", getMilestoneStateWithColor(state), n))
+			}
+		}
+		fmt.Printf("  %s\n", strings.Join(states, ", "))
+	}
+	if progress.RequirementsTracked > 0 {
+		fmt.Printf("• Requirements: %d/%d tracked done, %d in progress (%.0f%%), %d not tracked\n",
+			progress.RequirementsDone, progress.RequirementsTracked, progress.RequirementsInProgress, progress.RequirementPercent,
+			progress.Requirements-progress.RequirementsTracked)
+	}
+	if len(progress.AtRisk) > 0 {
+		fmt.Printf(color.YellowString("⚠️ At risk or slipped: %s\n"), strings.Join(progress.AtRisk, ", "))
+	}
+}
+
+func displayOKRProgress(prdDoc *prd.PRD) {
+	objectives := prdDoc.Objectives
+	if len(objectives.OKRs) == 0 {
+		return
+	}
+
+	fmt.Printf("\n🎯 OKR Progress:\n")
+	for i, okr := range objectives.OKRs {
+		if okr.Measurable() {
+			fmt.Printf("• %s %s %.0f%%\n", okr.Objective, progressBar(okr.Progress(), 20), okr.Progress())
+		} else {
+			fmt.Printf("• %s %s\n", okr.Objective, color.YellowString("(no measurable key results)"))
+		}
+		for j, kr := range okr.KeyResults {
+			details := []string{kr.ProgressLabel()}
+			if !kr.Measurable() {
+				details[0] = color.YellowString("no target")
+			}
+			if kr.Confidence != "" {
+				details = append(details, "confidence "+getConfidenceWithColor(kr.Confidence))
+			}
+			if c, ok := kr.LastCheckIn(); ok {
+				details = append(details, "checked in "+c.Date.String())
+			}
+			fmt.Printf("  %s %s: %s\n", color.GreenString(objectives.KeyResultID(i, j)), truncateString(kr.Description, 50), strings.Join(details, " · "))
+		}
+	}
+}
+
+func displayMetricReports(reports []prd.MetricReport) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Metric", "Goal", "Latest", "Trend", "Projected", "Status")
+	for _, r := range reports {
+		goal, projected := "", ""
+		if r.Metric.Goal != nil {
+			goal = r.Metric.Goal.String()
+		}
+		if r.Projected != nil {
+			projected = r.Metric.FormatValue(math.Round(*r.Projected*100) / 100)
+		}
+		err := table.Append([]string{
+			truncateString(r.Metric.Metric, 30),
+			goal,
+			r.LatestLabel(),
+			r.Metric.Sparkline(20),
+			projected,
+			getMetricStatusWithColor(r.Status),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return table.Render()
+}
+
+func displayMetricSummary(reports []prd.MetricReport) {
+	counts := map[prd.MetricStatus]int{}
+	var behind []string
+	for _, r := range reports {
+		if r.Status == "" {
+			continue
+		}
+		counts[r.Status]++
+		if r.Status == prd.MetricOffTrack || r.Status == prd.MetricMissed {
+			behind = append(behind, r.Metric.Metric)
+		}
+	}
+	if len(counts) == 0 {
+		return
+	}
+
+	var statuses []string
+	for _, status := range prd.MetricStatus("").Values() {
+		if n := counts[status]; n > 0 {
+			statuses = append(statuses, fmt.Sprintf("%s %d", getMetricStatusWithColor(status), n))
+		}
+	}
+	fmt.Printf("\n📏 Success Metrics: %s\n", strings.Join(statuses, ", "))
+	if len(behind) > 0 {
+		fmt.Printf(color.YellowString("⚠️ Off track or missed: %s\n"), strings.Join(behind, ", "))
+	}
+}
+
+func displayPortfolioSummary(summary prd.PortfolioSummary, days int) {
+	fmt.Printf("\n📊 Portfolio: %d PRDs\n", summary.Total)
+
+	var statuses []string
+	for _, status := range prd.Status("").Values() {
+		if n := summary.ByStatus[status]; n > 0 {
+			statuses = append(statuses, fmt.Sprintf("%s %d", getStatusWithColor(status), n))
+		}
+	}
+	fmt.Printf("• By status: %s\n", strings.Join(statuses, ", "))
+
+	var priorities []string
+	for _, priority := range prd.Priority("").Values() {
+		if n := summary.ByPriority[priority]; n > 0 {
+			priorities = append(priorities, fmt.Sprintf("%s %d", getPriorityWithColor(priority), n))
+		}
+	}
+	fmt.Printf("• By priority: %s\n", strings.Join(priorities, ", "))
+
+	var teams []string
+	for _, team := range summary.Teams() {
+		name := team
+		if name == "" {
+			name = "(no team)"
+		}
+		teams = append(teams, fmt.Sprintf("%s %d", name, summary.ByTeam[team]))
+	}
+	fmt.Printf("• By team: %s\n", strings.Join(teams, ", "))
+
+	if len(summary.UpcomingLaunches) == 0 {
+		fmt.Printf("🚀 No launches in the next %d days\n", days)
+		return
+	}
+	fmt.Printf("🚀 Launches in the next %d days:\n", days)
+	for _, item := range summary.UpcomingLaunches {
+		fmt.Printf("  • %s %s (%s)\n", color.CyanString(item.LaunchDate.String()), item.Title, item.ID)
+	}
+}
+
+func displayQueryResults(results []prd.QueryResult) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("File", "ID", "Title", "Status", "Priority", "Matches")
+	for _, r := range results {
+		matches := ""
+		if len(r.Matches) > 0 {
+			matches = fmt.Sprintf("%d", len(r.Matches))
+		}
+		err := table.Append([]string{
+			r.Path,
+			r.ID,
+			truncateString(r.Title, 40),
+			getStatusWithColor(r.Status),
+			getPriorityWithColor(r.Priority),
+			matches,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf(color.CyanString("🔎 %d matching PRDs\n"), len(results))
+	return table.Render()
+}
+
+func displayQueryElements(results []prd.QueryResult) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("File", "PRD", "Path", "Element", "Value")
+	count := 0
+	for _, r := range results {
+		for _, m := range r.Matches {
+			err := table.Append([]string{r.Path, r.ID, m.Path, m.ID, truncateString(queryValueLabel(m.Value), 60)})
+			if err != nil {
+				return err
+			}
+			count++
+		}
+	}
+	if count == 0 {
+		fmt.Printf(color.YellowString("%d PRDs match, but the query names no array elements\n"), len(results))
+		return nil
+	}
+
+	fmt.Printf(color.CyanString("🔎 %d matching elements in %d PRDs\n"), count, len(results))
+	return table.Render()
+}
+
+// queryValueLabel summarises a matched value, using an element's most
+// descriptive field
+func queryValueLabel(v any) string {
+	if obj, ok := v.(map[string]any); ok {
+		for _, key := range []string{"description", "title", "name", "metric", "objective"} {
+			if s, ok := obj[key].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return strings.Join(strings.Fields(fmt.Sprint(v)), " ")
+}
+
+func displaySearchResults(results []prd.SearchResult) {
+	fmt.Printf(color.CyanString("🔎 %d matching PRDs\n"), len(results))
+	for i, r := range results {
+		fmt.Printf("\n%d. %s (%s) %s %s\n", i+1, color.New(color.Bold).Sprint(r.Title), r.ID,
+			getStatusWithColor(r.Status), color.HiBlackString("%s · score %.2f", r.Path, r.Score))
+		for _, m := range r.Matches {
+			label := m.Path
+			if m.ID != "" {
+				label = fmt.Sprintf("%s (%s)", m.Path, m.ID)
+			}
+			fmt.Printf("   %s\n     %s\n", color.BlueString(label), highlightSnippet(m))
+		}
+	}
+}
+
+// highlightSnippet colours the words of a search snippet that matched
+func highlightSnippet(m prd.SearchMatch) string {
+	var b strings.Builder
+	last := 0
+	for _, h := range m.Highlights {
+		b.WriteString(m.Snippet[last:h[0]])
+		b.WriteString(color.New(color.FgYellow, color.Bold).Sprint(m.Snippet[h[0]:h[1]]))
+		last = h[1]
+	}
+	b.WriteString(m.Snippet[last:])
+	return b.String()
+}
+
+// displayConfig lists the settings of the effective configuration, noting
+// which come from the configuration file
+func displayConfig(cfg, file *prd.Config) error {
+	if cfg.Path != "" {
+		fmt.Printf(color.CyanString("⚙️  Configuration: %s\n"), cfg.Path)
+	} else {
+		fmt.Println(color.CyanString("⚙️  No configuration file; using defaults"))
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Key", "Value", "Source")
+	for _, key := range prd.ConfigKeys() {
+		value, err := cfg.Get(key)
+		if err != nil {
+			return err
+		}
+		source := ""
+		if set, _ := file.Get(key); set != "" {
+			source = "file"
+		} else if value != "" {
+			source = "default"
+		}
+		if err := table.Append([]string{key, value, source}); err != nil {
+			return err
+		}
+	}
+	return table.Render()
+}
+
+func progressBar(percent float64, width int) string {
+	filled := min(max(int(percent/100*float64(width)+0.5), 0), width)
+	return color.GreenString(strings.Repeat("█", filled)) + strings.Repeat("░", width-filled)
+}
+
+func displayTimelineAnalysis(analysis *prd.TimelineAnalysis) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Milestone", "Target Date", "Depends On", "Slack", "Flags")
+
+	for _, m := range analysis.Milestones {
+		name := m.Name
+		if m.Critical {
+			name = color.RedString("★ " + m.Name)
+		}
+
+		var flags []string
+		if m.Overdue {
+			flags = append(flags, color.RedString("overdue"))
+		}
+		if len(m.ScheduledBefore) > 0 {
+			flags = append(flags, color.YellowString("before "+strings.Join(m.ScheduledBefore, ", ")))
+		}
+
+		slack := fmt.Sprintf("%dd", m.SlackDays)
+		if m.SlackDays < 0 {
+			slack = color.RedString(slack)
+		}
+
+		err := table.Append([]string{
+			name,
+			m.TargetDate.String(),
+			strings.Join(append(m.Dependencies, m.External...), ", "),
+			slack,
+			strings.Join(flags, ", "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(color.CyanString("📅 Timeline Analysis"))
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	path := strings.Join(analysis.CriticalPath, " → ")
+	if !analysis.LaunchDate.IsZero() {
+		path += " → 🚀 Launch"
+	}
+	fmt.Printf("\n%s %s\n", color.RedString("Critical path:"), path)
+
+	if !analysis.LaunchDate.IsZero() {
+		switch {
+		case analysis.BufferDays < 0:
+			fmt.Printf(color.RedString("❌ Last milestone is %d days after the launch date (%s)\n"), -analysis.BufferDays, analysis.LaunchDate)
+		default:
+			fmt.Printf(color.GreenString("✅ %d days of buffer before the launch date (%s)\n"), analysis.BufferDays, analysis.LaunchDate)
+		}
+	}
+
+	if len(analysis.Undated) > 0 {
+		fmt.Printf(color.YellowString("⚠️ Not scheduled (no target date): %s\n"), strings.Join(analysis.Undated, ", "))
+	}
+	return nil
+}
+
+func displaySlipProjection(projection *prd.SlipProjection) {
+	fmt.Printf(color.CyanString("\n🔮 If '%s' moves by %d days:\n"), projection.Milestone, projection.Days)
+	for _, move := range projection.Moved {
+		fmt.Printf("  • %s: %s → %s\n", move.Name, move.From.Format("2006-01-02"), move.To.Format("2006-01-02"))
+	}
+
+	if projection.LaunchSlipDays > 0 {
+		fmt.Printf(color.RedString("  🚀 Launch slips %d days: %s → %s\n"), projection.LaunchSlipDays,
+			projection.Launch.Format("2006-01-02"), projection.ProjectedLaunch.Format("2006-01-02"))
+	} else {
+		fmt.Println(color.GreenString("  🚀 Launch date holds"))
+	}
+}
+
 func wrapText(text string, width int) string {
 	if len(text) <= width {
 		return text
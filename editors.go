@@ -13,7 +13,7 @@ import (
 )
 
 // Edit basic information
-func editBasicInfo(prdDoc *prd.PRD) {
+func editBasicInfo(prdDoc *prd.PRD, cfg *prd.Config) {
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Printf("Current Title: %s\n", color.CyanString(prdDoc.Title))
@@ -28,18 +28,14 @@ func editBasicInfo(prdDoc *prd.PRD) {
 		prdDoc.Version = strings.TrimSpace(input)
 	}
 
-	fmt.Printf("Current Status: %s\n", color.CyanString(prdDoc.Status))
+	fmt.Printf("Current Status: %s\n", color.CyanString(string(prdDoc.Status)))
 	if confirmChange("Do you want to change the status? (y/n): ") {
-		prdDoc.Status = selectFromOptions("New Status", []string{
-			"draft", "review", "approved", "in_development", "completed", "archived",
-		})
+		prdDoc.Status = selectEnum("New Status", cfg.AllowedStatuses())
 	}
 
-	fmt.Printf("Current Priority: %s\n", color.CyanString(prdDoc.Priority))
+	fmt.Printf("Current Priority: %s\n", color.CyanString(string(prdDoc.Priority)))
 	if confirmChange("Do you want to change the priority? (y/n): ") {
-		prdDoc.Priority = selectFromOptions("New Priority", []string{
-			"critical", "high", "medium", "low",
-		})
+		prdDoc.Priority = selectEnum("New Priority", prd.Priority("").Values())
 	}
 
 	fmt.Println(color.GreenString("✅ Basic information updated"))
@@ -148,7 +144,7 @@ func editObjectives(prdDoc *prd.PRD) {
 }
 
 // Edit requirements
-func editRequirements(prdDoc *prd.PRD) {
+func editRequirements(prdDoc *prd.PRD, cfg *prd.Config) {
 	fmt.Println(color.YellowString("⚙️ Editing Requirements"))
 
 	// Edit functional requirements
@@ -162,11 +158,12 @@ func editRequirements(prdDoc *prd.PRD) {
 
 		switch action {
 		case "add":
-			newReqs := collectFunctionalRequirements()
+			prefix := cfg.IDs.Requirement
+			newReqs := collectFunctionalRequirements(prefix)
 			// Update IDs to be unique
 			startID := len(prdDoc.Requirements.Functional) + 1
 			for i := range newReqs {
-				newReqs[i].ID = fmt.Sprintf("FR-%03d", startID+i)
+				newReqs[i].ID = prd.NewID(prefix, startID+i)
 			}
 			prdDoc.Requirements.Functional = append(prdDoc.Requirements.Functional, newReqs...)
 
@@ -189,7 +186,7 @@ func editRequirements(prdDoc *prd.PRD) {
 			}
 
 		case "replace_all":
-			newReqs := collectFunctionalRequirements()
+			newReqs := collectFunctionalRequirements(cfg.IDs.Requirement)
 			if len(newReqs) > 0 {
 				prdDoc.Requirements.Functional = newReqs
 			}
@@ -293,9 +290,7 @@ func editSingleRequirement(req *prd.FunctionalRequirement) {
 
 	fmt.Printf("Current Priority: %s\n", req.Priority)
 	if confirmChange("Update priority? (y/n): ") {
-		req.Priority = selectFromOptions("New Priority", []string{
-			"must_have", "should_have", "could_have", "wont_have",
-		})
+		req.Priority = selectEnum("New Priority", prd.MoSCoW("").Values())
 	}
 
 	fmt.Printf("Current Dependencies: %v\n", req.Dependencies)
@@ -76,7 +76,7 @@ func createSamplePRD() *prd.PRD {
 		ID:          "PRD-DEMO-2024",
 		Title:       "Smart Task Management Feature",
 		Version:     "1.0.0",
-		CreatedDate: "2024-01-15",
+		CreatedDate: prd.MustParseDate("2024-01-15"),
 		Owner: prd.Owner{
 			Name:  "Alex Johnson",
 			Email: "alex.johnson@company.com",
@@ -202,22 +202,22 @@ func createSamplePRD() *prd.PRD {
 				{
 					Name:        "MVP Development",
 					Description: "Core task management and basic prioritization",
-					TargetDate:  "2024-03-15",
+					TargetDate:  prd.MustParseDate("2024-03-15"),
 				},
 				{
 					Name:         "AI Integration",
 					Description:  "Machine learning algorithm for intelligent prioritization",
-					TargetDate:   "2024-04-30",
+					TargetDate:   prd.MustParseDate("2024-04-30"),
 					Dependencies: []string{"MVP Development"},
 				},
 				{
 					Name:         "Calendar Integration",
 					Description:  "Integration with Google Calendar, Outlook, and Apple Calendar",
-					TargetDate:   "2024-06-01",
+					TargetDate:   prd.MustParseDate("2024-06-01"),
 					Dependencies: []string{"AI Integration"},
 				},
 			},
-			LaunchDate: "2024-07-15",
+			LaunchDate: prd.MustParseDate("2024-07-15"),
 		},
 		RisksAndAssumptions: &prd.RisksAndAssumptions{
 			Risks: []prd.Risk{
@@ -234,10 +234,10 @@ func createSamplePRD() *prd.PRD {
 					MitigationStrategy: "Early API testing and fallback solutions",
 				},
 			},
-			Assumptions: []string{
-				"Users are willing to grant calendar access permissions",
-				"Third-party APIs will remain stable during development",
-				"User adoption of AI-suggested prioritization will be high",
+			Assumptions: []prd.Assumption{
+				{Statement: "Users are willing to grant calendar access permissions", Confidence: prd.ConfidenceHigh, ValidationMethod: "Onboarding prototype test", Result: prd.AssumptionValidated},
+				{Statement: "Third-party APIs will remain stable during development", Confidence: prd.ConfidenceMedium, ValidationMethod: "API provider deprecation policy review", Result: prd.AssumptionValidated},
+				{Statement: "User adoption of AI-suggested prioritization will be high", Confidence: prd.ConfidenceLow, ValidationMethod: "Beta cohort opt-in rate", Result: prd.AssumptionValidated},
 			},
 		},
 		OutOfScope: []string{
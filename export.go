@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -12,459 +13,194 @@ import (
 )
 
 // Export PRD to Markdown
-func exportToMarkdown(prdDoc *prd.PRD, filename string) error {
-	var md strings.Builder
-
-	// Header
-	md.WriteString(fmt.Sprintf("# %s\n\n", prdDoc.Title))
-	md.WriteString("---\n\n")
-	md.WriteString(fmt.Sprintf("**Document ID:** %s  \n", prdDoc.ID))
-	md.WriteString(fmt.Sprintf("**Version:** %s  \n", prdDoc.Version))
-	md.WriteString(fmt.Sprintf("**Status:** %s  \n", prdDoc.Status))
-	if prdDoc.Priority != "" {
-		md.WriteString(fmt.Sprintf("**Priority:** %s  \n", prdDoc.Priority))
-	}
-	md.WriteString(fmt.Sprintf("**Owner:** %s (%s)  \n", prdDoc.Owner.Name, prdDoc.Owner.Email))
-	if prdDoc.Owner.Team != "" {
-		md.WriteString(fmt.Sprintf("**Team:** %s  \n", prdDoc.Owner.Team))
-	}
-	md.WriteString(fmt.Sprintf("**Created:** %s  \n", prdDoc.CreatedDate))
-	if prdDoc.LastUpdated != nil {
-		md.WriteString(fmt.Sprintf("**Last Updated:** %s  \n", prdDoc.LastUpdated.Format("2006-01-02 15:04")))
-	}
-	md.WriteString("\n---\n\n")
-
-	// Table of Contents
-	md.WriteString("## Table of Contents\n\n")
-	md.WriteString("1. [Overview](#overview)\n")
-	md.WriteString("2. [Objectives](#objectives)\n")
-	md.WriteString("3. [User Stories](#user-stories)\n")
-	md.WriteString("4. [Requirements](#requirements)\n")
-	if prdDoc.TechnicalSpecifications != nil {
-		md.WriteString("5. [Technical Specifications](#technical-specifications)\n")
-	}
-	if prdDoc.Timeline != nil {
-		md.WriteString("6. [Timeline](#timeline)\n")
-	}
-	if prdDoc.RisksAndAssumptions != nil {
-		md.WriteString("7. [Risks and Assumptions](#risks-and-assumptions)\n")
+func exportToMarkdown(prdDoc *prd.PRD, filename string, opts prd.MarkdownOptions) error {
+	if err := os.WriteFile(filename, []byte(prdDoc.ToMarkdownWithOptions(opts)), 0600); err != nil {
+		return fmt.Errorf("failed to write markdown file: %w", err)
 	}
-	md.WriteString("\n")
-
-	// Overview
-	md.WriteString("## Overview\n\n")
-	md.WriteString("### Problem Statement\n\n")
-	md.WriteString(fmt.Sprintf("%s\n\n", prdDoc.Overview.ProblemStatement))
 
-	md.WriteString("### Solution Summary\n\n")
-	md.WriteString(fmt.Sprintf("%s\n\n", prdDoc.Overview.SolutionSummary))
+	fmt.Printf(color.GreenString("✅ PRD exported to Markdown: %s\n"), filename)
+	return nil
+}
 
-	if prdDoc.Overview.TargetAudience != "" {
-		md.WriteString("### Target Audience\n\n")
-		md.WriteString(fmt.Sprintf("%s\n\n", prdDoc.Overview.TargetAudience))
+// Export PRD to HTML
+func exportToHTML(prdDoc *prd.PRD, filename string) error {
+	now := time.Now()
+	html, err := prdDoc.ToHTML(prd.HTMLOptions{GeneratedAt: &now})
+	if err != nil {
+		return err
 	}
 
-	if prdDoc.Overview.MarketContext != "" {
-		md.WriteString("### Market Context\n\n")
-		md.WriteString(fmt.Sprintf("%s\n\n", prdDoc.Overview.MarketContext))
+	if err := os.WriteFile(filename, []byte(html), 0600); err != nil {
+		return fmt.Errorf("failed to write HTML file: %w", err)
 	}
 
-	// Objectives
-	md.WriteString("## Objectives\n\n")
+	fmt.Printf(color.GreenString("✅ PRD exported to HTML: %s\n"), filename)
+	return nil
+}
 
-	if len(prdDoc.Objectives.BusinessGoals) > 0 {
-		md.WriteString("### Business Goals\n\n")
-		for _, goal := range prdDoc.Objectives.BusinessGoals {
-			md.WriteString(fmt.Sprintf("- %s\n", goal))
-		}
-		md.WriteString("\n")
+// Export PRD to DOCX
+func exportToDOCX(prdDoc *prd.PRD, filename string) error {
+	if err := prdDoc.SaveToDOCX(filename, prd.DOCXOptions{}); err != nil {
+		return fmt.Errorf("failed to write DOCX file: %w", err)
 	}
 
-	if len(prdDoc.Objectives.SuccessMetrics) > 0 {
-		md.WriteString("### Success Metrics\n\n")
-		md.WriteString("| Metric | Target | Measurement Method |\n")
-		md.WriteString("|--------|--------|-----------------|\n")
-		for _, metric := range prdDoc.Objectives.SuccessMetrics {
-			method := metric.MeasurementMethod
-			if method == "" {
-				method = "TBD"
-			}
-			md.WriteString(fmt.Sprintf("| %s | %s | %s |\n", metric.Metric, metric.Target, method))
-		}
-		md.WriteString("\n")
-	}
+	fmt.Printf(color.GreenString("✅ PRD exported to DOCX: %s\n"), filename)
+	return nil
+}
 
-	// User Stories
-	if len(prdDoc.UserStories) > 0 {
-		md.WriteString("## User Stories\n\n")
-		for _, story := range prdDoc.UserStories {
-			md.WriteString(fmt.Sprintf("### %s", story.ID))
-			if story.Priority != "" {
-				md.WriteString(fmt.Sprintf(" [%s]", story.Priority))
-			}
-			if story.EffortEstimate != "" {
-				md.WriteString(fmt.Sprintf(" (%s)", story.EffortEstimate))
-			}
-			md.WriteString("\n\n")
-			md.WriteString(fmt.Sprintf("**User Story:** %s\n\n", story.Story))
-
-			if len(story.AcceptanceCriteria) > 0 {
-				md.WriteString("**Acceptance Criteria:**\n\n")
-				for _, criteria := range story.AcceptanceCriteria {
-					md.WriteString(fmt.Sprintf("- %s\n", criteria))
-				}
-				md.WriteString("\n")
-			}
-		}
+// Export PRD to Confluence storage format
+func exportToConfluence(prdDoc *prd.PRD, filename string) error {
+	if err := os.WriteFile(filename, []byte(prdDoc.ToConfluence()), 0600); err != nil {
+		return fmt.Errorf("failed to write Confluence file: %w", err)
 	}
 
-	// Requirements
-	md.WriteString("## Requirements\n\n")
-
-	if len(prdDoc.Requirements.Functional) > 0 {
-		md.WriteString("### Functional Requirements\n\n")
-		md.WriteString("| ID | Description | Priority | Dependencies |\n")
-		md.WriteString("|----|-------------|----------|-------------|\n")
-		for _, req := range prdDoc.Requirements.Functional {
-			deps := strings.Join(req.Dependencies, ", ")
-			if deps == "" {
-				deps = "None"
-			}
-			priority := req.Priority
-			if priority == "" {
-				priority = "TBD"
-			}
-			md.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", req.ID, req.Description, priority, deps))
-		}
-		md.WriteString("\n")
-	}
+	fmt.Printf(color.GreenString("✅ PRD exported to Confluence storage format: %s\n"), filename)
+	return nil
+}
 
-	if len(prdDoc.Requirements.NonFunctional) > 0 {
-		md.WriteString("### Non-Functional Requirements\n\n")
-		md.WriteString("| ID | Category | Description | Acceptance Criteria |\n")
-		md.WriteString("|----|----------|-------------|-------------------|\n")
-		for _, req := range prdDoc.Requirements.NonFunctional {
-			criteria := req.AcceptanceCriteria
-			if criteria == "" {
-				criteria = "TBD"
-			}
-			md.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", req.ID, req.Category, req.Description, criteria))
-		}
-		md.WriteString("\n")
+// Export PRD to Jira wiki markup
+func exportToJiraWiki(prdDoc *prd.PRD, filename string) error {
+	if err := os.WriteFile(filename, []byte(prdDoc.ToJiraWiki()), 0600); err != nil {
+		return fmt.Errorf("failed to write Jira wiki file: %w", err)
 	}
 
-	// Technical Specifications
-	if prdDoc.TechnicalSpecifications != nil {
-		md.WriteString("## Technical Specifications\n\n")
+	fmt.Printf(color.GreenString("✅ PRD exported to Jira wiki markup: %s\n"), filename)
+	return nil
+}
 
-		if prdDoc.TechnicalSpecifications.ArchitectureOverview != "" {
-			md.WriteString("### Architecture Overview\n\n")
-			md.WriteString(fmt.Sprintf("%s\n\n", prdDoc.TechnicalSpecifications.ArchitectureOverview))
+// Export user stories and functional requirements as tracker import rows
+func exportToBacklog(prdDoc *prd.PRD, filename, format, mappingFile string) error {
+	mapping := prd.DefaultBacklogMapping()
+	if mappingFile != "" {
+		var err error
+		if mapping, err = prd.LoadBacklogMapping(mappingFile); err != nil {
+			return err
 		}
+	}
 
-		if prdDoc.TechnicalSpecifications.TechnologyStack != nil {
-			stack := prdDoc.TechnicalSpecifications.TechnologyStack
-			md.WriteString("### Technology Stack\n\n")
-
-			if len(stack.Frontend) > 0 {
-				md.WriteString(fmt.Sprintf("**Frontend:** %s\n\n", strings.Join(stack.Frontend, ", ")))
-			}
-			if len(stack.Backend) > 0 {
-				md.WriteString(fmt.Sprintf("**Backend:** %s\n\n", strings.Join(stack.Backend, ", ")))
-			}
-			if len(stack.Database) > 0 {
-				md.WriteString(fmt.Sprintf("**Database:** %s\n\n", strings.Join(stack.Database, ", ")))
-			}
-			if len(stack.Infrastructure) > 0 {
-				md.WriteString(fmt.Sprintf("**Infrastructure:** %s\n\n", strings.Join(stack.Infrastructure, ", ")))
-			}
-		}
+	items := prdDoc.BuildBacklog(mapping)
 
-		if len(prdDoc.TechnicalSpecifications.SecurityConsiderations) > 0 {
-			md.WriteString("### Security Considerations\n\n")
-			for _, consideration := range prdDoc.TechnicalSpecifications.SecurityConsiderations {
-				md.WriteString(fmt.Sprintf("- %s\n", consideration))
-			}
-			md.WriteString("\n")
-		}
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create backlog file: %w", err)
 	}
 
-	// Timeline
-	if prdDoc.Timeline != nil {
-		md.WriteString("## Timeline\n\n")
+	if format == "backlog-json" {
+		err = prd.WriteBacklogJSON(f, items, mapping)
+	} else {
+		err = prd.WriteBacklogCSV(f, items, mapping)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write backlog file: %w", err)
+	}
 
-		if prdDoc.Timeline.LaunchDate != "" {
-			md.WriteString(fmt.Sprintf("**Target Launch Date:** %s\n\n", prdDoc.Timeline.LaunchDate))
-		}
+	fmt.Printf(color.GreenString("✅ %d backlog items exported: %s\n"), len(items), filename)
+	return nil
+}
 
-		if len(prdDoc.Timeline.Milestones) > 0 {
-			md.WriteString("### Milestones\n\n")
-			md.WriteString("| Milestone | Target Date | Description | Dependencies |\n")
-			md.WriteString("|-----------|-------------|-------------|-------------|\n")
-			for _, milestone := range prdDoc.Timeline.Milestones {
-				deps := strings.Join(milestone.Dependencies, ", ")
-				if deps == "" {
-					deps = "None"
-				}
-				description := milestone.Description
-				if description == "" {
-					description = "TBD"
-				}
-				md.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", milestone.Name, milestone.TargetDate, description, deps))
-			}
-			md.WriteString("\n")
-		}
+// Export one Gherkin feature file per user story into a directory
+func exportToGherkin(prdDoc *prd.PRD, dir string) error {
+	features := prdDoc.ToGherkin()
+	if len(features) == 0 {
+		return fmt.Errorf("PRD has no user stories to export")
 	}
 
-	// Risks and Assumptions
-	if prdDoc.RisksAndAssumptions != nil {
-		md.WriteString("## Risks and Assumptions\n\n")
-
-		if len(prdDoc.RisksAndAssumptions.Risks) > 0 {
-			md.WriteString("### Risks\n\n")
-			md.WriteString("| Risk | Impact | Probability | Mitigation Strategy |\n")
-			md.WriteString("|------|--------|-------------|-------------------|\n")
-			for _, risk := range prdDoc.RisksAndAssumptions.Risks {
-				mitigation := risk.MitigationStrategy
-				if mitigation == "" {
-					mitigation = "TBD"
-				}
-				md.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", risk.Description, risk.Impact, risk.Probability, mitigation))
-			}
-			md.WriteString("\n")
-		}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
 
-		if len(prdDoc.RisksAndAssumptions.Assumptions) > 0 {
-			md.WriteString("### Assumptions\n\n")
-			for _, assumption := range prdDoc.RisksAndAssumptions.Assumptions {
-				md.WriteString(fmt.Sprintf("- %s\n", assumption))
-			}
-			md.WriteString("\n")
+	for _, f := range features {
+		if err := os.WriteFile(filepath.Join(dir, f.Filename), []byte(f.Content), 0600); err != nil {
+			return fmt.Errorf("failed to write feature file: %w", err)
 		}
 	}
 
-	// Out of Scope
-	if len(prdDoc.OutOfScope) > 0 {
-		md.WriteString("## Out of Scope\n\n")
-		for _, item := range prdDoc.OutOfScope {
-			md.WriteString(fmt.Sprintf("- %s\n", item))
-		}
-		md.WriteString("\n")
+	fmt.Printf(color.GreenString("✅ %d feature files exported: %s\n"), len(features), dir)
+	return nil
+}
+
+// Export API specifications as an OpenAPI document (YAML for .yaml/.yml)
+func exportToOpenAPI(prdDoc *prd.PRD, filename string) error {
+	doc, err := prdDoc.ToOpenAPI()
+	if err != nil {
+		return err
 	}
 
-	// Footer
-	md.WriteString("---\n\n")
-	md.WriteString(fmt.Sprintf("*Document generated on %s*\n", time.Now().Format("2006-01-02 15:04")))
+	ext := strings.ToLower(filepath.Ext(filename))
+	data, err := doc.Marshal(ext == ".yaml" || ext == ".yml")
+	if err != nil {
+		return err
+	}
 
-	// Write to file
-	if err := os.WriteFile(filename, []byte(md.String()), 0600); err != nil {
-		return fmt.Errorf("failed to write markdown file: %w", err)
+	if err := os.WriteFile(filename, data, 0600); err != nil {
+		return fmt.Errorf("failed to write OpenAPI file: %w", err)
 	}
 
-	fmt.Printf(color.GreenString("✅ PRD exported to Markdown: %s\n"), filename)
+	fmt.Printf(color.GreenString("✅ %d API paths exported to OpenAPI: %s\n"), len(doc.Paths), filename)
 	return nil
 }
 
-// Export PRD to HTML
-func exportToHTML(prdDoc *prd.PRD, filename string) error {
-	var html strings.Builder
-
-	// HTML Header
-	html.WriteString("<!DOCTYPE html>\n")
-	html.WriteString("<html lang=\"en\">\n")
-	html.WriteString("<head>\n")
-	html.WriteString("    <meta charset=\"UTF-8\">\n")
-	html.WriteString("    <meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\">\n")
-	html.WriteString(fmt.Sprintf("    <title>%s - PRD</title>\n", prdDoc.Title))
-	html.WriteString("    <style>\n")
-	html.WriteString(getHTMLCSS())
-	html.WriteString("    </style>\n")
-	html.WriteString("</head>\n")
-	html.WriteString("<body>\n")
-
-	// Header
-	html.WriteString("    <div class=\"header\">\n")
-	html.WriteString(fmt.Sprintf("        <h1>%s</h1>\n", prdDoc.Title))
-	html.WriteString("        <div class=\"metadata\">\n")
-	html.WriteString(fmt.Sprintf("            <span class=\"badge\">%s</span>\n", prdDoc.Status))
-	if prdDoc.Priority != "" {
-		html.WriteString(fmt.Sprintf("            <span class=\"badge priority-%s\">%s</span>\n", prdDoc.Priority, prdDoc.Priority))
+// Export PRD milestones as a Gantt chart. The output extension selects the
+// format: .mmd or .mermaid for Mermaid, .puml or .plantuml for PlantUML, and
+// SVG otherwise.
+func exportToGantt(prdDoc *prd.PRD, filename string) error {
+	now := time.Now()
+	opts := prd.GanttOptions{Today: &now}
+
+	var chart, kind string
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".mmd", ".mermaid":
+		chart, err = prdDoc.ToMermaidGantt(opts)
+		kind = "Mermaid"
+	case ".puml", ".plantuml":
+		chart, err = prdDoc.ToPlantUMLGantt(opts)
+		kind = "PlantUML"
+	default:
+		chart, err = prdDoc.ToGanttSVG(opts)
+		kind = "SVG"
 	}
-	html.WriteString("        </div>\n")
-	html.WriteString("    </div>\n\n")
-
-	// Document Info
-	html.WriteString("    <div class=\"doc-info\">\n")
-	html.WriteString("        <div class=\"info-grid\">\n")
-	html.WriteString(fmt.Sprintf("            <div><strong>ID:</strong> %s</div>\n", prdDoc.ID))
-	html.WriteString(fmt.Sprintf("            <div><strong>Version:</strong> %s</div>\n", prdDoc.Version))
-	html.WriteString(fmt.Sprintf("            <div><strong>Owner:</strong> %s</div>\n", prdDoc.Owner.Name))
-	html.WriteString(fmt.Sprintf("            <div><strong>Created:</strong> %s</div>\n", prdDoc.CreatedDate))
-	html.WriteString("        </div>\n")
-	html.WriteString("    </div>\n\n")
-
-	// Overview
-	html.WriteString("    <section class=\"section\">\n")
-	html.WriteString("        <h2>Overview</h2>\n")
-	html.WriteString("        <div class=\"subsection\">\n")
-	html.WriteString("            <h3>Problem Statement</h3>\n")
-	html.WriteString(fmt.Sprintf("            <p>%s</p>\n", prdDoc.Overview.ProblemStatement))
-	html.WriteString("        </div>\n")
-	html.WriteString("        <div class=\"subsection\">\n")
-	html.WriteString("            <h3>Solution Summary</h3>\n")
-	html.WriteString(fmt.Sprintf("            <p>%s</p>\n", prdDoc.Overview.SolutionSummary))
-	html.WriteString("        </div>\n")
-	html.WriteString("    </section>\n\n")
-
-	// Objectives
-	html.WriteString("    <section class=\"section\">\n")
-	html.WriteString("        <h2>Objectives</h2>\n")
-	if len(prdDoc.Objectives.BusinessGoals) > 0 {
-		html.WriteString("        <div class=\"subsection\">\n")
-		html.WriteString("            <h3>Business Goals</h3>\n")
-		html.WriteString("            <ul>\n")
-		for _, goal := range prdDoc.Objectives.BusinessGoals {
-			html.WriteString(fmt.Sprintf("                <li>%s</li>\n", goal))
-		}
-		html.WriteString("            </ul>\n")
-		html.WriteString("        </div>\n")
+	if err != nil {
+		return err
 	}
-	html.WriteString("    </section>\n\n")
-
-	// Requirements
-	html.WriteString("    <section class=\"section\">\n")
-	html.WriteString("        <h2>Requirements</h2>\n")
-	if len(prdDoc.Requirements.Functional) > 0 {
-		html.WriteString("        <div class=\"subsection\">\n")
-		html.WriteString("            <h3>Functional Requirements</h3>\n")
-		html.WriteString("            <table>\n")
-		html.WriteString("                <thead>\n")
-		html.WriteString("                    <tr><th>ID</th><th>Description</th><th>Priority</th></tr>\n")
-		html.WriteString("                </thead>\n")
-		html.WriteString("                <tbody>\n")
-		for _, req := range prdDoc.Requirements.Functional {
-			priority := req.Priority
-			if priority == "" {
-				priority = "TBD"
-			}
-			html.WriteString(fmt.Sprintf("                    <tr><td>%s</td><td>%s</td><td>%s</td></tr>\n", req.ID, req.Description, priority))
-		}
-		html.WriteString("                </tbody>\n")
-		html.WriteString("            </table>\n")
-		html.WriteString("        </div>\n")
+
+	if err := os.WriteFile(filename, []byte(chart), 0600); err != nil {
+		return fmt.Errorf("failed to write Gantt chart: %w", err)
+	}
+
+	fmt.Printf(color.GreenString("✅ Gantt chart exported to %s: %s\n"), kind, filename)
+	return nil
+}
+
+// Export all PRDs under a directory (or a single PRD file) to a static HTML
+// site, finding them as the list command does
+func exportToSite(source, dir string) error {
+	portfolio, err := prd.LoadPortfolio(source, prd.DefaultPortfolioOptions())
+	if err != nil {
+		return err
+	}
+	for _, e := range portfolio.Errors {
+		fmt.Printf(color.YellowString("⚠️ Skipping %s: %s\n"), e.Path, e.Error)
 	}
-	html.WriteString("    </section>\n\n")
 
-	// Footer
-	html.WriteString("    <footer>\n")
-	html.WriteString(fmt.Sprintf("        <p>Generated on %s</p>\n", time.Now().Format("January 2, 2006 at 3:04 PM")))
-	html.WriteString("    </footer>\n")
+	docs := make([]*prd.PRD, 0, len(portfolio.Items))
+	for _, item := range portfolio.Items {
+		docs = append(docs, item.PRD)
+	}
 
-	html.WriteString("</body>\n")
-	html.WriteString("</html>\n")
+	if len(docs) == 0 {
+		return fmt.Errorf("no PRD files found in %s", source)
+	}
 
-	// Write to file
-	if err := os.WriteFile(filename, []byte(html.String()), 0600); err != nil {
-		return fmt.Errorf("failed to write HTML file: %w", err)
+	now := time.Now()
+	if err := prd.BuildSite(dir, docs, prd.SiteOptions{GeneratedAt: &now}); err != nil {
+		return err
 	}
 
-	fmt.Printf(color.GreenString("✅ PRD exported to HTML: %s\n"), filename)
+	fmt.Printf(color.GreenString("✅ %d PRDs exported to static site: %s\n"), len(docs), filepath.Join(dir, "index.html"))
 	return nil
 }
-
-func getHTMLCSS() string {
-	return `
-        body {
-            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
-            line-height: 1.6;
-            max-width: 1200px;
-            margin: 0 auto;
-            padding: 20px;
-            color: #333;
-        }
-        .header {
-            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
-            color: white;
-            padding: 2rem;
-            border-radius: 10px;
-            margin-bottom: 2rem;
-            text-align: center;
-        }
-        .header h1 {
-            margin: 0;
-            font-size: 2.5rem;
-        }
-        .metadata {
-            margin-top: 1rem;
-        }
-        .badge {
-            display: inline-block;
-            padding: 0.25rem 0.75rem;
-            background: rgba(255,255,255,0.2);
-            border-radius: 20px;
-            font-size: 0.875rem;
-            margin: 0 0.5rem;
-            text-transform: uppercase;
-        }
-        .priority-critical { background: #e74c3c; }
-        .priority-high { background: #e67e22; }
-        .priority-medium { background: #f39c12; }
-        .priority-low { background: #27ae60; }
-        .doc-info {
-            background: #f8f9fa;
-            padding: 1.5rem;
-            border-radius: 8px;
-            margin-bottom: 2rem;
-        }
-        .info-grid {
-            display: grid;
-            grid-template-columns: repeat(auto-fit, minmax(200px, 1fr));
-            gap: 1rem;
-        }
-        .section {
-            margin-bottom: 3rem;
-        }
-        .section h2 {
-            color: #2c3e50;
-            border-bottom: 3px solid #3498db;
-            padding-bottom: 0.5rem;
-        }
-        .subsection {
-            margin: 2rem 0;
-        }
-        .subsection h3 {
-            color: #34495e;
-        }
-        table {
-            width: 100%;
-            border-collapse: collapse;
-            margin: 1rem 0;
-        }
-        th, td {
-            text-align: left;
-            padding: 0.75rem;
-            border-bottom: 1px solid #ddd;
-        }
-        th {
-            background: #f4f4f4;
-            font-weight: 600;
-        }
-        tr:hover {
-            background: #f9f9f9;
-        }
-        ul, ol {
-            padding-left: 2rem;
-        }
-        li {
-            margin: 0.5rem 0;
-        }
-        footer {
-            margin-top: 3rem;
-            padding-top: 2rem;
-            border-top: 1px solid #eee;
-            text-align: center;
-            color: #666;
-            font-size: 0.9rem;
-        }
-    `
-}
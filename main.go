@@ -3,8 +3,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/grokify/product-artifacts/prd"
+	"github.com/grokify/product-artifacts/tracker"
 )
 
 var rootCmd = &cobra.Command{
@@ -22,6 +26,167 @@ func main() {
 	}
 }
 
+// Timeline commands
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Analyze PRD timelines",
+	Long:  `Reason about milestone target dates and dependencies.`,
+}
+
+var timelineAnalyzeCmd = &cobra.Command{
+	Use:   "analyze <filename>",
+	Short: "Show the critical path, slack and scheduling problems",
+	Long: `Schedule milestones by their dependencies, which name other milestones,
+and show each milestone's slack before it would delay the launch. The
+critical path is highlighted, along with milestones due before their
+dependencies and overdue milestones.
+
+Use --slip to project the effect of moving a milestone, either by a number
+of days or weeks ("Beta Testing=10d", "Beta Testing=2w") or to a new date
+("Beta Testing=2024-05-01").`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slips, _ := cmd.Flags().GetStringArray("slip")
+		return analyzeTimeline(args[0], slips)
+	},
+}
+
+// Risks command
+var risksCmd = &cobra.Command{
+	Use:   "risks <filename>",
+	Short: "Show the risk register and heat map",
+	Long: `Show a probability×impact heat map of open risks and the risk register
+with each risk's score (impact × probability, 1-16), owner, status, review
+date and trigger. Risks scoring 8 or more without an owner or mitigation
+strategy, and risks past their review date, are listed as warnings.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showRisks(args[0])
+	},
+}
+
+// Assumptions command
+var assumptionsCmd = &cobra.Command{
+	Use:   "assumptions <filename>",
+	Short: "Report assumptions and how they were validated",
+	Long: `List the PRD's assumptions with their confidence, validation method and
+result, followed by the assumptions that are not yet validated. A PRD that is
+approved, in development or completed fails validation while any assumption is
+unvalidated; older PRDs whose assumptions are all plain strings only get a
+warning.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		failUnvalidated, _ := cmd.Flags().GetBool("fail-unvalidated")
+		return showAssumptions(args[0], failUnvalidated)
+	},
+}
+
+// OKR commands
+var okrCmd = &cobra.Command{
+	Use:   "okr",
+	Short: "Track OKR key results",
+	Long:  `Record progress on the key results of a PRD's objectives.`,
+}
+
+var okrUpdateCmd = &cobra.Command{
+	Use:   "update <filename> <key-result> <value>",
+	Short: "Record a check-in for a key result",
+	Long: `Record the measured value of a key result, identified by its ID or by its
+default ID KR<objective>.<key result> (e.g. KR1.2). Check-ins are kept in date
+order as the key result's history, and the latest one sets its current value
+and, when given, its confidence.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confidence, _ := cmd.Flags().GetString("confidence")
+		note, _ := cmd.Flags().GetString("note")
+		date, _ := cmd.Flags().GetString("date")
+		return updateKeyResult(args[0], args[1], args[2], confidence, note, date)
+	},
+}
+
+// Metrics commands
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Measure success metrics against their goals",
+	Long:  `Ingest measurement data for success metrics and report progress against their goals.`,
+}
+
+var metricsIngestCmd = &cobra.Command{
+	Use:   "ingest <filename> [data-file]",
+	Short: "Ingest a CSV or JSON metric time series",
+	Long: `Merge measurements from a CSV or JSON file into the PRD's success metrics.
+
+CSV files need a header row with date and value columns, and JSON files an
+array of {"date": ..., "value": ...} objects. Rows are added to the metric
+named in their metric column or field, or to the metric chosen with
+--metric. A measurement on a date that already has one replaces it.
+
+The data file is recorded as each metric's data source. Without a data file,
+every metric is re-ingested from its recorded data source.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metric, _ := cmd.Flags().GetString("metric")
+		source := ""
+		if len(args) > 1 {
+			source = args[1]
+		}
+		return ingestMetrics(args[0], source, metric)
+	},
+}
+
+var metricsReportCmd = &cobra.Command{
+	Use:   "report <filename>",
+	Short: "Report success metrics as on or off track",
+	Long: `Show each success metric's latest value, a sparkline of recent
+measurements and its status against its goal: met, on track (the trend
+reaches the goal by its deadline), off track, missed (the deadline passed) or
+no data.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showMetrics(args[0])
+	},
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change workspace configuration",
+	Long: `Show and change the workspace configuration in .prd/config.yaml, found by
+walking up from the working directory. It sets defaults for new PRDs (owner,
+team, ID prefixes, templates), the lint rules validate runs, export defaults
+and the statuses PRDs may have. Settings are dotted keys such as
+defaults.owner or ids.requirement; lists are comma-separated.`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getConfig(args[0])
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting in the configuration file",
+	Long: `Change a setting in the nearest .prd/config.yaml, creating one in the
+working directory if there is none. An empty value removes the setting.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setConfig(args[0], args[1])
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings with their effective values",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return listConfig(format)
+	},
+}
+
 func init() {
 	// Add all subcommands
 	rootCmd.AddCommand(createCmd)
@@ -32,6 +197,18 @@ func init() {
 	rootCmd.AddCommand(templateCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(traceCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(timelineCmd)
+	rootCmd.AddCommand(risksCmd)
+	rootCmd.AddCommand(assumptionsCmd)
+	rootCmd.AddCommand(okrCmd)
+	rootCmd.AddCommand(metricsCmd)
+	rootCmd.AddCommand(queryCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(treeCmd)
+	rootCmd.AddCommand(configCmd)
 }
 
 // Create command
@@ -63,14 +240,36 @@ var createCmd = &cobra.Command{
 var listCmd = &cobra.Command{
 	Use:   "list [directory]",
 	Short: "List all PRD documents",
-	Long:  `List all PRD documents in the specified directory or current directory.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `List all PRD documents in the specified directory or current directory,
+including subdirectories, with a portfolio summary: counts by status, priority
+and owner team, and the launches coming up. Files that look like PRDs but
+cannot be loaded are reported; other JSON files are ignored.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir := "."
 		if len(args) > 0 {
 			dir = args[0]
 		}
-		return listPRDs(dir)
+		format, _ := cmd.Flags().GetString("format")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		statuses, _ := cmd.Flags().GetStringSlice("status")
+		priorities, _ := cmd.Flags().GetStringSlice("priority")
+		teams, _ := cmd.Flags().GetStringSlice("team")
+		owner, _ := cmd.Flags().GetString("owner")
+		sortKey, _ := cmd.Flags().GetString("sort")
+		reverse, _ := cmd.Flags().GetBool("reverse")
+		days, _ := cmd.Flags().GetInt("launch-window")
+		return listPRDs(dir, listOptions{
+			format:     format,
+			recursive:  recursive,
+			statuses:   statuses,
+			priorities: priorities,
+			teams:      teams,
+			owner:      owner,
+			sortKey:    sortKey,
+			reverse:    reverse,
+			days:       days,
+		})
 	},
 }
 
@@ -78,12 +277,16 @@ var listCmd = &cobra.Command{
 var viewCmd = &cobra.Command{
 	Use:   "view <filename>",
 	Short: "View a PRD document",
-	Long:  `View the contents of a PRD document in various formats.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `View the contents of a PRD document in various formats.
+
+The pretty format ends with the PRD's references: its dependencies on other
+PRDs in the workspace, and the PRDs that depend on it.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, _ := cmd.Flags().GetString("format")
 		section, _ := cmd.Flags().GetString("section")
-		return viewPRD(args[0], format, section)
+		workspace, _ := cmd.Flags().GetString("workspace")
+		return viewPRD(args[0], format, section, workspace)
 	},
 }
 
@@ -103,11 +306,23 @@ var editCmd = &cobra.Command{
 var validateCmd = &cobra.Command{
 	Use:   "validate <filename>",
 	Short: "Validate a PRD document",
-	Long:  `Validate a PRD document against the schema and business rules.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Validate a PRD document against the schema and business rules.
+
+With --openapi, every API specification must exist in the given OpenAPI
+document. Spec endpoints not covered by the PRD and differing descriptions
+are reported as warnings.
+
+Dependencies on other PRDs ("PRD-042" or "PRD-042#FR-007") are resolved
+against the workspace: the nearest directory above the PRD with a .prd or .git
+directory, or --workspace. Each must name a PRD that exists and is not
+archived, and an element that PRD has. The PRD's parent and children must be
+in the workspace, and it cannot be approved before its parent.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		strict, _ := cmd.Flags().GetBool("strict")
-		return validatePRD(args[0], strict)
+		openapi, _ := cmd.Flags().GetString("openapi")
+		workspace, _ := cmd.Flags().GetString("workspace")
+		return validatePRD(args[0], strict, openapi, workspace)
 	},
 }
 
@@ -131,35 +346,267 @@ var statusCmd = &cobra.Command{
 
 // Export command
 var exportCmd = &cobra.Command{
-	Use:   "export <filename>",
+	Use:   "export <filename|directory>",
 	Short: "Export PRD to different formats",
-	Long:  `Export a PRD document to various formats like Markdown, HTML, or PDF.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Export a PRD document to various formats like Markdown, HTML, or Word (DOCX).
+
+With --format site, the argument may be a directory of PRD files. A static,
+offline HTML site with an index page, client-side search, and one page per
+PRD is written to the output directory (default "site").
+
+With --format gherkin, one .feature file per user story is written to the
+output directory.
+
+With --format gantt, milestones are drawn as a Gantt chart. The output
+extension selects Mermaid (.mmd), PlantUML (.puml) or SVG (default).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := applyExportConfig(cmd, cfg); err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		opts, err := exportOptionsFromFlags(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		return exportPRD(args[0], format, output, opts)
+	},
+}
+
+// Sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync <filename>",
+	Short: "Sync user stories and requirements with an issue tracker",
+	Long: `Create GitHub issues for user stories and functional requirements that
+are not yet linked, store the issue number back in the PRD, and pull issue
+status to report per-requirement delivery progress.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, _ := cmd.Flags().GetString("repo")
+		token, _ := cmd.Flags().GetString("token")
+		baseURL, _ := cmd.Flags().GetString("base-url")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		update, _ := cmd.Flags().GetBool("update")
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+		return syncPRD(args[0], repo, token, baseURL, dryRun, update)
+	},
+}
+
+// Import command
+var importCmd = &cobra.Command{
+	Use:   "import <filename> <source>",
+	Short: "Import content from external formats into a PRD",
+	Long: `Import content from an external document into an existing PRD.
+
+With --from openapi, every operation in an OpenAPI 3.x document (JSON or
+YAML) is added to the PRD's API specifications. Entries with the same method
+and endpoint are replaced.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		return importIntoPRD(args[0], from, args[1])
+	},
+}
+
+// Trace command
+var traceCmd = &cobra.Command{
+	Use:   "trace <filename> [source-dir]",
+	Short: "Build a requirements traceability matrix from a source tree",
+	Long: `Scan a source tree (default ".") for user story and requirement IDs in
+code, comments, test names and Gherkin tags, and report which requirements
+are covered by tests. Untested must-have requirements and references to
+unknown IDs are listed after the matrix.`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		source := "."
+		if len(args) > 1 {
+			source = args[1]
+		}
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
-		return exportPRD(args[0], format, output)
+		failUntested, _ := cmd.Flags().GetBool("fail-untested")
+		return tracePRD(args[0], source, format, output, failUntested)
+	},
+}
+
+// Query command
+var queryCmd = &cobra.Command{
+	Use:   "query <expression> [file|directory]",
+	Short: "Find PRDs and elements matching a query",
+	Long: `Find the PRDs in a file or directory that match a query expression over
+their JSON fields, for example:
+
+  status = approved and requirements.functional[priority = must_have].description ~ "SSO"
+
+Paths use the PRD's JSON field names. A [predicate] or [index] narrows an
+array to matching elements. Operators are =, != (case-insensitive), ~, !~
+(contains), <, <=, >, >=, combined with and, or, not and parentheses.
+With --elements the matching requirements, stories and other array elements
+are listed with their paths.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 1 {
+			path = args[1]
+		}
+		format, _ := cmd.Flags().GetString("format")
+		elements, _ := cmd.Flags().GetBool("elements")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		return queryPRDs(args[0], path, format, elements, recursive)
+	},
+}
+
+// Search command
+var searchCmd = &cobra.Command{
+	Use:   "search <text> [directory]",
+	Short: "Full-text search across PRDs",
+	Long: `Search the text of every PRD in a directory tree, ranking the PRDs that
+contain all the words and showing snippets from the fields that matched.
+
+Searches use an index kept in .prd/index.json under the directory. Each run
+re-reads only files whose modification time or size changed, and re-indexes
+only those whose content changed.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 1 {
+			dir = args[1]
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		format, _ := cmd.Flags().GetString("format")
+		indexPath, _ := cmd.Flags().GetString("index")
+		rebuild, _ := cmd.Flags().GetBool("rebuild")
+		return searchPRDs(args[0], dir, indexPath, format, limit, rebuild)
+	},
+}
+
+// Tree command
+var treeCmd = &cobra.Command{
+	Use:   "tree [directory]",
+	Short: "Show the parent/child hierarchy of PRDs",
+	Long: `Show how the PRDs in a directory tree break down into each other, from
+their parent and children fields, with each PRD's status and a rollup of the
+PRDs, requirements and delivery progress beneath it.
+
+Problems are listed after the tree: parents or children that are not in the
+directory, conflicting parents, cycles, and children approved before their
+parent.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		root, _ := cmd.Flags().GetString("root")
+		format, _ := cmd.Flags().GetString("format")
+		return showTree(dir, root, format)
 	},
 }
 
 func init() {
+	// List command flags
+	listCmd.Flags().StringP("format", "f", "table", "Output format (table, json, csv, markdown)")
+	listCmd.Flags().BoolP("recursive", "r", true, "Include PRDs in subdirectories")
+	listCmd.Flags().StringSlice("status", nil, "Only PRDs with these statuses")
+	listCmd.Flags().StringSlice("priority", nil, "Only PRDs with these priorities")
+	listCmd.Flags().StringSlice("team", nil, "Only PRDs whose owner is on these teams")
+	listCmd.Flags().String("owner", "", "Only PRDs whose owner name or email contains this text")
+	listCmd.Flags().String("sort", "path", "Sort by "+strings.Join(prd.PortfolioSortKeys, ", "))
+	listCmd.Flags().Bool("reverse", false, "Reverse the sort order")
+	listCmd.Flags().Int("launch-window", 90, "Days ahead to look for upcoming launches")
+
 	// Create command flags
 	createCmd.Flags().BoolP("interactive", "i", false, "Use interactive wizard")
-	createCmd.Flags().StringP("template", "t", "", "Create from template (basic, feature, epic)")
+	createCmd.Flags().StringP("template", "t", "", "Create from template (basic, feature, epic, or one in the configured template paths)")
 
 	// View command flags
 	viewCmd.Flags().StringP("format", "f", "pretty", "Output format (pretty, json, table)")
 	viewCmd.Flags().StringP("section", "s", "", "View specific section (overview, requirements, etc.)")
+	viewCmd.Flags().String("workspace", "", "Directory of PRDs to resolve references in (default: nearest with .prd or .git)")
 
 	// Edit command flags
 	editCmd.Flags().StringP("section", "s", "", "Edit specific section")
 
 	// Validate command flags
 	validateCmd.Flags().BoolP("strict", "", false, "Use strict validation mode")
+	validateCmd.Flags().String("openapi", "", "Check API specifications against an OpenAPI file (JSON or YAML)")
+	validateCmd.Flags().String("workspace", "", "Directory of PRDs to resolve references in (default: nearest with .prd or .git)")
+
+	// Export command flags, with Markdown defaults shared with ToMarkdown
+	markdownDefaults := prd.DefaultMarkdownOptions()
+	exportCmd.Flags().StringP("format", "f", "markdown", "Export format (markdown, html, docx, confluence, jira-wiki, backlog-csv, backlog-json, gherkin, openapi, gantt, site)")
+	exportCmd.Flags().StringP("output", "o", "", "Output filename (or directory for site)")
+	exportCmd.Flags().Bool("toc", markdownDefaults.IncludeTOC, "Include a table of contents (markdown)")
+	exportCmd.Flags().String("sections", "", "Comma-separated sections to include (markdown)")
+	exportCmd.Flags().String("exclude-sections", "", "Comma-separated sections to exclude (markdown)")
+	exportCmd.Flags().Int("heading-offset", markdownDefaults.HeadingOffset, "Shift heading levels down by N (markdown)")
+	exportCmd.Flags().String("style", string(markdownDefaults.Style), "Tabular data style: table or list (markdown)")
+	exportCmd.Flags().String("flavor", string(markdownDefaults.Flavor), "Markdown flavor: gfm or commonmark (markdown)")
+	exportCmd.Flags().Bool("footer", markdownDefaults.GeneratedAt != nil, "Add a footer with the generation time (markdown)")
+	exportCmd.Flags().String("mapping", "", "JSON field mapping file (backlog-csv, backlog-json)")
+
+	// Sync command flags
+	syncCmd.Flags().String("repo", "", "GitHub repository (owner/name)")
+	syncCmd.Flags().String("token", "", "GitHub token (defaults to $GITHUB_TOKEN)")
+	syncCmd.Flags().String("base-url", tracker.DefaultGitHubBaseURL, "GitHub API base URL")
+	syncCmd.Flags().Bool("dry-run", false, "Show what would be created without calling the tracker")
+	syncCmd.Flags().Bool("update", false, "Push title and body changes to linked issues")
+	_ = syncCmd.MarkFlagRequired("repo")
+
+	// Import command flags
+	importCmd.Flags().String("from", "", "Source format (openapi)")
+	_ = importCmd.MarkFlagRequired("from")
+
+	// Trace command flags
+	traceCmd.Flags().StringP("format", "f", "table", "Output format (table, csv, html)")
+	traceCmd.Flags().StringP("output", "o", "", "Output filename (csv, html)")
+	traceCmd.Flags().Bool("fail-untested", false, "Exit with an error if any must-have requirement is untested")
+
+	// Timeline command flags
+	timelineAnalyzeCmd.Flags().StringArray("slip", nil, "Project moving a milestone: \"<name>=<days>d|<weeks>w|<date>\" (repeatable)")
+	timelineCmd.AddCommand(timelineAnalyzeCmd)
+
+	// Assumptions command flags
+	assumptionsCmd.Flags().Bool("fail-unvalidated", false, "Exit with an error if any assumption is not validated")
+
+	// OKR command flags
+	okrUpdateCmd.Flags().String("confidence", "", "Confidence that the target will be met (low, medium, high)")
+	okrUpdateCmd.Flags().String("note", "", "Context for the check-in")
+	okrUpdateCmd.Flags().String("date", "", "Date of the measurement (defaults to today)")
+	okrCmd.AddCommand(okrUpdateCmd)
+
+	// Metrics command flags
+	metricsIngestCmd.Flags().String("metric", "", "Success metric for rows that name no metric")
+	metricsCmd.AddCommand(metricsIngestCmd)
+	metricsCmd.AddCommand(metricsReportCmd)
+
+	// Query command flags
+	queryCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	queryCmd.Flags().BoolP("elements", "e", false, "List the matching elements with their paths")
+	queryCmd.Flags().BoolP("recursive", "r", true, "Include PRDs in subdirectories")
+
+	// Search command flags
+	searchCmd.Flags().IntP("limit", "n", 10, "Maximum number of PRDs to show")
+	searchCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	searchCmd.Flags().String("index", "", "Index file (default <directory>/.prd/index.json)")
+	searchCmd.Flags().Bool("rebuild", false, "Rebuild the index from scratch")
+
+	// Tree command flags
+	treeCmd.Flags().String("root", "", "Only show the PRD with this ID and its descendants")
+	treeCmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
 
-	// Export command flags
-	exportCmd.Flags().StringP("format", "f", "markdown", "Export format (markdown, html, pdf)")
-	exportCmd.Flags().StringP("output", "o", "", "Output filename")
+	// Config command flags
+	configListCmd.Flags().StringP("format", "f", "table", "Output format (table, yaml)")
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
 
 	// Template subcommands
 	templateCmd.AddCommand(templateListCmd)
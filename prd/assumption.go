@@ -0,0 +1,157 @@
+package prd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// UnmarshalJSON also accepts a plain string, read as the statement of an
+// untested assumption, so PRDs written before assumptions were structured
+// still load
+func (a *Assumption) UnmarshalJSON(data []byte) error {
+	var statement string
+	if err := json.Unmarshal(data, &statement); err == nil {
+		*a = Assumption{Statement: statement}
+		return nil
+	}
+
+	type assumption Assumption
+	var v assumption
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*a = Assumption(v)
+	return nil
+}
+
+// Validated reports whether the assumption has been tested and held
+func (a Assumption) Validated() bool {
+	return a.Result == AssumptionValidated
+}
+
+// AssumptionID returns the ID of the i-th assumption, or "A<n>" if it has
+// none
+func (ra *RisksAndAssumptions) AssumptionID(i int) string {
+	if id := ra.Assumptions[i].ID; id != "" {
+		return id
+	}
+	return fmt.Sprintf("A%d", i+1)
+}
+
+// UnvalidatedAssumptions returns the assumptions that have not been
+// validated, with default IDs filled in. Invalidated and inconclusive
+// assumptions are included: they too must be resolved before approval.
+func (p *PRD) UnvalidatedAssumptions() []Assumption {
+	if p.RisksAndAssumptions == nil {
+		return nil
+	}
+
+	var unvalidated []Assumption
+	for i, a := range p.RisksAndAssumptions.Assumptions {
+		if !a.Validated() {
+			a.ID = p.RisksAndAssumptions.AssumptionID(i)
+			unvalidated = append(unvalidated, a)
+		}
+	}
+	return unvalidated
+}
+
+// plain reports whether the assumption is only a statement, as written
+// before assumptions were structured
+func (a Assumption) plain() bool {
+	return a == Assumption{Statement: a.Statement}
+}
+
+// plainAssumptions reports whether every assumption of the PRD is only a
+// statement, as in documents written before assumptions were structured
+func (p *PRD) plainAssumptions() bool {
+	if p.RisksAndAssumptions == nil {
+		return true
+	}
+	for _, a := range p.RisksAndAssumptions.Assumptions {
+		if !a.plain() {
+			return false
+		}
+	}
+	return true
+}
+
+// unvalidatedAssumptionsMessage describes the unvalidated assumptions of a
+// PRD that is approved or later, or returns "" if there are none
+func (p *PRD) unvalidatedAssumptionsMessage() string {
+	if !approvedOrLater(p.Status) {
+		return ""
+	}
+	unvalidated := p.UnvalidatedAssumptions()
+	if len(unvalidated) == 0 {
+		return ""
+	}
+	ids := make([]string, len(unvalidated))
+	for i, a := range unvalidated {
+		ids[i] = a.ID
+	}
+	return fmt.Sprintf("PRD is %s but %d assumptions are not validated: %s", p.Status, len(ids), strings.Join(ids, ", "))
+}
+
+// validateAssumptions checks assumption values, and blocks approval, being
+// in development or completion while assumptions are not validated. PRDs
+// whose assumptions are all plain statements are only warned about, by
+// CheckAssumptions.
+func (p *PRD) validateAssumptions() error {
+	ra := p.RisksAndAssumptions
+	for i, a := range ra.Assumptions {
+		if a.Confidence != "" && !a.Confidence.Valid() {
+			return fmt.Errorf("invalid confidence for assumption %s: %s", ra.AssumptionID(i), a.Confidence)
+		}
+		if a.Result != "" && !a.Result.Valid() {
+			return fmt.Errorf("invalid result for assumption %s: %s", ra.AssumptionID(i), a.Result)
+		}
+	}
+	if !p.plainAssumptions() {
+		if msg := p.unvalidatedAssumptionsMessage(); msg != "" {
+			return errors.New(msg)
+		}
+	}
+	return nil
+}
+
+// CheckAssumptions returns a warning when a PRD is approved or later while
+// some of its assumptions are not validated, for PRDs whose assumptions are
+// all plain statements. Structured assumptions fail validation instead.
+func (p *PRD) CheckAssumptions() []string {
+	if !p.plainAssumptions() {
+		return nil
+	}
+	if msg := p.unvalidatedAssumptionsMessage(); msg != "" {
+		return []string{msg}
+	}
+	return nil
+}
+
+// ResultLabel formats the result with its date, e.g. "validated
+// 2025-03-01". Assumptions without a result are untested.
+func (a Assumption) ResultLabel() string {
+	label := string(a.Result)
+	if label == "" {
+		label = string(AssumptionUntested)
+	}
+	if !a.ValidatedDate.IsZero() {
+		label += " " + a.ValidatedDate.String()
+	}
+	return label
+}
+
+// assumptionBullets formats assumptions as list items, with their result
+// when one is recorded
+func assumptionBullets(assumptions []Assumption) []string {
+	bullets := make([]string, len(assumptions))
+	for i, a := range assumptions {
+		bullets[i] = a.Statement
+		if a.Result != "" {
+			bullets[i] += " (" + string(a.Result) + ")"
+		}
+	}
+	return bullets
+}
@@ -0,0 +1,103 @@
+package prd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAssumptionUnmarshalJSON(t *testing.T) {
+	var ra RisksAndAssumptions
+	data := `{"assumptions":[
+		"Users want dark mode",
+		{"id":"HYP-2","statement":"Dark mode reduces churn","confidence":"low","validation_method":"A/B test","result":"inconclusive"}
+	]}`
+	if err := json.Unmarshal([]byte(data), &ra); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(ra.Assumptions) != 2 {
+		t.Fatalf("Expected 2 assumptions, got %d", len(ra.Assumptions))
+	}
+	if a := ra.Assumptions[0]; a.Statement != "Users want dark mode" || a.Result != "" || ra.AssumptionID(0) != "A1" {
+		t.Errorf("Expected a plain string to load as an untested assumption, got %+v", a)
+	}
+	if a := ra.Assumptions[1]; a.Confidence != ConfidenceLow || a.Result != AssumptionInconclusive || ra.AssumptionID(1) != "HYP-2" {
+		t.Errorf("Unexpected structured assumption: %+v", a)
+	}
+
+	if err := json.Unmarshal([]byte(`{"assumptions":[{"statement":"x","result":"proven"}]}`), &ra); err == nil || !strings.Contains(err.Error(), "invalid assumption result 'proven'") {
+		t.Errorf("Expected invalid result error, got %v", err)
+	}
+}
+
+func TestAssumptionResultLabel(t *testing.T) {
+	tests := []struct {
+		assumption Assumption
+		expected   string
+	}{
+		{Assumption{}, "untested"},
+		{Assumption{Result: AssumptionInvalidated}, "invalidated"},
+		{Assumption{Result: AssumptionValidated, ValidatedDate: MustParseDate("2025-03-01")}, "validated 2025-03-01"},
+	}
+	for _, tt := range tests {
+		if got := tt.assumption.ResultLabel(); got != tt.expected {
+			t.Errorf("ResultLabel() = %q, expected %q", got, tt.expected)
+		}
+	}
+}
+
+func TestUnvalidatedAssumptionsBlockApproval(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	if got := p.UnvalidatedAssumptions(); len(got) != 0 {
+		t.Fatalf("Expected example assumptions to be validated, got %v", got)
+	}
+
+	ra := p.RisksAndAssumptions
+	ra.Assumptions[1].Result = AssumptionInvalidated
+	ra.Assumptions = append(ra.Assumptions, Assumption{Statement: "Support can handle the launch"})
+
+	unvalidated := p.UnvalidatedAssumptions()
+	if len(unvalidated) != 2 || unvalidated[0].ID != "A2" || unvalidated[1].ID != "A4" {
+		t.Errorf("Unexpected unvalidated assumptions: %+v", unvalidated)
+	}
+
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "PRD is approved but 2 assumptions are not validated: A2, A4") {
+		t.Errorf("Expected approval to be blocked, got %v", err)
+	}
+	if warnings := p.CheckAssumptions(); len(warnings) != 0 {
+		t.Errorf("Expected an error rather than a warning, got %v", warnings)
+	}
+
+	p.Status = StatusReview
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected a PRD in review to allow unvalidated assumptions, got %v", err)
+	}
+}
+
+func TestCheckAssumptions(t *testing.T) {
+	// Older PRDs with plain string assumptions are warned about, not blocked
+	var p PRD
+	data := `{"id":"PRD-001","title":"Login","version":"1.0","status":"approved","priority":"high",
+		"owner":{"name":"A","email":"a@example.com"},"created_date":"2024-01-01",
+		"risks_and_assumptions":{"assumptions":["Users want dark mode","Dark mode reduces churn"]}}`
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := p.validateAssumptions(); err != nil {
+		t.Errorf("Expected plain assumptions to validate, got %v", err)
+	}
+
+	warnings := p.CheckAssumptions()
+	if len(warnings) != 1 || warnings[0] != "PRD is approved but 2 assumptions are not validated: A1, A2" {
+		t.Errorf("Expected an approval warning, got %v", warnings)
+	}
+
+	p.Status = StatusReview
+	if warnings := p.CheckAssumptions(); len(warnings) != 0 {
+		t.Errorf("Expected no warning for a PRD in review, got %v", warnings)
+	}
+}
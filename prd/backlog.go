@@ -0,0 +1,340 @@
+package prd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Backlog field names used as keys in BacklogMapping.Fields
+const (
+	BacklogFieldKey         = "key"
+	BacklogFieldIssueType   = "issue_type"
+	BacklogFieldSummary     = "summary"
+	BacklogFieldDescription = "description"
+	BacklogFieldPriority    = "priority"
+	BacklogFieldLabels      = "labels"
+	BacklogFieldStoryPoints = "story_points"
+	BacklogFieldParent      = "parent"
+)
+
+// backlogFieldOrder is the column order used for CSV output
+var backlogFieldOrder = []string{
+	BacklogFieldKey,
+	BacklogFieldIssueType,
+	BacklogFieldSummary,
+	BacklogFieldDescription,
+	BacklogFieldPriority,
+	BacklogFieldLabels,
+	BacklogFieldStoryPoints,
+	BacklogFieldParent,
+}
+
+// BacklogItem is a tracker-neutral issue generated from a PRD
+type BacklogItem struct {
+	Key         string   `json:"key"`
+	IssueType   string   `json:"issue_type"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Priority    string   `json:"priority,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	StoryPoints *float64 `json:"story_points,omitempty"`
+	Parent      string   `json:"parent,omitempty"`
+}
+
+// BacklogMapping controls how PRD elements map onto tracker import fields.
+// It is typically loaded from a JSON file so it can be tailored for Jira,
+// Linear, or GitHub Issues without code changes.
+type BacklogMapping struct {
+	// Fields maps backlog field names to output column names. Fields
+	// mapped to an empty string are omitted.
+	Fields map[string]string `json:"fields,omitempty"`
+	// Priorities maps PRD priorities (MoSCoW or level) to tracker priorities
+	Priorities map[string]string `json:"priorities,omitempty"`
+	// StoryPoints maps non-numeric effort estimates (e.g. t-shirt sizes)
+	// to story points
+	StoryPoints map[string]float64 `json:"story_points,omitempty"`
+	// Labels are added to every item in addition to the PRD ID
+	Labels []string `json:"labels,omitempty"`
+	// LabelSeparator joins labels in CSV output. Defaults to a space.
+	LabelSeparator string `json:"label_separator,omitempty"`
+	// IssueTypes names the epic, story and requirement issue types
+	EpicType        string `json:"epic_type,omitempty"`
+	StoryType       string `json:"story_type,omitempty"`
+	RequirementType string `json:"requirement_type,omitempty"`
+	// SkipEpic omits the parent epic generated for the PRD itself
+	SkipEpic bool `json:"skip_epic,omitempty"`
+	// SkipRequirements omits functional requirements
+	SkipRequirements bool `json:"skip_requirements,omitempty"`
+}
+
+// DefaultBacklogMapping returns a mapping suitable for a Jira CSV import
+func DefaultBacklogMapping() BacklogMapping {
+	return BacklogMapping{
+		Fields: map[string]string{
+			BacklogFieldKey:         "External ID",
+			BacklogFieldIssueType:   "Issue Type",
+			BacklogFieldSummary:     "Summary",
+			BacklogFieldDescription: "Description",
+			BacklogFieldPriority:    "Priority",
+			BacklogFieldLabels:      "Labels",
+			BacklogFieldStoryPoints: "Story Points",
+			BacklogFieldParent:      "Parent",
+		},
+		Priorities: map[string]string{
+			"must_have":   "Highest",
+			"should_have": "High",
+			"could_have":  "Medium",
+			"wont_have":   "Lowest",
+			"critical":    "Highest",
+			"high":        "High",
+			"medium":      "Medium",
+			"low":         "Low",
+		},
+		StoryPoints: map[string]float64{
+			"xs": 1,
+			"s":  2,
+			"m":  3,
+			"l":  5,
+			"xl": 8,
+		},
+		LabelSeparator:  " ",
+		EpicType:        "Epic",
+		StoryType:       "Story",
+		RequirementType: "Task",
+	}
+}
+
+// LoadBacklogMapping loads a mapping from a JSON file. Values not set in
+// the file fall back to DefaultBacklogMapping.
+func LoadBacklogMapping(filename string) (BacklogMapping, error) {
+	mapping := DefaultBacklogMapping()
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return mapping, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	var override BacklogMapping
+	if err := json.Unmarshal(data, &override); err != nil {
+		return mapping, fmt.Errorf("failed to unmarshal backlog mapping: %w", err)
+	}
+
+	for k, v := range override.Fields {
+		mapping.Fields[k] = v
+	}
+	if override.Priorities != nil {
+		mapping.Priorities = override.Priorities
+	}
+	if override.StoryPoints != nil {
+		mapping.StoryPoints = override.StoryPoints
+	}
+	mapping.Labels = override.Labels
+	if override.LabelSeparator != "" {
+		mapping.LabelSeparator = override.LabelSeparator
+	}
+	if override.EpicType != "" {
+		mapping.EpicType = override.EpicType
+	}
+	if override.StoryType != "" {
+		mapping.StoryType = override.StoryType
+	}
+	if override.RequirementType != "" {
+		mapping.RequirementType = override.RequirementType
+	}
+	mapping.SkipEpic = override.SkipEpic
+	mapping.SkipRequirements = override.SkipRequirements
+
+	return mapping, nil
+}
+
+// BuildBacklog generates backlog items from the PRD's user stories and
+// functional requirements, parented to an epic representing the PRD
+func (p *PRD) BuildBacklog(m BacklogMapping) []BacklogItem {
+	labels := append([]string{p.ID}, m.Labels...)
+
+	var items []BacklogItem
+	parent := ""
+	if !m.SkipEpic {
+		parent = p.ID
+		items = append(items, BacklogItem{
+			Key:         p.ID,
+			IssueType:   m.EpicType,
+			Summary:     backlogSummary(p.Title),
+			Description: strings.TrimSpace(p.Overview.ProblemStatement + "\n\n" + p.Overview.SolutionSummary),
+			Priority:    m.Priorities[string(p.Priority)],
+			Labels:      labels,
+		})
+	}
+
+	for _, story := range p.UserStories {
+		var desc strings.Builder
+		desc.WriteString(story.Story)
+		if len(story.AcceptanceCriteria) > 0 {
+			desc.WriteString("\n\nAcceptance Criteria:\n")
+			for _, criteria := range story.AcceptanceCriteria {
+				desc.WriteString("- " + criteria + "\n")
+			}
+		}
+
+		items = append(items, BacklogItem{
+			Key:         p.ID + "-" + story.ID,
+			IssueType:   m.StoryType,
+			Summary:     backlogSummary(story.ID + ": " + story.Story),
+			Description: strings.TrimSpace(desc.String()),
+			Priority:    m.Priorities[string(story.Priority)],
+			Labels:      labels,
+			StoryPoints: m.storyPoints(story.EffortEstimate),
+			Parent:      parent,
+		})
+	}
+
+	if !m.SkipRequirements {
+		for _, req := range p.Requirements.Functional {
+			desc := req.Description
+			if len(req.Dependencies) > 0 {
+				desc += "\n\nDepends on: " + strings.Join(req.Dependencies, ", ")
+			}
+
+			items = append(items, BacklogItem{
+				Key:         p.ID + "-" + req.ID,
+				IssueType:   m.RequirementType,
+				Summary:     backlogSummary(req.ID + ": " + req.Description),
+				Description: desc,
+				Priority:    m.Priorities[string(req.Priority)],
+				Labels:      labels,
+				Parent:      parent,
+			})
+		}
+	}
+
+	return items
+}
+
+// WriteBacklogCSV writes backlog items as CSV with mapped column names
+func WriteBacklogCSV(w io.Writer, items []BacklogItem, m BacklogMapping) error {
+	fields := m.activeFields()
+
+	cw := csv.NewWriter(w)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = m.Fields[f]
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	sep := m.LabelSeparator
+	if sep == "" {
+		sep = " "
+	}
+	for _, item := range items {
+		values := item.values(sep)
+		row := make([]string, len(fields))
+		for i, f := range fields {
+			row[i] = values[f]
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteBacklogJSON writes backlog items as a JSON array of objects keyed by
+// mapped field names
+func WriteBacklogJSON(w io.Writer, items []BacklogItem, m BacklogMapping) error {
+	fields := m.activeFields()
+
+	rows := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		row := map[string]any{}
+		for _, f := range fields {
+			var v any
+			switch f {
+			case BacklogFieldLabels:
+				v = item.Labels
+			case BacklogFieldStoryPoints:
+				if item.StoryPoints == nil {
+					continue
+				}
+				v = *item.StoryPoints
+			default:
+				s := item.values("")[f]
+				if s == "" {
+					continue
+				}
+				v = s
+			}
+			row[m.Fields[f]] = v
+		}
+		rows = append(rows, row)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rows); err != nil {
+		return fmt.Errorf("failed to marshal backlog to JSON: %w", err)
+	}
+	return nil
+}
+
+func (m BacklogMapping) activeFields() []string {
+	var fields []string
+	for _, f := range backlogFieldOrder {
+		if m.Fields[f] != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+var leadingNumber = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)`)
+
+// storyPoints parses an effort estimate such as "8", "5 story points" or a
+// mapped size such as "M"
+func (m BacklogMapping) storyPoints(estimate string) *float64 {
+	if match := leadingNumber.FindStringSubmatch(estimate); match != nil {
+		if v, err := strconv.ParseFloat(match[1], 64); err == nil {
+			return &v
+		}
+	}
+	if v, ok := m.StoryPoints[strings.ToLower(strings.TrimSpace(estimate))]; ok {
+		return &v
+	}
+	return nil
+}
+
+func (item BacklogItem) values(labelSep string) map[string]string {
+	points := ""
+	if item.StoryPoints != nil {
+		points = strconv.FormatFloat(*item.StoryPoints, 'f', -1, 64)
+	}
+	return map[string]string{
+		BacklogFieldKey:         item.Key,
+		BacklogFieldIssueType:   item.IssueType,
+		BacklogFieldSummary:     item.Summary,
+		BacklogFieldDescription: item.Description,
+		BacklogFieldPriority:    item.Priority,
+		BacklogFieldLabels:      strings.Join(item.Labels, labelSep),
+		BacklogFieldStoryPoints: points,
+		BacklogFieldParent:      item.Parent,
+	}
+}
+
+// backlogSummary collapses whitespace and truncates to the 255 character
+// limit common to issue trackers
+func backlogSummary(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 255 {
+		return string(r[:252]) + "..."
+	}
+	return s
+}
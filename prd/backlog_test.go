@@ -0,0 +1,90 @@
+package prd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildBacklog(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	items := p.BuildBacklog(DefaultBacklogMapping())
+
+	want := 1 + len(p.UserStories) + len(p.Requirements.Functional)
+	if len(items) != want {
+		t.Fatalf("Expected %d items, got %d", want, len(items))
+	}
+
+	epic, story := items[0], items[1]
+	if epic.IssueType != "Epic" || epic.Key != "PRD-001" {
+		t.Errorf("Expected first item to be the PRD epic, got %+v", epic)
+	}
+	if story.Parent != "PRD-001" {
+		t.Errorf("Expected story parent PRD-001, got %s", story.Parent)
+	}
+	if story.Priority != "Highest" {
+		t.Errorf("Expected must_have to map to Highest, got %s", story.Priority)
+	}
+	if story.StoryPoints == nil || *story.StoryPoints != 8 {
+		t.Errorf("Expected 8 story points, got %v", story.StoryPoints)
+	}
+	if len(story.Labels) == 0 || story.Labels[0] != "PRD-001" {
+		t.Errorf("Expected PRD ID label, got %v", story.Labels)
+	}
+}
+
+func TestBacklogMappingFile(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "mapping.json")
+	mapping := `{"fields": {"key": "", "summary": "Title"}, "priorities": {"must_have": "Urgent"}, "skip_epic": true}`
+	if err := os.WriteFile(filename, []byte(mapping), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadBacklogMapping(filename)
+	if err != nil {
+		t.Fatalf("Failed to load mapping: %v", err)
+	}
+	items := p.BuildBacklog(m)
+
+	var csvBuf bytes.Buffer
+	if err := WriteBacklogCSV(&csvBuf, items, m); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	records, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if records[0][0] != "Issue Type" || records[0][1] != "Title" {
+		t.Errorf("Unexpected CSV header: %v", records[0])
+	}
+	if len(records) != 1+len(items) {
+		t.Errorf("Expected %d CSV rows, got %d", 1+len(items), len(records))
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := WriteBacklogJSON(&jsonBuf, items, m); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &rows); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if rows[0]["Priority"] != "Urgent" {
+		t.Errorf("Expected mapped priority Urgent, got %v", rows[0]["Priority"])
+	}
+	if _, ok := rows[0]["Parent"]; ok {
+		t.Error("Expected no parent when epic is skipped")
+	}
+}
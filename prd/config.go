@@ -0,0 +1,418 @@
+package prd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigDir is the workspace directory for configuration and indexes
+	ConfigDir = ".prd"
+	// ConfigFile is the name of the configuration file in ConfigDir
+	ConfigFile = "config.yaml"
+)
+
+// LintRule is an optional validation check
+type LintRule struct {
+	Name        string
+	Description string
+}
+
+// LintRules are the checks a workspace can enable. All of them run in
+// strict mode.
+var LintRules = []LintRule{
+	{"timeline-review", "Warn about overdue, slipped and out-of-order milestones"},
+	{"risk-review", "Warn about high risks without mitigations and overdue risk reviews"},
+	{"assumption-review", "Warn when an approved PRD has plain string assumptions that are not validated"},
+	{"user-stories", "Warn when a PRD has no user stories"},
+	{"timeline", "Warn when a PRD has no timeline"},
+	{"non-functional", "Warn when a PRD has no non-functional requirements"},
+}
+
+// Config is a workspace configuration, read from .prd/config.yaml
+type Config struct {
+	Defaults  ConfigDefaults  `yaml:"defaults,omitempty"`
+	IDs       ConfigIDs       `yaml:"ids,omitempty"`
+	Lint      ConfigLint      `yaml:"lint,omitempty"`
+	Templates ConfigTemplates `yaml:"templates,omitempty"`
+	Export    ConfigExport    `yaml:"export,omitempty"`
+	// Statuses are the statuses PRDs may have; empty allows all of them
+	Statuses []Status `yaml:"statuses,omitempty"`
+
+	// Root is the workspace directory, the parent of ConfigDir
+	Root string `yaml:"-"`
+	// Path is the file the configuration was read from, if any
+	Path string `yaml:"-"`
+}
+
+// ConfigDefaults are the values given to new PRDs
+type ConfigDefaults struct {
+	Owner    string   `yaml:"owner,omitempty"`
+	Email    string   `yaml:"email,omitempty"`
+	Team     string   `yaml:"team,omitempty"`
+	Priority Priority `yaml:"priority,omitempty"`
+}
+
+// ConfigIDs are the prefixes of generated IDs
+type ConfigIDs struct {
+	PRD           string `yaml:"prd,omitempty"`
+	Requirement   string `yaml:"requirement,omitempty"`
+	NonFunctional string `yaml:"non_functional,omitempty"`
+	UserStory     string `yaml:"user_story,omitempty"`
+}
+
+// ConfigLint selects the optional validation checks
+type ConfigLint struct {
+	Rules []string `yaml:"rules,omitempty"`
+}
+
+// ConfigTemplates lists directories of JSON PRD templates, searched before
+// the built-in templates. Relative paths are relative to the workspace.
+type ConfigTemplates struct {
+	Paths []string `yaml:"paths,omitempty"`
+}
+
+// ConfigExport are defaults for the export command
+type ConfigExport struct {
+	Format    string `yaml:"format,omitempty"`
+	OutputDir string `yaml:"output_dir,omitempty"`
+	Style     string `yaml:"style,omitempty"`
+	Flavor    string `yaml:"flavor,omitempty"`
+}
+
+// DefaultConfig returns the configuration used where a workspace sets
+// nothing
+func DefaultConfig() *Config {
+	return &Config{
+		IDs:    ConfigIDs{PRD: "PRD-", Requirement: "FR-", NonFunctional: "NFR-", UserStory: "US-"},
+		Lint:   ConfigLint{Rules: []string{"timeline-review", "risk-review", "assumption-review"}},
+		Export: ConfigExport{Format: "markdown", Style: string(MarkdownStyleTable), Flavor: string(MarkdownFlavorGFM)},
+	}
+}
+
+// FindConfig returns the path of the nearest .prd/config.yaml at or above
+// dir, or "" if there is none
+func FindConfig(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(abs, ConfigDir, ConfigFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		abs = parent
+	}
+}
+
+// LoadConfig returns the effective configuration for dir: the nearest
+// .prd/config.yaml at or above it over the defaults. Without a configuration
+// file the defaults apply, with dir as the workspace root.
+func LoadConfig(dir string) (*Config, error) {
+	cfg := DefaultConfig()
+	path := FindConfig(dir)
+	if path == "" {
+		cfg.Root = dir
+		return cfg, nil
+	}
+	if err := cfg.readFile(path); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ReadConfigFile reads a configuration file on its own, without defaults,
+// for editing. A missing file reads as an empty configuration.
+func ReadConfigFile(path string) (*Config, error) {
+	cfg := &Config{}
+	if err := cfg.readFile(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	cfg.Path, cfg.Root = path, filepath.Dir(filepath.Dir(path))
+	return cfg, nil
+}
+
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration %s: %w", path, err)
+	}
+	c.Path, c.Root = path, filepath.Dir(filepath.Dir(path))
+	return nil
+}
+
+// Save writes the configuration to its Path
+func (c *Config) Save() error {
+	if c.Path == "" {
+		return fmt.Errorf("configuration has no path")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(c.Path), err)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	if err := os.WriteFile(c.Path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the configuration's statuses, priority, export options and
+// lint rules
+func (c *Config) Validate() error {
+	for _, s := range c.Statuses {
+		if !s.Valid() {
+			return fmt.Errorf("invalid status '%s' in statuses", s)
+		}
+	}
+	if c.Defaults.Priority != "" && !c.Defaults.Priority.Valid() {
+		return fmt.Errorf("invalid default priority '%s'", c.Defaults.Priority)
+	}
+	switch MarkdownStyle(c.Export.Style) {
+	case "", MarkdownStyleTable, MarkdownStyleList:
+	default:
+		return fmt.Errorf("invalid export style '%s': expected table or list", c.Export.Style)
+	}
+	switch MarkdownFlavor(c.Export.Flavor) {
+	case "", MarkdownFlavorGFM, MarkdownFlavorCommonMark:
+	default:
+		return fmt.Errorf("invalid export flavor '%s': expected gfm or commonmark", c.Export.Flavor)
+	}
+	for _, rule := range c.Lint.Rules {
+		if !slices.ContainsFunc(LintRules, func(r LintRule) bool { return r.Name == rule }) {
+			return fmt.Errorf("unknown lint rule '%s'", rule)
+		}
+	}
+	return nil
+}
+
+// AllowedStatuses returns the statuses PRDs may have
+func (c *Config) AllowedStatuses() []Status {
+	if len(c.Statuses) == 0 {
+		return Status("").Values()
+	}
+	return c.Statuses
+}
+
+// CheckStatus returns an error if a PRD status is not allowed
+func (c *Config) CheckStatus(s Status) error {
+	if allowed := c.AllowedStatuses(); !slices.Contains(allowed, s) {
+		return fmt.Errorf("status '%s' is not allowed in this workspace: expected one of %s", s, strings.Join(EnumStrings(allowed), ", "))
+	}
+	return nil
+}
+
+// LintEnabled reports whether a lint rule runs. Strict mode runs them all.
+func (c *Config) LintEnabled(rule string, strict bool) bool {
+	return strict || slices.Contains(c.Lint.Rules, rule)
+}
+
+// TemplatePaths returns the template directories, resolved against the
+// workspace root
+func (c *Config) TemplatePaths() []string {
+	paths := make([]string, len(c.Templates.Paths))
+	for i, p := range c.Templates.Paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(c.Root, p)
+		}
+		paths[i] = p
+	}
+	return paths
+}
+
+// FindTemplate returns the path of the named template in the template
+// directories, or "" if none has it
+func (c *Config) FindTemplate(name string) string {
+	for _, dir := range c.TemplatePaths() {
+		path := filepath.Join(dir, name+".json")
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// ApplyDefaults sets the owner and priority of a new PRD from the
+// configuration, where the configuration has them
+func (c *Config) ApplyDefaults(p *PRD) {
+	if c.Defaults.Owner != "" {
+		p.Owner.Name = c.Defaults.Owner
+	}
+	if c.Defaults.Email != "" {
+		p.Owner.Email = c.Defaults.Email
+	}
+	if c.Defaults.Team != "" {
+		p.Owner.Team = c.Defaults.Team
+	}
+	if c.Defaults.Priority != "" {
+		p.Priority = c.Defaults.Priority
+	}
+}
+
+// ApplyIDPrefixes renumbers a new PRD's requirements and user stories with
+// the configured ID prefixes, in place of the default FR-, NFR- and US-
+func (c *Config) ApplyIDPrefixes(p *PRD) {
+	defaults := DefaultConfig().IDs
+	renamed := map[string]string{}
+	rename := func(id *string, from, to string) {
+		if to != "" && to != from && strings.HasPrefix(*id, from) {
+			renamed[*id] = to + strings.TrimPrefix(*id, from)
+			*id = renamed[*id]
+		}
+	}
+	for i := range p.Requirements.Functional {
+		rename(&p.Requirements.Functional[i].ID, defaults.Requirement, c.IDs.Requirement)
+	}
+	for i := range p.Requirements.NonFunctional {
+		rename(&p.Requirements.NonFunctional[i].ID, defaults.NonFunctional, c.IDs.NonFunctional)
+	}
+	for i := range p.UserStories {
+		rename(&p.UserStories[i].ID, defaults.UserStory, c.IDs.UserStory)
+	}
+	for i := range p.Requirements.Functional {
+		for j, dep := range p.Requirements.Functional[i].Dependencies {
+			if id, ok := renamed[dep]; ok {
+				p.Requirements.Functional[i].Dependencies[j] = id
+			}
+		}
+	}
+}
+
+// ConfigKeys returns the dotted keys of every configuration setting, such
+// as "defaults.owner"
+func ConfigKeys() []string {
+	var keys []string
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := range t.NumField() {
+			name := yamlName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			if t.Field(i).Type.Kind() == reflect.Struct {
+				walk(t.Field(i).Type, prefix+name+".")
+			} else {
+				keys = append(keys, prefix+name)
+			}
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	sort.Strings(keys)
+	return keys
+}
+
+// Get returns a setting by its dotted key. Lists are comma-separated.
+func (c *Config) Get(key string) (string, error) {
+	v, err := c.field(key)
+	if err != nil {
+		return "", err
+	}
+	if v.Kind() == reflect.Slice {
+		parts := make([]string, v.Len())
+		for i := range v.Len() {
+			parts[i] = v.Index(i).String()
+		}
+		return strings.Join(parts, ","), nil
+	}
+	return v.String(), nil
+}
+
+// Set changes a setting by its dotted key. Lists are comma-separated, and
+// an empty value clears the setting.
+func (c *Config) Set(key, value string) error {
+	v, err := c.field(key)
+	if err != nil {
+		return err
+	}
+	old := reflect.New(v.Type()).Elem()
+	old.Set(v)
+
+	if v.Kind() == reflect.Slice {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		list := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			list.Index(i).SetString(item)
+		}
+		v.Set(list)
+	} else {
+		v.SetString(strings.TrimSpace(value))
+	}
+
+	if err := c.Validate(); err != nil {
+		v.Set(old)
+		return err
+	}
+	return nil
+}
+
+// field returns the settable value of a setting
+func (c *Config) field(key string) (reflect.Value, error) {
+	v := reflect.ValueOf(c).Elem()
+	for _, name := range strings.Split(key, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("unknown configuration key '%s'", key)
+		}
+		found := false
+		for i := range v.NumField() {
+			if yamlName(v.Type().Field(i)) == name {
+				v, found = v.Field(i), true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}, fmt.Errorf("unknown configuration key '%s'", key)
+		}
+	}
+	if v.Kind() == reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("configuration key '%s' is a section; use one of its keys", key)
+	}
+	return v, nil
+}
+
+// yamlName returns the YAML key of a struct field, or "" if it is not
+// serialized
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+// NewID returns an ID with the given prefix and a zero-padded number, e.g.
+// "FR-001"
+func NewID(prefix string, n int) string {
+	return fmt.Sprintf("%s%03d", prefix, n)
+}
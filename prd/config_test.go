@@ -0,0 +1,192 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFixture(t *testing.T, content string) string {
+	t.Helper()
+	return writeFixture(t, map[string]string{ConfigDir + "/" + ConfigFile: content})
+}
+
+func TestLoadConfig(t *testing.T) {
+	root := writeConfigFixture(t, `
+defaults:
+  owner: Ana Ruiz
+  team: Payments
+ids:
+  requirement: REQ-
+lint:
+  rules: [timeline]
+templates:
+  paths: [templates, /opt/prd-templates]
+statuses: [draft, review, approved]
+`)
+	sub := filepath.Join(root, "docs", "prds")
+	if err := os.MkdirAll(sub, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(sub)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Root != root || cfg.Path != filepath.Join(root, ConfigDir, ConfigFile) {
+		t.Errorf("Unexpected location: root %s, path %s", cfg.Root, cfg.Path)
+	}
+
+	// File settings override the defaults; unset ones keep them
+	tests := map[string]string{
+		"defaults.owner":  "Ana Ruiz",
+		"defaults.email":  "",
+		"ids.requirement": "REQ-",
+		"ids.prd":         "PRD-",
+		"lint.rules":      "timeline",
+		"export.format":   "markdown",
+		"statuses":        "draft,review,approved",
+	}
+	for key, expected := range tests {
+		if got, err := cfg.Get(key); err != nil || got != expected {
+			t.Errorf("%s: got %q (%v), expected %q", key, got, err, expected)
+		}
+	}
+
+	if paths := cfg.TemplatePaths(); paths[0] != filepath.Join(root, "templates") || paths[1] != "/opt/prd-templates" {
+		t.Errorf("Unexpected template paths: %v", paths)
+	}
+	if !cfg.LintEnabled("timeline", false) || cfg.LintEnabled("risk-review", false) || !cfg.LintEnabled("risk-review", true) {
+		t.Error("Unexpected lint rules")
+	}
+	if err := cfg.CheckStatus(StatusArchived); err == nil || !strings.Contains(err.Error(), "expected one of draft, review, approved") {
+		t.Errorf("Expected archived to be disallowed, got %v", err)
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Path != "" || cfg.IDs.PRD != "PRD-" || len(cfg.AllowedStatuses()) != len(Status("").Values()) {
+		t.Errorf("Unexpected default configuration: %+v", cfg)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"statuses: [draft, shipped]", "invalid status 'shipped'"},
+		{"lint:\n  rules: [spelling]", "unknown lint rule 'spelling'"},
+		{"defaults:\n  priority: urgent", "invalid default priority 'urgent'"},
+		{"export:\n  style: grid", "invalid export style 'grid'"},
+		{"ids: [1, 2]", "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			root := writeConfigFixture(t, tt.content)
+			if _, err := LoadConfig(root); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigDir, ConfigFile)
+	cfg, err := ReadConfigFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := cfg.Set("defaults.team", "Payments"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := cfg.Set("statuses", "draft, approved"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := cfg.Set("lint.rules", "timeline,spelling"); err == nil {
+		t.Error("Expected an unknown lint rule to be rejected")
+	}
+	if len(cfg.Lint.Rules) != 0 {
+		t.Errorf("Expected a rejected value to be rolled back, got %v", cfg.Lint.Rules)
+	}
+	for _, key := range []string{"defaults", "defaults.nickname", "root"} {
+		if err := cfg.Set(key, "x"); err == nil {
+			t.Errorf("Expected %s to be rejected", key)
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Only the settings that were set are written
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "defaults:\n  team: Payments\nstatuses:\n  - draft\n  - approved\n"
+	if string(data) != expected {
+		t.Errorf("Unexpected file:\n%s", data)
+	}
+
+	loaded, err := LoadConfig(filepath.Dir(filepath.Dir(path)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loaded.Defaults.Team != "Payments" || len(loaded.Statuses) != 2 || loaded.IDs.Requirement != "FR-" {
+		t.Errorf("Unexpected configuration: %+v", loaded)
+	}
+
+	if err := loaded.Set("statuses", ""); err != nil || len(loaded.AllowedStatuses()) != len(Status("").Values()) {
+		t.Errorf("Expected clearing statuses to allow all, got %v", err)
+	}
+}
+
+func TestConfigKeys(t *testing.T) {
+	keys := ConfigKeys()
+	for _, key := range keys {
+		if _, err := DefaultConfig().Get(key); err != nil {
+			t.Errorf("Key %s: %v", key, err)
+		}
+	}
+	if len(keys) != 15 || keys[0] != "defaults.email" || keys[len(keys)-1] != "templates.paths" {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+}
+
+func TestApplyConfigToPRD(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults = ConfigDefaults{Owner: "Ana Ruiz", Team: "Payments", Priority: PriorityHigh}
+	cfg.IDs.Requirement = "REQ-"
+	cfg.IDs.UserStory = "STORY-"
+
+	p := &PRD{
+		Owner:       Owner{Name: "[OWNER_NAME]", Email: "[OWNER_EMAIL]"},
+		UserStories: []UserStory{{ID: "US-001"}},
+		Requirements: Requirements{
+			Functional:    []FunctionalRequirement{{ID: "FR-001"}, {ID: "FR-002", Dependencies: []string{"FR-001", "PRD-042#FR-001"}}},
+			NonFunctional: []NonFunctionalRequirement{{ID: "NFR-001"}},
+		},
+	}
+	cfg.ApplyDefaults(p)
+	cfg.ApplyIDPrefixes(p)
+
+	if p.Owner != (Owner{Name: "Ana Ruiz", Email: "[OWNER_EMAIL]", Team: "Payments"}) || p.Priority != PriorityHigh {
+		t.Errorf("Unexpected owner and priority: %+v %s", p.Owner, p.Priority)
+	}
+	got := []string{p.UserStories[0].ID, p.Requirements.Functional[0].ID, p.Requirements.Functional[1].ID,
+		strings.Join(p.Requirements.Functional[1].Dependencies, ","), p.Requirements.NonFunctional[0].ID}
+	expected := []string{"STORY-001", "REQ-001", "REQ-002", "REQ-001,PRD-042#FR-001", "NFR-001"}
+	if strings.Join(got, " ") != strings.Join(expected, " ") {
+		t.Errorf("Unexpected IDs: %v", got)
+	}
+	if id := NewID("REQ-", 12); id != "REQ-012" {
+		t.Errorf("Unexpected ID: %s", id)
+	}
+}
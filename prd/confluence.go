@@ -0,0 +1,294 @@
+package prd
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Confluence status macro colours for status and priority values
+var (
+	confluenceStatusColours = map[string]string{
+		string(StatusDraft):         "Grey",
+		string(StatusReview):        "Yellow",
+		string(StatusApproved):      "Green",
+		string(StatusInDevelopment): "Blue",
+		string(StatusCompleted):     "Green",
+		string(StatusArchived):      "Grey",
+	}
+	confluencePriorityColours = map[string]string{
+		string(PriorityCritical): "Red",
+		string(PriorityHigh):     "Red",
+		string(PriorityMedium):   "Yellow",
+		string(PriorityLow):      "Green",
+		string(MustHave):         "Red",
+		string(ShouldHave):       "Yellow",
+		string(CouldHave):        "Blue",
+		string(WontHave):         "Grey",
+	}
+)
+
+// ToConfluence converts the PRD into Confluence storage format (XHTML).
+// Status and priority values are rendered with the status macro and
+// acceptance criteria are collapsed into expand macros.
+func (p *PRD) ToConfluence() string {
+	c := &confluenceWriter{}
+
+	c.tag("h1", p.Title)
+
+	c.write("<table><tbody>")
+	c.metaRow("ID", esc(p.ID))
+	c.metaRow("Version", esc(p.Version))
+	c.metaRow("Status", confluenceStatus(p.Status, confluenceStatusColours))
+	if p.Priority != "" {
+		c.metaRow("Priority", confluenceStatus(p.Priority, confluencePriorityColours))
+	}
+	owner := esc(p.Owner.Name)
+	if p.Owner.Email != "" {
+		owner += fmt.Sprintf(` (<a href="mailto:%s">%s</a>)`, esc(p.Owner.Email), esc(p.Owner.Email))
+	}
+	c.metaRow("Owner", owner)
+	if p.Owner.Team != "" {
+		c.metaRow("Team", esc(p.Owner.Team))
+	}
+	c.metaRow("Created", esc(p.CreatedDate.String()))
+	if p.LastUpdated != nil {
+		c.metaRow("Last Updated", esc(p.LastUpdated.Format("2006-01-02 15:04")))
+	}
+	c.write("</tbody></table>")
+
+	c.write(`<ac:structured-macro ac:name="toc"><ac:parameter ac:name="maxLevel">2</ac:parameter></ac:structured-macro>`)
+
+	if len(p.Stakeholders) > 0 {
+		c.tag("h2", "Stakeholders")
+		var rows [][]string
+		for _, s := range p.Stakeholders {
+			rows = append(rows, []string{esc(s.Name), esc(s.Role), esc(s.Team), esc(s.Email)})
+		}
+		c.table([]string{"Name", "Role", "Team", "Email"}, rows)
+	}
+
+	c.tag("h2", "Overview")
+	c.tag("h3", "Problem Statement")
+	c.tag("p", p.Overview.ProblemStatement)
+	c.tag("h3", "Solution Summary")
+	c.tag("p", p.Overview.SolutionSummary)
+	if p.Overview.TargetAudience != "" {
+		c.tag("h3", "Target Audience")
+		c.tag("p", p.Overview.TargetAudience)
+	}
+	if p.Overview.MarketContext != "" {
+		c.tag("h3", "Market Context")
+		c.tag("p", p.Overview.MarketContext)
+	}
+
+	c.tag("h2", "Objectives")
+	if len(p.Objectives.BusinessGoals) > 0 {
+		c.tag("h3", "Business Goals")
+		c.list(p.Objectives.BusinessGoals)
+	}
+	if len(p.Objectives.SuccessMetrics) > 0 {
+		c.tag("h3", "Success Metrics")
+		var rows [][]string
+		for _, m := range p.Objectives.SuccessMetrics {
+			rows = append(rows, []string{esc(m.Metric), esc(m.Target), esc(m.MeasurementMethod)})
+		}
+		c.table([]string{"Metric", "Target", "Measurement Method"}, rows)
+	}
+	if len(p.Objectives.OKRs) > 0 {
+		c.tag("h3", "OKRs")
+		for _, okr := range p.Objectives.OKRs {
+			c.write(fmt.Sprintf("<p><strong>Objective:</strong> %s</p>", esc(okr.Objective)))
+			c.list(keyResultBullets(okr.KeyResults))
+		}
+	}
+
+	if len(p.UserStories) > 0 {
+		c.tag("h2", "User Stories")
+		for _, story := range p.UserStories {
+			heading := esc(story.ID)
+			if story.Priority != "" {
+				heading += " " + confluenceStatus(story.Priority, confluencePriorityColours)
+			}
+			c.write(fmt.Sprintf("<h3>%s</h3>", heading))
+			c.tag("p", story.Story)
+			if story.EffortEstimate != "" {
+				c.write(fmt.Sprintf("<p><strong>Effort Estimate:</strong> %s</p>", esc(story.EffortEstimate)))
+			}
+			if len(story.AcceptanceCriteria) > 0 {
+				c.write(`<ac:structured-macro ac:name="expand"><ac:parameter ac:name="title">Acceptance Criteria</ac:parameter><ac:rich-text-body>`)
+				c.list(story.AcceptanceCriteria)
+				c.write(`</ac:rich-text-body></ac:structured-macro>`)
+			}
+		}
+	}
+
+	c.tag("h2", "Requirements")
+	if len(p.Requirements.Functional) > 0 {
+		c.tag("h3", "Functional Requirements")
+		var rows [][]string
+		for _, req := range p.Requirements.Functional {
+			rows = append(rows, []string{
+				esc(req.ID),
+				esc(req.Description),
+				confluenceStatus(req.Priority, confluencePriorityColours),
+				esc(strings.Join(req.Dependencies, ", ")),
+			})
+		}
+		c.table([]string{"ID", "Description", "Priority", "Dependencies"}, rows)
+	}
+	if len(p.Requirements.NonFunctional) > 0 {
+		c.tag("h3", "Non-Functional Requirements")
+		var rows [][]string
+		for _, req := range p.Requirements.NonFunctional {
+			criteria := ""
+			if req.AcceptanceCriteria != "" {
+				criteria = `<ac:structured-macro ac:name="expand"><ac:parameter ac:name="title">Acceptance Criteria</ac:parameter><ac:rich-text-body><p>` +
+					esc(req.AcceptanceCriteria) + `</p></ac:rich-text-body></ac:structured-macro>`
+			}
+			rows = append(rows, []string{esc(req.ID), esc(string(req.Category)), esc(req.Description), criteria})
+		}
+		c.table([]string{"ID", "Category", "Description", "Acceptance Criteria"}, rows)
+	}
+
+	if specs := p.TechnicalSpecifications; specs != nil {
+		c.tag("h2", "Technical Specifications")
+		if specs.ArchitectureOverview != "" {
+			c.tag("h3", "Architecture Overview")
+			c.tag("p", specs.ArchitectureOverview)
+		}
+		if stack := specs.TechnologyStack; stack != nil {
+			c.tag("h3", "Technology Stack")
+			var items []string
+			for _, layer := range []struct {
+				name  string
+				items []string
+			}{
+				{"Frontend", stack.Frontend},
+				{"Backend", stack.Backend},
+				{"Database", stack.Database},
+				{"Infrastructure", stack.Infrastructure},
+			} {
+				if len(layer.items) > 0 {
+					items = append(items, fmt.Sprintf("%s: %s", layer.name, strings.Join(layer.items, ", ")))
+				}
+			}
+			c.list(items)
+		}
+		if len(specs.APISpecifications) > 0 {
+			c.tag("h3", "API Specifications")
+			var rows [][]string
+			for _, api := range specs.APISpecifications {
+				rows = append(rows, []string{esc(api.Method), "<code>" + esc(api.Endpoint) + "</code>", esc(api.Description)})
+			}
+			c.table([]string{"Method", "Endpoint", "Description"}, rows)
+		}
+		if len(specs.SecurityConsiderations) > 0 {
+			c.tag("h3", "Security Considerations")
+			c.list(specs.SecurityConsiderations)
+		}
+	}
+
+	if timeline := p.Timeline; timeline != nil {
+		c.tag("h2", "Timeline")
+		if !timeline.LaunchDate.IsZero() {
+			c.write(fmt.Sprintf("<p><strong>Target Launch Date:</strong> %s</p>", esc(timeline.LaunchDate.String())))
+		}
+		if len(timeline.Milestones) > 0 {
+			var rows [][]string
+			for _, m := range timeline.Milestones {
+				rows = append(rows, []string{esc(m.Name), esc(m.TargetDate.String()), esc(m.Description), esc(strings.Join(m.Dependencies, ", "))})
+			}
+			c.table([]string{"Milestone", "Target Date", "Description", "Dependencies"}, rows)
+		}
+	}
+
+	if ra := p.RisksAndAssumptions; ra != nil {
+		c.tag("h2", "Risks and Assumptions")
+		if len(ra.Risks) > 0 {
+			c.tag("h3", "Risks")
+			var rows [][]string
+			for _, risk := range ra.Risks {
+				rows = append(rows, []string{
+					esc(risk.Description),
+					confluenceStatus(risk.Impact, confluencePriorityColours),
+					confluenceStatus(risk.Probability, confluencePriorityColours),
+					esc(risk.MitigationStrategy),
+				})
+			}
+			c.table([]string{"Risk", "Impact", "Probability", "Mitigation Strategy"}, rows)
+		}
+		if len(ra.Assumptions) > 0 {
+			c.tag("h3", "Assumptions")
+			c.list(assumptionBullets(ra.Assumptions))
+		}
+	}
+
+	if len(p.OutOfScope) > 0 {
+		c.tag("h2", "Out of Scope")
+		c.list(p.OutOfScope)
+	}
+
+	return c.b.String()
+}
+
+type confluenceWriter struct {
+	b strings.Builder
+}
+
+func (c *confluenceWriter) write(s string) {
+	c.b.WriteString(s)
+	c.b.WriteString("\n")
+}
+
+// tag writes an element with escaped text content
+func (c *confluenceWriter) tag(name, text string) {
+	c.write(fmt.Sprintf("<%s>%s</%s>", name, esc(text), name))
+}
+
+func (c *confluenceWriter) list(items []string) {
+	c.b.WriteString("<ul>")
+	for _, item := range items {
+		c.b.WriteString("<li>" + esc(item) + "</li>")
+	}
+	c.write("</ul>")
+}
+
+func (c *confluenceWriter) metaRow(label, valueXHTML string) {
+	c.b.WriteString(fmt.Sprintf("<tr><th>%s</th><td>%s</td></tr>", esc(label), valueXHTML))
+}
+
+// table writes a table whose cells are already-escaped XHTML fragments
+func (c *confluenceWriter) table(headers []string, rows [][]string) {
+	c.b.WriteString("<table><tbody><tr>")
+	for _, h := range headers {
+		c.b.WriteString("<th>" + esc(h) + "</th>")
+	}
+	c.b.WriteString("</tr>")
+	for _, row := range rows {
+		c.b.WriteString("<tr>")
+		for _, cell := range row {
+			c.b.WriteString("<td>" + cell + "</td>")
+		}
+		c.b.WriteString("</tr>")
+	}
+	c.write("</tbody></table>")
+}
+
+// confluenceStatus renders a value as a coloured status macro lozenge
+func confluenceStatus[T ~string](value T, colours map[string]string) string {
+	if value == "" {
+		return ""
+	}
+	colour, ok := colours[string(value)]
+	if !ok {
+		colour = "Grey"
+	}
+	return fmt.Sprintf(`<ac:structured-macro ac:name="status"><ac:parameter ac:name="colour">%s</ac:parameter><ac:parameter ac:name="title">%s</ac:parameter></ac:structured-macro>`,
+		colour, esc(string(value)))
+}
+
+// esc escapes text for inclusion in XHTML
+func esc(s string) string {
+	return html.EscapeString(s)
+}
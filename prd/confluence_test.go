@@ -0,0 +1,40 @@
+package prd
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestToConfluence(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	p.Overview.ProblemStatement = "Users see <error> & retry"
+
+	out := p.ToConfluence()
+
+	for _, s := range []string{
+		`<ac:parameter ac:name="title">approved</ac:parameter>`,
+		`<ac:structured-macro ac:name="expand">`,
+		"Users see &lt;error&gt; &amp; retry",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected Confluence output to contain %q", s)
+		}
+	}
+
+	// Storage format must be well-formed XML once the ac: prefix is declared
+	doc := `<root xmlns:ac="http://atlassian.com/content">` + out + `</root>`
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if err != io.EOF {
+				t.Fatalf("Confluence output is not well-formed: %v", err)
+			}
+			break
+		}
+	}
+}
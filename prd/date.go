@@ -0,0 +1,217 @@
+package prd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatePrecision is the granularity of a Date
+type DatePrecision int
+
+// Date precisions, from coarsest to finest
+const (
+	PrecisionQuarter DatePrecision = iota + 1
+	PrecisionMonth
+	PrecisionDay
+)
+
+// Date is a calendar date with day, month or quarter precision. It is
+// written in JSON as "2025-07-15", "2025-07" or "2025-Q3".
+type Date struct {
+	start     time.Time
+	precision DatePrecision
+}
+
+var quarterPattern = regexp.MustCompile(`^(\d{4})-[Qq]([1-4])$`)
+
+// ParseDate parses an ISO date ("2025-07-15"), month ("2025-07") or quarter
+// ("2025-Q3"). RFC 3339 timestamps are truncated to their date.
+func ParseDate(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+	if m := quarterPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		return Quarter(year, quarter), nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return DateOf(t), nil
+	}
+	if t, err := time.Parse("2006-01", s); err == nil {
+		return MonthOf(t), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return DateOf(t), nil
+	}
+	return Date{}, fmt.Errorf("invalid date '%s': expected YYYY-MM-DD, YYYY-MM or YYYY-QN", s)
+}
+
+// MustParseDate is like ParseDate but panics on error
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DateOf returns the day containing t
+func DateOf(t time.Time) Date {
+	return Date{start: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), precision: PrecisionDay}
+}
+
+// MonthOf returns the month containing t
+func MonthOf(t time.Time) Date {
+	return Date{start: time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), precision: PrecisionMonth}
+}
+
+// QuarterOf returns the quarter containing t
+func QuarterOf(t time.Time) Date {
+	return Quarter(t.Year(), (int(t.Month())-1)/3+1)
+}
+
+// Quarter returns the given quarter (1-4) of year
+func Quarter(year, quarter int) Date {
+	return Date{start: time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC), precision: PrecisionQuarter}
+}
+
+// IsZero reports whether the date is unset
+func (d Date) IsZero() bool {
+	return d.precision == 0
+}
+
+// Precision returns the date's granularity
+func (d Date) Precision() DatePrecision {
+	return d.precision
+}
+
+// Start returns the first day of the period
+func (d Date) Start() time.Time {
+	return d.start
+}
+
+// End returns the last day of the period
+func (d Date) End() time.Time {
+	switch d.precision {
+	case PrecisionQuarter:
+		return d.start.AddDate(0, 3, -1)
+	case PrecisionMonth:
+		return d.start.AddDate(0, 1, -1)
+	default:
+		return d.start
+	}
+}
+
+// Compare orders dates by start, with coarser periods before finer ones
+// starting on the same day. It returns -1, 0 or +1.
+func (d Date) Compare(o Date) int {
+	if c := d.start.Compare(o.start); c != 0 {
+		return c
+	}
+	switch {
+	case d.precision < o.precision:
+		return -1
+	case d.precision > o.precision:
+		return 1
+	}
+	return 0
+}
+
+// Before reports whether the whole period ends before o starts
+func (d Date) Before(o Date) bool {
+	return d.End().Before(o.Start())
+}
+
+// After reports whether the whole period starts after o ends
+func (d Date) After(o Date) bool {
+	return d.Start().After(o.End())
+}
+
+// String formats the date at its precision
+func (d Date) String() string {
+	switch d.precision {
+	case PrecisionQuarter:
+		return fmt.Sprintf("%d-Q%d", d.start.Year(), (int(d.start.Month())-1)/3+1)
+	case PrecisionMonth:
+		return d.start.Format("2006-01")
+	case PrecisionDay:
+		return d.start.Format("2006-01-02")
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON encodes the date as a string at its precision
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON parses and validates a date string. An empty string leaves
+// the date unset.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date: %w", err)
+	}
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// SortedMilestones returns the milestones ordered by target date. Milestones
+// without a date sort last; ties keep their original order.
+func (t *Timeline) SortedMilestones() []Milestone {
+	sorted := make([]Milestone, len(t.Milestones))
+	copy(sorted, t.Milestones)
+	sort.SliceStable(sorted, func(i, j int) bool { return milestoneLess(sorted[i], sorted[j]) })
+	return sorted
+}
+
+func milestoneLess(a, b Milestone) bool {
+	if a.TargetDate.IsZero() || b.TargetDate.IsZero() {
+		return !a.TargetDate.IsZero() && b.TargetDate.IsZero()
+	}
+	return a.TargetDate.Compare(b.TargetDate) < 0
+}
+
+// CheckTimeline returns warnings for milestones scheduled after the launch
+// date and for past milestones that are not done while the PRD is not yet
+// completed
+func (p *PRD) CheckTimeline(now time.Time) []string {
+	if p.Timeline == nil {
+		return nil
+	}
+
+	today := DateOf(now)
+	finished := p.Status == StatusCompleted || p.Status == StatusArchived
+
+	var warnings []string
+	launch := p.Timeline.LaunchDate
+	for _, m := range p.Timeline.Milestones {
+		if m.TargetDate.IsZero() {
+			warnings = append(warnings, fmt.Sprintf("Milestone '%s' has no target date", m.Name))
+			continue
+		}
+		if !launch.IsZero() && m.TargetDate.After(launch) {
+			warnings = append(warnings, fmt.Sprintf("Milestone '%s' (%s) is after the launch date (%s)", m.Name, m.TargetDate, launch))
+		}
+		if !finished && !m.Done() && m.TargetDate.Before(today) {
+			warnings = append(warnings, fmt.Sprintf("Milestone '%s' (%s) is in the past but the PRD is %s", m.Name, m.TargetDate, p.Status))
+		}
+	}
+	if !launch.IsZero() && !finished && launch.Before(today) {
+		warnings = append(warnings, fmt.Sprintf("Launch date %s is in the past but the PRD is %s", launch, p.Status))
+	}
+	return warnings
+}
@@ -0,0 +1,151 @@
+package prd
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  string
+		precision DatePrecision
+		end       string
+		wantErr   bool
+	}{
+		{input: "2025-07-15", expected: "2025-07-15", precision: PrecisionDay, end: "2025-07-15"},
+		{input: "2024-02", expected: "2024-02", precision: PrecisionMonth, end: "2024-02-29"},
+		{input: "2025-Q3", expected: "2025-Q3", precision: PrecisionQuarter, end: "2025-09-30"},
+		{input: "2025-q4", expected: "2025-Q4", precision: PrecisionQuarter, end: "2025-12-31"},
+		{input: "2025-07-15T10:30:00Z", expected: "2025-07-15", precision: PrecisionDay, end: "2025-07-15"},
+		{input: "Q3", wantErr: true},
+		{input: "2024-13-45", wantErr: true},
+		{input: "2025-Q5", wantErr: true},
+		{input: "[DATE]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %s", tt.input, d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if d.String() != tt.expected || d.Precision() != tt.precision {
+				t.Errorf("ParseDate(%q) = %s (precision %d), expected %s (precision %d)", tt.input, d, d.Precision(), tt.expected, tt.precision)
+			}
+			if got := d.End().Format("2006-01-02"); got != tt.end {
+				t.Errorf("End() = %s, expected %s", got, tt.end)
+			}
+		})
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	dates := []Date{
+		MustParseDate("2025-08-01"),
+		MustParseDate("2025-07"),
+		MustParseDate("2024-12-31"),
+		MustParseDate("2025-Q3"),
+		MustParseDate("2025-07-15"),
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Compare(dates[j]) < 0 })
+
+	var got []string
+	for _, d := range dates {
+		got = append(got, d.String())
+	}
+	if strings.Join(got, ",") != "2024-12-31,2025-Q3,2025-07,2025-07-15,2025-08-01" {
+		t.Errorf("Unexpected sort order: %v", got)
+	}
+
+	q3 := MustParseDate("2025-Q3")
+	if q3.After(MustParseDate("2025-08-01")) || q3.Before(MustParseDate("2025-08-01")) {
+		t.Error("A quarter should neither precede nor follow a day it contains")
+	}
+	if !q3.After(MustParseDate("2025-06")) || !q3.Before(MustParseDate("2025-10-01")) {
+		t.Error("Expected 2025-Q3 after 2025-06 and before 2025-10-01")
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	var timeline Timeline
+	if err := json.Unmarshal([]byte(`{"milestones":[{"name":"Beta","target_date":"2025-Q3"}]}`), &timeline); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !timeline.LaunchDate.IsZero() {
+		t.Error("Expected missing launch date to be zero")
+	}
+
+	data, err := json.Marshal(timeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"milestones":[{"name":"Beta","target_date":"2025-Q3"}]}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	if _, err := FromJSON(`{"created_date":"2024-13-45"}`); err == nil || !strings.Contains(err.Error(), "invalid date '2024-13-45'") {
+		t.Errorf("Expected invalid date error, got %v", err)
+	}
+}
+
+func TestSortedMilestones(t *testing.T) {
+	timeline := &Timeline{Milestones: []Milestone{
+		{Name: "GA", TargetDate: MustParseDate("2025-Q4")},
+		{Name: "TBD"},
+		{Name: "Beta", TargetDate: MustParseDate("2025-08-15")},
+	}}
+
+	var names []string
+	for _, m := range timeline.SortedMilestones() {
+		names = append(names, m.Name)
+	}
+	if strings.Join(names, ",") != "Beta,GA,TBD" {
+		t.Errorf("Unexpected milestone order: %v", names)
+	}
+	if timeline.Milestones[0].Name != "GA" {
+		t.Error("SortedMilestones should not reorder the timeline")
+	}
+}
+
+func TestCheckTimeline(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	p := &PRD{
+		Status: StatusInDevelopment,
+		Timeline: &Timeline{
+			LaunchDate: MustParseDate("2025-Q3"),
+			Milestones: []Milestone{
+				{Name: "Design", TargetDate: MustParseDate("2025-05")},
+				{Name: "Beta", TargetDate: MustParseDate("2025-09-15")},
+				{Name: "Scale", TargetDate: MustParseDate("2025-10-01")},
+			},
+		},
+	}
+
+	warnings := p.CheckTimeline(now)
+	if len(warnings) != 2 ||
+		!strings.Contains(warnings[0], "'Design' (2025-05) is in the past") ||
+		!strings.Contains(warnings[1], "'Scale' (2025-10-01) is after the launch date (2025-Q3)") {
+		t.Errorf("Unexpected warnings: %v", warnings)
+	}
+
+	p.Timeline.Milestones[0].State = MilestoneDone
+	if warnings := p.CheckTimeline(now); len(warnings) != 1 {
+		t.Errorf("Expected no past-date warning for a done milestone, got %v", warnings)
+	}
+
+	p.Timeline.Milestones[0].State = ""
+	p.Status = StatusCompleted
+	if warnings := p.CheckTimeline(now); len(warnings) != 1 {
+		t.Errorf("Expected only the after-launch warning for a completed PRD, got %v", warnings)
+	}
+}
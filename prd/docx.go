@@ -0,0 +1,477 @@
+package prd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// DOCXOptions configures DOCX rendering
+type DOCXOptions struct {
+	// Modified sets the document modified time. Defaults to LastUpdated or now.
+	Modified *time.Time
+}
+
+// SaveToDOCX writes the PRD as an Office Open XML (.docx) document
+func (p *PRD) SaveToDOCX(filename string, opts DOCXOptions) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", filename, err)
+	}
+	if err := p.WriteDOCX(f, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteDOCX writes the PRD as an Office Open XML (.docx) document with
+// heading styles, a table of contents field, native tables for tabular
+// sections, and core document properties taken from the PRD metadata.
+func (p *PRD) WriteDOCX(w io.Writer, opts DOCXOptions) error {
+	modified := time.Now()
+	if opts.Modified != nil {
+		modified = *opts.Modified
+	} else if p.LastUpdated != nil {
+		modified = *p.LastUpdated
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRootRels},
+		{"docProps/core.xml", p.docxCoreProperties(modified)},
+		{"docProps/app.xml", docxAppProperties},
+		{"word/_rels/document.xml.rels", docxDocumentRels},
+		{"word/document.xml", p.docxDocument()},
+		{"word/styles.xml", docxStyles},
+		{"word/numbering.xml", docxNumbering},
+		{"word/settings.xml", docxSettings},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to create DOCX part %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(fw, part.content); err != nil {
+			return fmt.Errorf("failed to write DOCX part %s: %w", part.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize DOCX: %w", err)
+	}
+	return nil
+}
+
+func (p *PRD) docxCoreProperties(modified time.Time) string {
+	created := modified
+	if !p.CreatedDate.IsZero() {
+		created = p.CreatedDate.Start()
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:dcmitype="http://purl.org/dc/dcmitype/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`)
+	fmt.Fprintf(&b, "<dc:title>%s</dc:title>", xmlEscape(p.Title))
+	b.WriteString("<dc:subject>Product Requirements Document</dc:subject>")
+	fmt.Fprintf(&b, "<dc:creator>%s</dc:creator>", xmlEscape(p.Owner.Name))
+	fmt.Fprintf(&b, "<cp:lastModifiedBy>%s</cp:lastModifiedBy>", xmlEscape(p.Owner.Name))
+	fmt.Fprintf(&b, "<dc:identifier>%s</dc:identifier>", xmlEscape(p.ID))
+	fmt.Fprintf(&b, "<cp:version>%s</cp:version>", xmlEscape(p.Version))
+	fmt.Fprintf(&b, "<cp:contentStatus>%s</cp:contentStatus>", xmlEscape(string(p.Status)))
+	fmt.Fprintf(&b, `<dcterms:created xsi:type="dcterms:W3CDTF">%s</dcterms:created>`, created.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, `<dcterms:modified xsi:type="dcterms:W3CDTF">%s</dcterms:modified>`, modified.UTC().Format(time.RFC3339))
+	b.WriteString(`</cp:coreProperties>`)
+	return b.String()
+}
+
+// docxBuilder accumulates WordprocessingML body content
+type docxBuilder struct {
+	body strings.Builder
+}
+
+func (d *docxBuilder) paragraph(style, text string) {
+	d.body.WriteString("<w:p>")
+	if style != "" {
+		fmt.Fprintf(&d.body, `<w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style)
+	}
+	d.run(text, false)
+	d.body.WriteString("</w:p>")
+}
+
+func (d *docxBuilder) heading(level int, text string) {
+	d.paragraph(fmt.Sprintf("Heading%d", level), text)
+}
+
+// labelled writes a paragraph with a bold label followed by plain text
+func (d *docxBuilder) labelled(label, text string) {
+	d.body.WriteString("<w:p>")
+	d.run(label+": ", true)
+	d.run(text, false)
+	d.body.WriteString("</w:p>")
+}
+
+func (d *docxBuilder) bullets(items []string) {
+	for _, item := range items {
+		d.paragraph("ListBullet", item)
+	}
+}
+
+func (d *docxBuilder) run(text string, bold bool) {
+	d.body.WriteString("<w:r>")
+	if bold {
+		d.body.WriteString("<w:rPr><w:b/></w:rPr>")
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if i > 0 {
+			d.body.WriteString("<w:br/>")
+		}
+		fmt.Fprintf(&d.body, `<w:t xml:space="preserve">%s</w:t>`, xmlEscape(line))
+	}
+	d.body.WriteString("</w:r>")
+}
+
+func (d *docxBuilder) table(headers []string, rows [][]string) {
+	d.body.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="5000" w:type="pct"/></w:tblPr><w:tblGrid>`)
+	for range headers {
+		d.body.WriteString(`<w:gridCol/>`)
+	}
+	d.body.WriteString(`</w:tblGrid>`)
+
+	d.body.WriteString(`<w:tr><w:trPr><w:tblHeader/></w:trPr>`)
+	for _, h := range headers {
+		d.body.WriteString(`<w:tc><w:tcPr><w:shd w:val="clear" w:color="auto" w:fill="F4F4F4"/></w:tcPr><w:p>`)
+		d.run(h, true)
+		d.body.WriteString(`</w:p></w:tc>`)
+	}
+	d.body.WriteString(`</w:tr>`)
+
+	for _, row := range rows {
+		d.body.WriteString(`<w:tr>`)
+		for _, cell := range row {
+			d.body.WriteString(`<w:tc><w:p>`)
+			d.run(cell, false)
+			d.body.WriteString(`</w:p></w:tc>`)
+		}
+		d.body.WriteString(`</w:tr>`)
+	}
+	d.body.WriteString(`</w:tbl>`)
+	// Word requires a paragraph between adjacent tables
+	d.body.WriteString(`<w:p/>`)
+}
+
+func (d *docxBuilder) toc() {
+	d.paragraph("TOCHeading", "Table of Contents")
+	d.body.WriteString(`<w:p>`)
+	d.body.WriteString(`<w:r><w:fldChar w:fldCharType="begin" w:dirty="true"/></w:r>`)
+	d.body.WriteString(`<w:r><w:instrText xml:space="preserve"> TOC \o "1-3" \h \z \u </w:instrText></w:r>`)
+	d.body.WriteString(`<w:r><w:fldChar w:fldCharType="separate"/></w:r>`)
+	d.body.WriteString(`<w:r><w:t>Right-click and choose Update Field to build the table of contents.</w:t></w:r>`)
+	d.body.WriteString(`<w:r><w:fldChar w:fldCharType="end"/></w:r>`)
+	d.body.WriteString(`</w:p>`)
+}
+
+func (p *PRD) docxDocument() string {
+	d := &docxBuilder{}
+
+	d.paragraph("Title", p.Title)
+	meta := [][]string{
+		{"ID", p.ID},
+		{"Version", p.Version},
+		{"Status", string(p.Status)},
+	}
+	if p.Priority != "" {
+		meta = append(meta, []string{"Priority", string(p.Priority)})
+	}
+	owner := p.Owner.Name
+	if p.Owner.Email != "" {
+		owner += fmt.Sprintf(" (%s)", p.Owner.Email)
+	}
+	meta = append(meta, []string{"Owner", owner})
+	if p.Owner.Team != "" {
+		meta = append(meta, []string{"Team", p.Owner.Team})
+	}
+	meta = append(meta, []string{"Created", p.CreatedDate.String()})
+	if p.LastUpdated != nil {
+		meta = append(meta, []string{"Last Updated", p.LastUpdated.Format("2006-01-02 15:04")})
+	}
+	d.table([]string{"Field", "Value"}, meta)
+
+	d.toc()
+
+	if len(p.Stakeholders) > 0 {
+		d.heading(1, "Stakeholders")
+		var rows [][]string
+		for _, s := range p.Stakeholders {
+			rows = append(rows, []string{s.Name, s.Role, s.Team, s.Email})
+		}
+		d.table([]string{"Name", "Role", "Team", "Email"}, rows)
+	}
+
+	d.heading(1, "Overview")
+	d.heading(2, "Problem Statement")
+	d.paragraph("", p.Overview.ProblemStatement)
+	d.heading(2, "Solution Summary")
+	d.paragraph("", p.Overview.SolutionSummary)
+	if p.Overview.TargetAudience != "" {
+		d.heading(2, "Target Audience")
+		d.paragraph("", p.Overview.TargetAudience)
+	}
+	if p.Overview.MarketContext != "" {
+		d.heading(2, "Market Context")
+		d.paragraph("", p.Overview.MarketContext)
+	}
+
+	d.heading(1, "Objectives")
+	if len(p.Objectives.BusinessGoals) > 0 {
+		d.heading(2, "Business Goals")
+		d.bullets(p.Objectives.BusinessGoals)
+	}
+	if len(p.Objectives.SuccessMetrics) > 0 {
+		d.heading(2, "Success Metrics")
+		var rows [][]string
+		for _, m := range p.Objectives.SuccessMetrics {
+			rows = append(rows, []string{m.Metric, m.Target, m.MeasurementMethod})
+		}
+		d.table([]string{"Metric", "Target", "Measurement Method"}, rows)
+	}
+	if len(p.Objectives.OKRs) > 0 {
+		d.heading(2, "OKRs")
+		for _, okr := range p.Objectives.OKRs {
+			d.labelled("Objective", okr.Objective)
+			d.bullets(keyResultBullets(okr.KeyResults))
+		}
+	}
+
+	if len(p.UserPersonas) > 0 {
+		d.heading(1, "User Personas")
+		for _, persona := range p.UserPersonas {
+			d.heading(2, persona.Name)
+			d.paragraph("", persona.Description)
+			if len(persona.Goals) > 0 {
+				d.heading(3, "Goals")
+				d.bullets(persona.Goals)
+			}
+			if len(persona.PainPoints) > 0 {
+				d.heading(3, "Pain Points")
+				d.bullets(persona.PainPoints)
+			}
+		}
+	}
+
+	if len(p.UserStories) > 0 {
+		d.heading(1, "User Stories")
+		for _, story := range p.UserStories {
+			d.heading(2, story.ID)
+			d.paragraph("", story.Story)
+			if story.Priority != "" {
+				d.labelled("Priority", string(story.Priority))
+			}
+			if story.EffortEstimate != "" {
+				d.labelled("Effort Estimate", story.EffortEstimate)
+			}
+			if len(story.AcceptanceCriteria) > 0 {
+				d.heading(3, "Acceptance Criteria")
+				d.bullets(story.AcceptanceCriteria)
+			}
+		}
+	}
+
+	d.heading(1, "Requirements")
+	if len(p.Requirements.Functional) > 0 {
+		d.heading(2, "Functional Requirements")
+		var rows [][]string
+		for _, req := range p.Requirements.Functional {
+			rows = append(rows, []string{req.ID, req.Description, string(req.Priority), strings.Join(req.Dependencies, ", ")})
+		}
+		d.table([]string{"ID", "Description", "Priority", "Dependencies"}, rows)
+	}
+	if len(p.Requirements.NonFunctional) > 0 {
+		d.heading(2, "Non-Functional Requirements")
+		var rows [][]string
+		for _, req := range p.Requirements.NonFunctional {
+			rows = append(rows, []string{req.ID, string(req.Category), req.Description, req.AcceptanceCriteria})
+		}
+		d.table([]string{"ID", "Category", "Description", "Acceptance Criteria"}, rows)
+	}
+
+	if specs := p.TechnicalSpecifications; specs != nil {
+		d.heading(1, "Technical Specifications")
+		if specs.ArchitectureOverview != "" {
+			d.heading(2, "Architecture Overview")
+			d.paragraph("", specs.ArchitectureOverview)
+		}
+		if stack := specs.TechnologyStack; stack != nil {
+			d.heading(2, "Technology Stack")
+			if len(stack.Frontend) > 0 {
+				d.labelled("Frontend", strings.Join(stack.Frontend, ", "))
+			}
+			if len(stack.Backend) > 0 {
+				d.labelled("Backend", strings.Join(stack.Backend, ", "))
+			}
+			if len(stack.Database) > 0 {
+				d.labelled("Database", strings.Join(stack.Database, ", "))
+			}
+			if len(stack.Infrastructure) > 0 {
+				d.labelled("Infrastructure", strings.Join(stack.Infrastructure, ", "))
+			}
+		}
+		if len(specs.APISpecifications) > 0 {
+			d.heading(2, "API Specifications")
+			var rows [][]string
+			for _, api := range specs.APISpecifications {
+				rows = append(rows, []string{api.Method, api.Endpoint, api.Description})
+			}
+			d.table([]string{"Method", "Endpoint", "Description"}, rows)
+		}
+		if len(specs.SecurityConsiderations) > 0 {
+			d.heading(2, "Security Considerations")
+			d.bullets(specs.SecurityConsiderations)
+		}
+	}
+
+	if timeline := p.Timeline; timeline != nil {
+		d.heading(1, "Timeline")
+		if !timeline.LaunchDate.IsZero() {
+			d.labelled("Target Launch Date", timeline.LaunchDate.String())
+		}
+		if len(timeline.Milestones) > 0 {
+			d.heading(2, "Milestones")
+			var rows [][]string
+			for _, m := range timeline.Milestones {
+				rows = append(rows, []string{m.Name, m.TargetDate.String(), m.Description, strings.Join(m.Dependencies, ", ")})
+			}
+			d.table([]string{"Milestone", "Target Date", "Description", "Dependencies"}, rows)
+		}
+	}
+
+	if ra := p.RisksAndAssumptions; ra != nil {
+		d.heading(1, "Risks and Assumptions")
+		if len(ra.Risks) > 0 {
+			d.heading(2, "Risks")
+			var rows [][]string
+			for _, risk := range ra.Risks {
+				rows = append(rows, []string{risk.Description, string(risk.Impact), string(risk.Probability), risk.MitigationStrategy})
+			}
+			d.table([]string{"Risk", "Impact", "Probability", "Mitigation Strategy"}, rows)
+		}
+		if len(ra.Assumptions) > 0 {
+			d.heading(2, "Assumptions")
+			d.bullets(assumptionBullets(ra.Assumptions))
+		}
+	}
+
+	if len(p.OutOfScope) > 0 {
+		d.heading(1, "Out of Scope")
+		d.bullets(p.OutOfScope)
+	}
+
+	if appendices := p.Appendices; appendices != nil {
+		d.heading(1, "Appendices")
+		if appendices.ResearchData != "" {
+			d.heading(2, "Research Data")
+			d.paragraph("", appendices.ResearchData)
+		}
+		if len(appendices.MockupsWireframes) > 0 {
+			d.heading(2, "Mockups and Wireframes")
+			var rows [][]string
+			for _, m := range appendices.MockupsWireframes {
+				rows = append(rows, []string{m.Name, m.Description, m.URL})
+			}
+			d.table([]string{"Name", "Description", "URL"}, rows)
+		}
+		if len(appendices.RelatedDocuments) > 0 {
+			d.heading(2, "Related Documents")
+			var rows [][]string
+			for _, doc := range appendices.RelatedDocuments {
+				rows = append(rows, []string{doc.Title, doc.Type, doc.URL})
+			}
+			d.table([]string{"Title", "Type", "URL"}, rows)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>`)
+	b.WriteString(d.body.String())
+	b.WriteString(`<w:sectPr><w:pgSz w:w="12240" w:h="15840"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="720" w:footer="720" w:gutter="0"/></w:sectPr>`)
+	b.WriteString(`</w:body></w:document>`)
+	return b.String()
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+const docxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>` +
+	`<Override PartName="/word/settings.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.settings+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`<Override PartName="/docProps/app.xml" ContentType="application/vnd.openxmlformats-officedocument.extended-properties+xml"/>` +
+	`</Types>`
+
+const docxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties" Target="docProps/app.xml"/>` +
+	`</Relationships>`
+
+const docxDocumentRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>` +
+	`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/settings" Target="settings.xml"/>` +
+	`</Relationships>`
+
+const docxAppProperties = xml.Header + `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties">` +
+	`<Application>prd-manager</Application>` +
+	`</Properties>`
+
+// docxSettings asks Word to refresh fields, including the TOC, on open
+const docxSettings = xml.Header + `<w:settings xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:updateFields w:val="true"/>` +
+	`</w:settings>`
+
+const docxNumbering = xml.Header + `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:abstractNum w:abstractNumId="0"><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="•"/><w:lvlJc w:val="left"/>` +
+	`<w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr></w:lvl></w:abstractNum>` +
+	`<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>` +
+	`</w:numbering>`
+
+const docxStyles = xml.Header + `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="22"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="120" w:line="264" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:spacing w:after="240"/></w:pPr><w:rPr><w:b/><w:color w:val="2C3E50"/><w:sz w:val="52"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:color w:val="2C3E50"/><w:sz w:val="36"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:color w:val="34495E"/><w:sz w:val="28"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="200" w:after="60"/><w:outlineLvl w:val="2"/></w:pPr><w:rPr><w:b/><w:sz w:val="24"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="TOCHeading"><w:name w:val="TOC Heading"/><w:basedOn w:val="Heading1"/><w:next w:val="Normal"/>` +
+	`<w:pPr><w:outlineLvl w:val="9"/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr><w:spacing w:after="60"/></w:pPr></w:style>` +
+	`<w:style w:type="table" w:styleId="TableGrid"><w:name w:val="Table Grid"/>` +
+	`<w:tblPr><w:tblBorders><w:top w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:left w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/>` +
+	`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:right w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/>` +
+	`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/></w:tblBorders>` +
+	`<w:tblCellMar><w:left w:w="108" w:type="dxa"/><w:right w:w="108" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>` +
+	`</w:styles>`
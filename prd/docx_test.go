@@ -0,0 +1,72 @@
+package prd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOCX(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	p.Overview.ProblemStatement = "Login fails when <b> & friends appear"
+
+	var buf bytes.Buffer
+	if err := p.WriteDOCX(&buf, DOCXOptions{}); err != nil {
+		t.Fatalf("Failed to write DOCX: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("DOCX is not a valid zip archive: %v", err)
+	}
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[f.Name] = string(data)
+
+		// Every part must be well-formed XML
+		dec := xml.NewDecoder(bytes.NewReader(data))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("Part %s is not well-formed XML: %v", f.Name, err)
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "word/document.xml", "word/styles.xml", "docProps/core.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Expected DOCX part %s", name)
+		}
+	}
+
+	doc := parts["word/document.xml"]
+	for _, s := range []string{`w:val="Heading1"`, `TOC \o`, "<w:tbl>", "FR-001", "&lt;b&gt; &amp; friends"} {
+		if !strings.Contains(doc, s) {
+			t.Errorf("Expected document.xml to contain %q", s)
+		}
+	}
+
+	core := parts["docProps/core.xml"]
+	for _, s := range []string{"<dc:identifier>PRD-001</dc:identifier>", "<cp:version>1.0.0</cp:version>", "<dc:creator>Jane Smith</dc:creator>"} {
+		if !strings.Contains(core, s) {
+			t.Errorf("Expected core.xml to contain %q", s)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package prd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Status is the lifecycle status of a PRD
+type Status string
+
+// PRD statuses
+const (
+	StatusDraft         Status = "draft"
+	StatusReview        Status = "review"
+	StatusApproved      Status = "approved"
+	StatusInDevelopment Status = "in_development"
+	StatusCompleted     Status = "completed"
+	StatusArchived      Status = "archived"
+)
+
+// Values returns all statuses in lifecycle order
+func (Status) Values() []Status {
+	return []Status{StatusDraft, StatusReview, StatusApproved, StatusInDevelopment, StatusCompleted, StatusArchived}
+}
+
+// Valid reports whether s is a known status
+func (s Status) Valid() bool { return enumValid(s, s.Values()) }
+
+// UnmarshalJSON rejects unknown statuses
+func (s *Status) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, s, "status", s.Values())
+}
+
+// Priority is the business priority of a PRD
+type Priority string
+
+// PRD priorities
+const (
+	PriorityCritical Priority = "critical"
+	PriorityHigh     Priority = "high"
+	PriorityMedium   Priority = "medium"
+	PriorityLow      Priority = "low"
+)
+
+// Values returns all priorities from highest to lowest
+func (Priority) Values() []Priority {
+	return []Priority{PriorityCritical, PriorityHigh, PriorityMedium, PriorityLow}
+}
+
+// Valid reports whether p is a known priority
+func (p Priority) Valid() bool { return enumValid(p, p.Values()) }
+
+// UnmarshalJSON rejects unknown priorities
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, p, "priority", p.Values())
+}
+
+// MoSCoW is the MoSCoW priority of a user story or requirement
+type MoSCoW string
+
+// MoSCoW priorities
+const (
+	MustHave   MoSCoW = "must_have"
+	ShouldHave MoSCoW = "should_have"
+	CouldHave  MoSCoW = "could_have"
+	WontHave   MoSCoW = "wont_have"
+)
+
+// Values returns all MoSCoW priorities from highest to lowest
+func (MoSCoW) Values() []MoSCoW {
+	return []MoSCoW{MustHave, ShouldHave, CouldHave, WontHave}
+}
+
+// Valid reports whether m is a known MoSCoW priority
+func (m MoSCoW) Valid() bool { return enumValid(m, m.Values()) }
+
+// UnmarshalJSON rejects unknown MoSCoW priorities
+func (m *MoSCoW) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, m, "MoSCoW priority", m.Values())
+}
+
+// NFRCategory is the category of a non-functional requirement
+type NFRCategory string
+
+// Non-functional requirement categories
+const (
+	NFRPerformance     NFRCategory = "performance"
+	NFRSecurity        NFRCategory = "security"
+	NFRUsability       NFRCategory = "usability"
+	NFRReliability     NFRCategory = "reliability"
+	NFRScalability     NFRCategory = "scalability"
+	NFRMaintainability NFRCategory = "maintainability"
+	NFRCompliance      NFRCategory = "compliance"
+)
+
+// Values returns all non-functional requirement categories
+func (NFRCategory) Values() []NFRCategory {
+	return []NFRCategory{NFRPerformance, NFRSecurity, NFRUsability, NFRReliability, NFRScalability, NFRMaintainability, NFRCompliance}
+}
+
+// Valid reports whether c is a known category
+func (c NFRCategory) Valid() bool { return enumValid(c, c.Values()) }
+
+// UnmarshalJSON rejects unknown categories
+func (c *NFRCategory) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, c, "non-functional requirement category", c.Values())
+}
+
+// MilestoneState is the delivery state of a milestone
+type MilestoneState string
+
+// Milestone states
+const (
+	MilestonePlanned    MilestoneState = "planned"
+	MilestoneInProgress MilestoneState = "in_progress"
+	MilestoneDone       MilestoneState = "done"
+	MilestoneAtRisk     MilestoneState = "at_risk"
+	MilestoneSlipped    MilestoneState = "slipped"
+)
+
+// Values returns all milestone states
+func (MilestoneState) Values() []MilestoneState {
+	return []MilestoneState{MilestonePlanned, MilestoneInProgress, MilestoneDone, MilestoneAtRisk, MilestoneSlipped}
+}
+
+// Valid reports whether s is a known milestone state
+func (s MilestoneState) Valid() bool { return enumValid(s, s.Values()) }
+
+// UnmarshalJSON rejects unknown milestone states
+func (s *MilestoneState) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, s, "milestone state", s.Values())
+}
+
+// RiskLevel is the impact or probability of a risk
+type RiskLevel string
+
+// Risk levels
+const (
+	RiskLow      RiskLevel = "low"
+	RiskMedium   RiskLevel = "medium"
+	RiskHigh     RiskLevel = "high"
+	RiskCritical RiskLevel = "critical"
+)
+
+// Values returns all risk levels from lowest to highest
+func (RiskLevel) Values() []RiskLevel {
+	return []RiskLevel{RiskLow, RiskMedium, RiskHigh, RiskCritical}
+}
+
+// Valid reports whether l is a known risk level
+func (l RiskLevel) Valid() bool { return enumValid(l, l.Values()) }
+
+// Score returns 1 (low) to 4 (critical), or 0 if the level is unset or
+// unknown
+func (l RiskLevel) Score() int {
+	for i, v := range l.Values() {
+		if l == v {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// UnmarshalJSON normalizes case and surrounding space, so "High" is read as
+// "high", and rejects unknown levels
+func (l *RiskLevel) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if normalized, err := json.Marshal(strings.ToLower(strings.TrimSpace(s))); err == nil {
+			data = normalized
+		}
+	}
+	return enumUnmarshal(data, l, "risk level", l.Values())
+}
+
+// RiskStatus is the handling status of a risk
+type RiskStatus string
+
+// Risk statuses
+const (
+	RiskOpen      RiskStatus = "open"
+	RiskMitigated RiskStatus = "mitigated"
+	RiskAccepted  RiskStatus = "accepted"
+	RiskClosed    RiskStatus = "closed"
+)
+
+// Values returns all risk statuses
+func (RiskStatus) Values() []RiskStatus {
+	return []RiskStatus{RiskOpen, RiskMitigated, RiskAccepted, RiskClosed}
+}
+
+// Valid reports whether s is a known risk status
+func (s RiskStatus) Valid() bool { return enumValid(s, s.Values()) }
+
+// UnmarshalJSON rejects unknown risk statuses
+func (s *RiskStatus) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, s, "risk status", s.Values())
+}
+
+// Confidence is how strongly an assumption is believed, or a key result
+// expected to be met
+type Confidence string
+
+// Confidence levels
+const (
+	ConfidenceLow    Confidence = "low"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceHigh   Confidence = "high"
+)
+
+// Values returns all confidence levels from lowest to highest
+func (Confidence) Values() []Confidence {
+	return []Confidence{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+}
+
+// Valid reports whether c is a known confidence level
+func (c Confidence) Valid() bool { return enumValid(c, c.Values()) }
+
+// UnmarshalJSON rejects unknown confidence levels
+func (c *Confidence) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, c, "confidence", c.Values())
+}
+
+// AssumptionResult is the outcome of testing an assumption
+type AssumptionResult string
+
+// Assumption results
+const (
+	AssumptionUntested     AssumptionResult = "untested"
+	AssumptionValidated    AssumptionResult = "validated"
+	AssumptionInvalidated  AssumptionResult = "invalidated"
+	AssumptionInconclusive AssumptionResult = "inconclusive"
+)
+
+// Values returns all assumption results
+func (AssumptionResult) Values() []AssumptionResult {
+	return []AssumptionResult{AssumptionUntested, AssumptionValidated, AssumptionInvalidated, AssumptionInconclusive}
+}
+
+// Valid reports whether r is a known assumption result
+func (r AssumptionResult) Valid() bool { return enumValid(r, r.Values()) }
+
+// UnmarshalJSON rejects unknown assumption results
+func (r *AssumptionResult) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, r, "assumption result", r.Values())
+}
+
+// Comparator compares a measured value against a success metric's goal
+type Comparator string
+
+// Comparators
+const (
+	AtLeast Comparator = ">="
+	Above   Comparator = ">"
+	AtMost  Comparator = "<="
+	Below   Comparator = "<"
+	EqualTo Comparator = "=="
+)
+
+// Values returns all comparators
+func (Comparator) Values() []Comparator {
+	return []Comparator{AtLeast, Above, AtMost, Below, EqualTo}
+}
+
+// Valid reports whether c is a known comparator
+func (c Comparator) Valid() bool { return enumValid(c, c.Values()) }
+
+// UnmarshalJSON rejects unknown comparators
+func (c *Comparator) UnmarshalJSON(data []byte) error {
+	return enumUnmarshal(data, c, "comparator", c.Values())
+}
+
+// Compare reports whether value satisfies the comparator against goal
+func (c Comparator) Compare(value, goal float64) bool {
+	switch c {
+	case AtLeast:
+		return value >= goal
+	case Above:
+		return value > goal
+	case AtMost:
+		return value <= goal
+	case Below:
+		return value < goal
+	case EqualTo:
+		return value == goal
+	default:
+		return false
+	}
+}
+
+// MetricStatus is where a success metric stands against its goal. It is
+// computed from measurements, not stored.
+type MetricStatus string
+
+// Metric statuses
+const (
+	MetricNoData   MetricStatus = "no_data"
+	MetricOnTrack  MetricStatus = "on_track"
+	MetricOffTrack MetricStatus = "off_track"
+	MetricMet      MetricStatus = "met"
+	MetricMissed   MetricStatus = "missed"
+)
+
+// Values returns all metric statuses
+func (MetricStatus) Values() []MetricStatus {
+	return []MetricStatus{MetricNoData, MetricOnTrack, MetricOffTrack, MetricMet, MetricMissed}
+}
+
+// EnumStrings converts enum values to strings, e.g. for CLI menus
+func EnumStrings[T ~string](values []T) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return s
+}
+
+func enumValid[T ~string](v T, values []T) bool {
+	for _, known := range values {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// enumUnmarshal decodes a JSON string into v, rejecting values not in
+// values. Empty strings are accepted as unset.
+func enumUnmarshal[T ~string](data []byte, v *T, name string, values []T) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if s != "" && !enumValid(T(s), values) {
+		return fmt.Errorf("invalid %s '%s': expected one of %s", name, s, strings.Join(EnumStrings(values), ", "))
+	}
+	*v = T(s)
+	return nil
+}
@@ -0,0 +1,102 @@
+package prd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestEnumValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"status draft", StatusDraft.Valid()},
+		{"status in_development", Status("in_development").Valid()},
+		{"priority critical", PriorityCritical.Valid()},
+		{"moscow could_have", MoSCoW("could_have").Valid()},
+		{"nfr compliance", NFRCategory("compliance").Valid()},
+	}
+	for _, tt := range tests {
+		if !tt.valid {
+			t.Errorf("Expected %s to be valid", tt.name)
+		}
+	}
+
+	for _, invalid := range []bool{
+		Status("done").Valid(),
+		Status("").Valid(),
+		Priority("urgent").Valid(),
+		MoSCoW("must-have").Valid(),
+		NFRCategory("Performance").Valid(),
+	} {
+		if invalid {
+			t.Error("Expected unknown enum value to be invalid")
+		}
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	if got := EnumStrings(Status("").Values()); strings.Join(got, ",") != "draft,review,approved,in_development,completed,archived" {
+		t.Errorf("Unexpected status values: %v", got)
+	}
+	if got := len(NFRCategory("").Values()); got != 7 {
+		t.Errorf("Expected 7 NFR categories, got %d", got)
+	}
+	for _, v := range MoSCoW("").Values() {
+		if !v.Valid() {
+			t.Errorf("Value %s not valid", v)
+		}
+	}
+}
+
+func TestEnumUnmarshalJSON(t *testing.T) {
+	var story UserStory
+	if err := json.Unmarshal([]byte(`{"id":"US-001","priority":"should_have"}`), &story); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if story.Priority != ShouldHave {
+		t.Errorf("Expected should_have, got %s", story.Priority)
+	}
+
+	// Empty values mean unset and are left to Validate
+	var req FunctionalRequirement
+	if err := json.Unmarshal([]byte(`{"id":"FR-001","priority":""}`), &req); err != nil {
+		t.Errorf("Expected empty priority to be accepted, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"status", `{"status":"done"}`, "invalid status 'done'"},
+		{"priority", `{"priority":"urgent"}`, "invalid priority 'urgent'"},
+		{"story priority", `{"user_stories":[{"id":"US-001","priority":"must"}]}`, "invalid MoSCoW priority 'must'"},
+		{"nfr category", `{"requirements":{"non_functional":[{"id":"NFR-001","category":"speed"}]}}`, "invalid non-functional requirement category 'speed'"},
+		{"wrong type", `{"status":3}`, "invalid status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromJSON(tt.json)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateEnums(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Expected example PRD to be valid, got %v", err)
+	}
+
+	p.Requirements.NonFunctional[0].Category = "speed"
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "invalid category") {
+		t.Errorf("Expected invalid category error, got %v", err)
+	}
+}
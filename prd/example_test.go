@@ -12,7 +12,7 @@ func TestPRDMarshalUnmarshal(t *testing.T) {
 		ID:          "PRD-TEST-001",
 		Title:       "Test Product Feature",
 		Version:     "1.0.0",
-		CreatedDate: "2024-01-15",
+		CreatedDate: MustParseDate("2024-01-15"),
 		LastUpdated: &now,
 		Owner: Owner{
 			Name:  "Test Owner",
@@ -85,7 +85,7 @@ func TestPRDFileOperations(t *testing.T) {
 		ID:          "PRD-FILE-TEST-001",
 		Title:       "File Test Product",
 		Version:     "1.0.0",
-		CreatedDate: "2024-01-15",
+		CreatedDate: MustParseDate("2024-01-15"),
 		Owner: Owner{
 			Name:  "File Test Owner",
 			Email: "filetest@example.com",
@@ -144,7 +144,7 @@ func TestPRDValidation(t *testing.T) {
 				ID:          "PRD-VALID-001",
 				Title:       "Valid Product",
 				Version:     "1.0.0",
-				CreatedDate: "2024-01-15",
+				CreatedDate: MustParseDate("2024-01-15"),
 				Owner: Owner{
 					Name:  "Valid Owner",
 					Email: "valid@example.com",
@@ -245,7 +245,7 @@ func TestUpdateLastModified(t *testing.T) {
 		ID:          "PRD-UPDATE-001",
 		Title:       "Update Test Product",
 		Version:     "1.0.0",
-		CreatedDate: "2024-01-15",
+		CreatedDate: MustParseDate("2024-01-15"),
 		Owner: Owner{
 			Name:  "Test Owner",
 			Email: "test@example.com",
@@ -0,0 +1,315 @@
+package prd
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// GanttOptions configures Gantt chart rendering
+type GanttOptions struct {
+	// Today, when set, adds a marker for the given date
+	Today *time.Time
+}
+
+// ganttTask is one milestone bar. Milestones without dependencies and with
+// a day target are drawn as points; the others span from their latest
+// dependency (or the start of their target period) to their target date.
+type ganttTask struct {
+	ID       string
+	Name     string
+	Start    time.Time
+	End      time.Time
+	Point    bool
+	Critical bool
+	Deps     []string
+}
+
+// ganttTasks schedules the dated milestones in dependency order
+func (p *PRD) ganttTasks() ([]ganttTask, *TimelineAnalysis, error) {
+	analysis, err := p.AnalyzeTimeline(time.Time{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := map[string]string{}
+	tasks := make([]ganttTask, 0, len(analysis.Milestones))
+	for i, m := range analysis.Milestones {
+		task := ganttTask{
+			ID:       fmt.Sprintf("m%d", i+1),
+			Name:     m.Name,
+			Start:    m.TargetDate.Start(),
+			End:      m.TargetDate.End(),
+			Critical: m.Critical,
+		}
+		for _, dep := range m.Dependencies {
+			task.Deps = append(task.Deps, ids[dep])
+		}
+		if len(m.Dependencies) > 0 {
+			if m.DurationDays > 0 {
+				task.Start = task.End.AddDate(0, 0, 1-m.DurationDays)
+			} else {
+				task.Start = task.End
+			}
+		} else {
+			task.Point = m.TargetDate.Precision() == PrecisionDay
+		}
+		ids[m.Name] = task.ID
+		tasks = append(tasks, task)
+	}
+	return tasks, analysis, nil
+}
+
+// ToMermaidGantt renders the milestones as a Mermaid gantt chart.
+// Dependencies are expressed with "after", and Mermaid draws its own today
+// marker, so opts.Today is not used.
+func (p *PRD) ToMermaidGantt(opts GanttOptions) (string, error) {
+	tasks, analysis, err := p.ganttTasks()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	if p.Title != "" {
+		b.WriteString("    title " + mermaidText(p.Title) + "\n")
+	}
+	b.WriteString("    dateFormat YYYY-MM-DD\n")
+	b.WriteString("    axisFormat %b %Y\n")
+	b.WriteString("    section Milestones\n")
+
+	for _, task := range tasks {
+		var tags []string
+		if task.Critical {
+			tags = append(tags, "crit")
+		}
+		if task.Point {
+			tags = append(tags, "milestone")
+		}
+		tags = append(tags, task.ID)
+
+		switch {
+		case task.Point:
+			tags = append(tags, ganttDay(task.End), "0d")
+		case len(task.Deps) > 0:
+			tags = append(tags, "after "+strings.Join(task.Deps, " "), ganttDay(task.End.AddDate(0, 0, 1)))
+		default:
+			tags = append(tags, ganttDay(task.Start), ganttDay(task.End.AddDate(0, 0, 1)))
+		}
+		fmt.Fprintf(&b, "    %s :%s\n", mermaidText(task.Name), strings.Join(tags, ", "))
+	}
+
+	if !analysis.LaunchDate.IsZero() {
+		b.WriteString("    section Launch\n")
+		fmt.Fprintf(&b, "    Launch :milestone, launch, %s, 0d\n", ganttDay(analysis.LaunchDate.End()))
+	}
+	return b.String(), nil
+}
+
+// ToPlantUMLGantt renders the milestones as a PlantUML gantt chart
+func (p *PRD) ToPlantUMLGantt(opts GanttOptions) (string, error) {
+	tasks, analysis, err := p.ganttTasks()
+	if err != nil {
+		return "", err
+	}
+
+	names := map[string]string{}
+	var b strings.Builder
+	b.WriteString("@startgantt\n")
+	if p.Title != "" {
+		b.WriteString("title " + plantUMLText(p.Title) + "\n")
+	}
+	if len(tasks) > 0 {
+		start := tasks[0].Start
+		for _, task := range tasks {
+			if task.Start.Before(start) {
+				start = task.Start
+			}
+		}
+		b.WriteString("Project starts " + ganttDay(start) + "\n")
+	}
+	if opts.Today != nil {
+		b.WriteString("today is " + ganttDay(*opts.Today) + " and is colored in #FFD6D6\n")
+	}
+
+	for _, task := range tasks {
+		name := "[" + plantUMLText(task.Name) + "]"
+		names[task.ID] = name
+		if task.Point {
+			b.WriteString(name + " happens " + ganttDay(task.End) + "\n")
+		} else {
+			b.WriteString(name + " starts " + ganttDay(task.Start) + " and ends " + ganttDay(task.End) + "\n")
+		}
+		if task.Critical {
+			b.WriteString(name + " is colored in #E74C3C\n")
+		}
+	}
+	for _, task := range tasks {
+		for _, dep := range task.Deps {
+			b.WriteString(names[dep] + " -> " + names[task.ID] + "\n")
+		}
+	}
+
+	if !analysis.LaunchDate.IsZero() {
+		launch := ganttDay(analysis.LaunchDate.End())
+		b.WriteString("[Launch] happens " + launch + "\n")
+		b.WriteString(launch + " is colored in #2ECC71\n")
+	}
+	b.WriteString("@endgantt\n")
+	return b.String(), nil
+}
+
+// SVG layout, in pixels
+const (
+	ganttLabelWidth = 220
+	ganttChartWidth = 640
+	ganttRowHeight  = 28
+	ganttHeader     = 40
+	ganttFooter     = 24
+	ganttPadDays    = 7
+)
+
+// ToGanttSVG renders the milestones as a self-contained SVG image with
+// dependency arrows, the critical path in red, a launch date line and, when
+// opts.Today is set, a today marker
+func (p *PRD) ToGanttSVG(opts GanttOptions) (string, error) {
+	tasks, analysis, err := p.ganttTasks()
+	if err != nil {
+		return "", err
+	}
+	if len(tasks) == 0 {
+		return "", fmt.Errorf("no dated milestones to chart")
+	}
+
+	from, to := tasks[0].Start, tasks[0].End
+	for _, task := range tasks {
+		if task.Start.Before(from) {
+			from = task.Start
+		}
+		if task.End.After(to) {
+			to = task.End
+		}
+	}
+	var launch time.Time
+	if !analysis.LaunchDate.IsZero() {
+		launch = analysis.LaunchDate.End()
+		if launch.After(to) {
+			to = launch
+		}
+	}
+	from, to = from.AddDate(0, 0, -ganttPadDays), to.AddDate(0, 0, ganttPadDays+1)
+
+	span := to.Sub(from).Hours()
+	x := func(t time.Time) float64 {
+		return ganttLabelWidth + t.Sub(from).Hours()/span*ganttChartWidth
+	}
+	y := func(row int) float64 {
+		return float64(ganttHeader + row*ganttRowHeight + ganttRowHeight/2)
+	}
+	width := ganttLabelWidth + ganttChartWidth + 20
+	height := ganttHeader + len(tasks)*ganttRowHeight + ganttFooter
+	bottom := float64(height - ganttFooter)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" class="gantt" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", width, height, width, height)
+	b.WriteString(`<defs><marker id="gantt-arrow" viewBox="0 0 10 10" refX="9" refY="5" markerWidth="6" markerHeight="6" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="#7f8c8d"/></marker></defs>` + "\n")
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+
+	// Month grid, thinned out for long timelines
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	step := 1 + months/12
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0); m.Before(to); m = m.AddDate(0, step, 0) {
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%.1f" stroke="#ecf0f1"/>`+"\n", x(m), ganttHeader-8, x(m), bottom)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" fill="#7f8c8d">%s</text>`+"\n", x(m)+3, ganttHeader-12, m.Format("Jan 2006"))
+	}
+
+	for row, task := range tasks {
+		fill := "#3498db"
+		if task.Critical {
+			fill = "#e74c3c"
+		}
+		fmt.Fprintf(&b, `<text x="8" y="%.1f" dominant-baseline="middle">%s</text>`+"\n", y(row), html.EscapeString(truncateLabel(task.Name, 32)))
+		if task.Point {
+			cx, cy := x(task.End.AddDate(0, 0, 1)), y(row)
+			fmt.Fprintf(&b, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"><title>%s</title></polygon>`+"\n",
+				cx, cy-7, cx+7, cy, cx, cy+7, cx-7, cy, fill, html.EscapeString(task.Name+": "+ganttDay(task.End)))
+			continue
+		}
+		x1, x2 := x(task.Start), x(task.End.AddDate(0, 0, 1))
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="16" rx="3" fill="%s"><title>%s</title></rect>`+"\n",
+			x1, y(row)-8, max(x2-x1, 2), fill, html.EscapeString(task.Name+": "+ganttDay(task.Start)+" – "+ganttDay(task.End)))
+	}
+
+	// Dependency arrows from the end of each dependency: into the left edge
+	// of a dependent that starts later, or down onto one that starts at once
+	rows := map[string]int{}
+	for row, task := range tasks {
+		rows[task.ID] = row
+	}
+	for row, task := range tasks {
+		for _, dep := range task.Deps {
+			d := tasks[rows[dep]]
+			x1, y1 := x(d.End.AddDate(0, 0, 1)), y(rows[dep])+8
+			x2 := x(task.Start)
+			if task.Point {
+				x2 = x(task.End.AddDate(0, 0, 1)) - 7
+			}
+			path := fmt.Sprintf("M%.1f,%.1f V%.1f", x1, y1, y(row)-9)
+			if x2 > x1+8 {
+				path = fmt.Sprintf("M%.1f,%.1f V%.1f H%.1f", x1, y1, y(row), x2-1)
+			}
+			fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="#7f8c8d" marker-end="url(#gantt-arrow)"/>`+"\n", path)
+		}
+	}
+
+	if !launch.IsZero() {
+		lx := x(launch.AddDate(0, 0, 1))
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%.1f" stroke="#27ae60" stroke-width="2"/>`+"\n", lx, ganttHeader-8, lx, bottom)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" fill="#27ae60" text-anchor="middle">Launch %s</text>`+"\n", lx, bottom+16, ganttDay(launch))
+	}
+	if opts.Today != nil {
+		today := DateOf(*opts.Today).Start()
+		if !today.Before(from) && today.Before(to) {
+			tx := x(today)
+			fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%.1f" stroke="#e67e22" stroke-width="2" stroke-dasharray="4,3"/>`+"\n", tx, ganttHeader-8, tx, bottom)
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d" fill="#e67e22" text-anchor="middle">Today</text>`+"\n", tx, ganttHeader-24)
+		}
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String(), nil
+}
+
+func (t *Timeline) hasDatedMilestones() bool {
+	if t == nil {
+		return false
+	}
+	for _, m := range t.Milestones {
+		if !m.TargetDate.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+func ganttDay(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// mermaidText removes characters that end a Mermaid task name or title
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", " -", "#", "", ";", ",", "\n", " ").Replace(s)
+}
+
+// plantUMLText removes characters that end a PlantUML task name or title
+func plantUMLText(s string) string {
+	return strings.NewReplacer("[", "(", "]", ")", "\r", "", "\n", " ").Replace(s)
+}
+
+func truncateLabel(s string, length int) string {
+	if r := []rune(s); len(r) > length {
+		return string(r[:length-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package prd
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToMermaidGantt(t *testing.T) {
+	p := scheduleFixture()
+	p.Title = "Checkout: v2"
+
+	chart, err := p.ToMermaidGantt(GanttOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"title Checkout - v2\n",
+		"Design :crit, milestone, m1, 2025-03-01, 0d\n",
+		"Backend :crit, m2, after m1, 2025-04-16\n",
+		"Docs :m3, after m1, 2025-04-02\n",
+		"Beta :crit, m4, after m2 m3, 2025-06-02\n",
+		"Launch :milestone, launch, 2025-06-30, 0d\n",
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("Expected chart to contain %q, got:\n%s", want, chart)
+		}
+	}
+	if strings.Contains(chart, "Marketing") {
+		t.Error("Undated milestones should not be charted")
+	}
+}
+
+func TestToPlantUMLGantt(t *testing.T) {
+	today := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	chart, err := scheduleFixture().ToPlantUMLGantt(GanttOptions{Today: &today})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"@startgantt\nProject starts 2025-03-01\n",
+		"today is 2025-04-10",
+		"[Design] happens 2025-03-01\n",
+		"[Backend] starts 2025-03-02 and ends 2025-04-15\n",
+		"[Backend] is colored in",
+		"[Backend] -> [Beta]\n",
+		"[Docs] -> [Beta]\n",
+		"[Launch] happens 2025-06-30\n",
+		"@endgantt\n",
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("Expected chart to contain %q, got:\n%s", want, chart)
+		}
+	}
+	if strings.Contains(chart, "[Docs] is colored") {
+		t.Error("Docs is not on the critical path")
+	}
+
+	p := scheduleFixture()
+	p.Title = "Checkout [v2]\r\n@endgantt"
+	if chart, err := p.ToPlantUMLGantt(GanttOptions{Today: &today}); err != nil || !strings.Contains(chart, "title Checkout (v2) @endgantt\n") {
+		t.Errorf("Expected a sanitized title, got %v:\n%s", err, chart)
+	}
+}
+
+func TestToGanttSVG(t *testing.T) {
+	p := scheduleFixture()
+	p.Timeline.Milestones[1].Name = "Backend <API> & Auth"
+	p.Timeline.Milestones[3].Dependencies = []string{"Backend <API> & Auth", "Docs"}
+
+	today := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	svg, err := p.ToGanttSVG(GanttOptions{Today: &today})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := xml.Unmarshal([]byte(svg), new(struct{})); err != nil {
+		t.Errorf("Expected well-formed SVG: %v", err)
+	}
+	for _, want := range []string{"Backend &lt;API&gt; &amp; Auth", ">Today<", "Launch 2025-06-30", `marker-end="url(#gantt-arrow)"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Expected SVG to contain %q", want)
+		}
+	}
+	if got := strings.Count(svg, "marker-end="); got != 4 {
+		t.Errorf("Expected 4 dependency arrows, got %d", got)
+	}
+
+	outside := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	if svg, _ := p.ToGanttSVG(GanttOptions{Today: &outside}); strings.Contains(svg, ">Today<") {
+		t.Error("Expected no today marker outside the chart range")
+	}
+
+	if _, err := (&PRD{}).ToGanttSVG(GanttOptions{}); err == nil {
+		t.Error("Expected error without dated milestones")
+	}
+}
+
+func TestTimelineExportsEmbedGantt(t *testing.T) {
+	p := scheduleFixture()
+
+	if md := p.ToMarkdown(); !strings.Contains(md, "```mermaid\ngantt\n") {
+		t.Error("Expected Markdown timeline to embed a Mermaid gantt chart")
+	}
+	opts := DefaultMarkdownOptions()
+	opts.Flavor = MarkdownFlavorCommonMark
+	if md := p.ToMarkdownWithOptions(opts); strings.Contains(md, "```mermaid") {
+		t.Error("Expected no Mermaid chart for CommonMark")
+	}
+
+	html, err := p.ToHTML(HTMLOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(html, `<div class="gantt-chart">`) || !strings.Contains(html, "<svg") {
+		t.Error("Expected HTML timeline to embed an SVG gantt chart")
+	}
+}
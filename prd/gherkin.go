@@ -0,0 +1,132 @@
+package prd
+
+import (
+	"regexp"
+	"strings"
+)
+
+// GherkinFeature is a Gherkin feature file generated from a user story
+type GherkinFeature struct {
+	StoryID  string
+	Filename string
+	Content  string
+}
+
+// GherkinStep is a single Given/When/Then step
+type GherkinStep struct {
+	Keyword string
+	Text    string
+}
+
+var (
+	gherkinStart = regexp.MustCompile(`(?i)^\s*(given|when|then)\b`)
+	// Steps start only at the beginning or after punctuation or a line break,
+	// so prose such as "an error is shown when the password is wrong" or
+	// "username and password" is not split. ", and then" starts an And step.
+	gherkinSplit = regexp.MustCompile(`(?i)(?:^\s*|[,;.\n]\s*)(given|when|then)\s+|[,;.\n]\s*(and|but)\s+(?:then\s+)?`)
+	wantClause   = regexp.MustCompile(`(?i)\bI (?:want|need|would like)(?: to)?\s+(.+?)(?:,?\s+so that\b.*)?$`)
+)
+
+// ParseGherkinSteps splits an acceptance criterion written in Given/When/Then
+// form into steps. It returns nil if the criterion is prose.
+func ParseGherkinSteps(criterion string) []GherkinStep {
+	if !gherkinStart.MatchString(criterion) {
+		return nil
+	}
+
+	var steps []GherkinStep
+	matches := gherkinSplit.FindAllStringSubmatchIndex(criterion, -1)
+	for i, m := range matches {
+		kw := m[2:4]
+		if kw[0] < 0 {
+			kw = m[4:6]
+		}
+		end := len(criterion)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		text := strings.TrimRight(strings.TrimSpace(criterion[m[1]:end]), ",;.")
+		keyword := strings.ToUpper(criterion[kw[0]:kw[0]+1]) + strings.ToLower(criterion[kw[0]+1:kw[1]])
+		steps = append(steps, GherkinStep{Keyword: keyword, Text: strings.Join(strings.Fields(text), " ")})
+	}
+	return steps
+}
+
+// ToGherkin generates one feature file per user story. Acceptance criteria
+// already written as Given/When/Then become scenarios with those steps; prose
+// criteria become single-step Then scenarios for QA to refine.
+func (p *PRD) ToGherkin() []GherkinFeature {
+	features := make([]GherkinFeature, 0, len(p.UserStories))
+	for _, story := range p.UserStories {
+		features = append(features, GherkinFeature{
+			StoryID:  story.ID,
+			Filename: GherkinFilename(story.ID),
+			Content:  story.toGherkin(p),
+		})
+	}
+	return features
+}
+
+// GherkinFilename returns the feature filename for a story ID
+func GherkinFilename(id string) string {
+	return strings.TrimSuffix(SitePageFilename(id), ".html") + ".feature"
+}
+
+func (s UserStory) toGherkin(p *PRD) string {
+	var b strings.Builder
+
+	if p.ID != "" {
+		b.WriteString(gherkinTag(p.ID) + "\n")
+	}
+	b.WriteString("Feature: " + s.ID + " " + gherkinFeatureName(s.Story) + "\n")
+	b.WriteString("  " + strings.Join(strings.Fields(s.Story), " ") + "\n")
+	if p.Title != "" {
+		b.WriteString("\n  PRD: " + strings.TrimSpace(p.ID+" "+p.Title) + "\n")
+	}
+
+	tags := gherkinTag(s.ID)
+	if s.Priority != "" {
+		tags += " " + gherkinTag("priority-"+string(s.Priority))
+	}
+
+	for _, criterion := range s.AcceptanceCriteria {
+		criterion = strings.TrimSpace(criterion)
+		if criterion == "" {
+			continue
+		}
+
+		steps := ParseGherkinSteps(criterion)
+		name := strings.Join(strings.Fields(criterion), " ")
+		if steps == nil {
+			steps = []GherkinStep{{Keyword: "Then", Text: name}}
+		} else {
+			for _, step := range steps {
+				if step.Keyword == "Then" {
+					name = step.Text
+					break
+				}
+			}
+		}
+
+		b.WriteString("\n  " + tags + "\n")
+		b.WriteString("  Scenario: " + name + "\n")
+		for _, step := range steps {
+			b.WriteString("    " + step.Keyword + " " + step.Text + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// gherkinFeatureName shortens "As a user, I want to X so that Y" to "X"
+func gherkinFeatureName(story string) string {
+	story = strings.Join(strings.Fields(story), " ")
+	if m := wantClause.FindStringSubmatch(story); m != nil {
+		return strings.TrimRight(m[1], ".")
+	}
+	return strings.TrimRight(story, ".")
+}
+
+func gherkinTag(s string) string {
+	return "@" + strings.Join(strings.Fields(s), "_")
+}
@@ -0,0 +1,111 @@
+package prd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGherkinSteps(t *testing.T) {
+	tests := []struct {
+		name      string
+		criterion string
+		expected  []GherkinStep
+	}{
+		{
+			name:      "prose",
+			criterion: "Fallback to password is available if biometric fails",
+			expected:  nil,
+		},
+		{
+			name:      "single line",
+			criterion: "Given a registered user, when they log in with a fingerprint, then they see the dashboard",
+			expected: []GherkinStep{
+				{Keyword: "Given", Text: "a registered user"},
+				{Keyword: "When", Text: "they log in with a fingerprint"},
+				{Keyword: "Then", Text: "they see the dashboard"},
+			},
+		},
+		{
+			name:      "multi line with and",
+			criterion: "GIVEN biometrics are enabled\nAND the device is locked\nWHEN the user opens the app\nTHEN username and password are not required.",
+			expected: []GherkinStep{
+				{Keyword: "Given", Text: "biometrics are enabled"},
+				{Keyword: "And", Text: "the device is locked"},
+				{Keyword: "When", Text: "the user opens the app"},
+				{Keyword: "Then", Text: "username and password are not required"},
+			},
+		},
+		{
+			name:      "when inside a step",
+			criterion: "Given a registered user\nThen an error is shown when the password is wrong",
+			expected: []GherkinStep{
+				{Keyword: "Given", Text: "a registered user"},
+				{Keyword: "Then", Text: "an error is shown when the password is wrong"},
+			},
+		},
+		{
+			name:      "and then inside a step",
+			criterion: "Given a new user, when they log in, then they see the dashboard and then the tour starts",
+			expected: []GherkinStep{
+				{Keyword: "Given", Text: "a new user"},
+				{Keyword: "When", Text: "they log in"},
+				{Keyword: "Then", Text: "they see the dashboard and then the tour starts"},
+			},
+		},
+		{
+			name:      "and then after punctuation",
+			criterion: "When they log in, then they see the dashboard, and then the tour starts",
+			expected: []GherkinStep{
+				{Keyword: "When", Text: "they log in"},
+				{Keyword: "Then", Text: "they see the dashboard"},
+				{Keyword: "And", Text: "the tour starts"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseGherkinSteps(tt.criterion)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseGherkinSteps() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToGherkin(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	p.UserStories[0].AcceptanceCriteria = append(p.UserStories[0].AcceptanceCriteria,
+		"Given biometrics are enabled, when the sensor fails 3 times, then the password prompt is shown")
+
+	features := p.ToGherkin()
+	if len(features) != len(p.UserStories) {
+		t.Fatalf("Expected %d features, got %d", len(p.UserStories), len(features))
+	}
+
+	f := features[0]
+	if f.Filename != "US-001.feature" {
+		t.Errorf("Expected filename US-001.feature, got %s", f.Filename)
+	}
+
+	for _, s := range []string{
+		"@" + p.ID + "\nFeature: US-001 ",
+		"  @US-001 @priority-must_have\n  Scenario: User can enable biometric authentication in settings\n    Then User can enable biometric authentication in settings\n",
+		"  Scenario: the password prompt is shown\n    Given biometrics are enabled\n    When the sensor fails 3 times\n    Then the password prompt is shown\n",
+	} {
+		if !strings.Contains(f.Content, s) {
+			t.Errorf("Expected feature to contain %q, got:\n%s", s, f.Content)
+		}
+	}
+}
+
+func TestGherkinFeatureName(t *testing.T) {
+	got := gherkinFeatureName("As a busy user, I want to log in with my fingerprint so that I save time")
+	if got != "log in with my fingerprint" {
+		t.Errorf("gherkinFeatureName() = %q", got)
+	}
+}
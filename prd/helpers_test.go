@@ -0,0 +1,24 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixture writes files, keyed by slash-separated path, to a new
+// temporary directory and returns the directory
+func writeFixture(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
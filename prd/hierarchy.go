@@ -0,0 +1,222 @@
+package prd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HierarchyNode is a PRD in a workspace hierarchy, with its children
+type HierarchyNode struct {
+	PortfolioItem
+	Children []*HierarchyNode `json:"children,omitempty"`
+	Rollup   HierarchyRollup  `json:"rollup"`
+}
+
+// HierarchyRollup totals a PRD and its descendants
+type HierarchyRollup struct {
+	PRDs         int `json:"prds"`
+	Requirements int `json:"requirements"`
+	// Measured counts the PRDs whose delivery progress is known: completed
+	// PRDs, and those with milestones or tracked requirements
+	Measured int `json:"measured"`
+	// Progress is the mean delivery progress of the measured PRDs
+	Progress float64 `json:"progress"`
+}
+
+// HierarchyProblem is an inconsistency in a hierarchy, attributed to the PRD
+// that has to change to fix it
+type HierarchyProblem struct {
+	PRD     string `json:"prd"`
+	Message string `json:"message"`
+}
+
+// Hierarchy is the parent/child structure of the PRDs in a workspace. A
+// PRD's parent is the one it names in its parent field, or else the one that
+// lists it in its children.
+type Hierarchy struct {
+	Roots    []*HierarchyNode   `json:"roots"`
+	Problems []HierarchyProblem `json:"problems,omitempty"`
+
+	nodes   map[string]*HierarchyNode
+	parents map[string]string
+}
+
+// Hierarchy builds the parent/child structure of the workspace, with
+// rollups computed as of now
+func (w *Workspace) Hierarchy(now time.Time) *Hierarchy {
+	return buildHierarchy(w.Portfolio.Items, now)
+}
+
+// CheckHierarchy returns the hierarchy problems that p has to fix, such as
+// being approved before its parent. p replaces the workspace PRD at path.
+func (w *Workspace) CheckHierarchy(p *PRD, path string, now time.Time) []string {
+	items := slices.Clone(w.Portfolio.Items)
+	if i := slices.IndexFunc(items, func(item PortfolioItem) bool { return item.Path == path }); i >= 0 {
+		items[i] = newPortfolioItem(path, p)
+	} else {
+		items = append(items, newPortfolioItem(path, p))
+	}
+	return buildHierarchy(items, now).ProblemsFor(p.ID)
+}
+
+// Node returns the node of the PRD with the given ID
+func (h *Hierarchy) Node(id string) *HierarchyNode {
+	return h.nodes[id]
+}
+
+// Parent returns the node of the parent of the PRD with the given ID
+func (h *Hierarchy) Parent(id string) *HierarchyNode {
+	return h.nodes[h.parents[id]]
+}
+
+// ProblemsFor returns the messages of the problems attributed to a PRD
+func (h *Hierarchy) ProblemsFor(id string) []string {
+	var messages []string
+	for _, problem := range h.Problems {
+		if problem.PRD == id {
+			messages = append(messages, problem.Message)
+		}
+	}
+	return messages
+}
+
+func buildHierarchy(items []PortfolioItem, now time.Time) *Hierarchy {
+	h := &Hierarchy{nodes: map[string]*HierarchyNode{}, parents: map[string]string{}}
+	problem := func(id, format string, args ...any) {
+		h.Problems = append(h.Problems, HierarchyProblem{PRD: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var ids []string
+	for _, item := range items {
+		// Duplicate IDs are reported by reference checks; the first is used
+		if item.ID != "" && h.nodes[item.ID] == nil {
+			h.nodes[item.ID] = &HierarchyNode{PortfolioItem: item}
+			ids = append(ids, item.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	// A parent named by the child takes precedence over children lists
+	for _, id := range ids {
+		parent := h.nodes[id].PRD.Parent
+		switch {
+		case parent == "" || parent == id:
+		case h.nodes[parent] == nil:
+			problem(id, "%s: parent %s is not in the workspace", id, parent)
+		default:
+			h.parents[id] = parent
+		}
+	}
+	for _, id := range ids {
+		for _, child := range h.nodes[id].PRD.Children {
+			parent, ok := h.parents[child]
+			switch {
+			case child == id:
+			case h.nodes[child] == nil:
+				problem(id, "%s: child %s is not in the workspace", id, child)
+			case !ok:
+				h.parents[child] = id
+			case parent != id:
+				problem(child, "%s has parent %s but is listed as a child of %s", child, parent, id)
+			}
+		}
+	}
+
+	// Break parent cycles at their first PRD by ID
+	for _, id := range ids {
+		chain := []string{id}
+		for cur := h.parents[id]; cur != ""; cur = h.parents[cur] {
+			if cur == id {
+				problem(id, "parent cycle: %s", strings.Join(append(chain, id), " → "))
+				delete(h.parents, id)
+				break
+			}
+			if slices.Contains(chain, cur) {
+				break
+			}
+			chain = append(chain, cur)
+		}
+	}
+
+	for _, id := range ids {
+		node := h.nodes[id]
+		parent, ok := h.parents[id]
+		if !ok {
+			h.Roots = append(h.Roots, node)
+			continue
+		}
+		h.nodes[parent].Children = append(h.nodes[parent].Children, node)
+		if approvedOrLater(node.Status) && !approvedOrLater(h.nodes[parent].Status) {
+			problem(id, "%s is %s but its parent %s is %s", id, node.Status, parent, h.nodes[parent].Status)
+		}
+	}
+
+	var rollup func(n *HierarchyNode) HierarchyRollup
+	rollup = func(n *HierarchyNode) HierarchyRollup {
+		r := HierarchyRollup{PRDs: 1, Requirements: len(n.PRD.Requirements.Functional) + len(n.PRD.Requirements.NonFunctional)}
+		var total float64
+		if progress, ok := prdProgress(n.PRD, now); ok {
+			r.Measured, total = 1, progress
+		}
+		for _, child := range n.Children {
+			c := rollup(child)
+			r.PRDs += c.PRDs
+			r.Requirements += c.Requirements
+			r.Measured += c.Measured
+			total += c.Progress * float64(c.Measured)
+		}
+		if r.Measured > 0 {
+			r.Progress = total / float64(r.Measured)
+		}
+		n.Rollup = r
+		return r
+	}
+	for _, root := range h.Roots {
+		rollup(root)
+	}
+
+	sort.SliceStable(h.Problems, func(i, j int) bool { return h.Problems[i].PRD < h.Problems[j].PRD })
+	return h
+}
+
+// approvedOrLater reports whether a status is past approval and not archived
+func approvedOrLater(s Status) bool {
+	return s == StatusApproved || s == StatusInDevelopment || s == StatusCompleted
+}
+
+// prdProgress returns the delivery progress of a PRD, if it is known
+func prdProgress(p *PRD, now time.Time) (float64, bool) {
+	if p.Status == StatusCompleted {
+		return 100, true
+	}
+	progress := p.DeliveryProgress(now)
+	if progress.Milestones == 0 && progress.RequirementsTracked == 0 {
+		return 0, false
+	}
+	return progress.Overall, true
+}
+
+// validateHierarchy checks a PRD's own parent and children fields
+func (p *PRD) validateHierarchy() error {
+	if p.Parent != "" && p.Parent == p.ID {
+		return fmt.Errorf("PRD %s cannot be its own parent", p.ID)
+	}
+	seen := map[string]bool{}
+	for _, child := range p.Children {
+		switch {
+		case child == "":
+			return fmt.Errorf("child PRD IDs cannot be empty")
+		case child == p.ID:
+			return fmt.Errorf("PRD %s cannot be its own child", p.ID)
+		case child == p.Parent:
+			return fmt.Errorf("PRD %s cannot be both the parent and a child of %s", child, p.ID)
+		case seen[child]:
+			return fmt.Errorf("duplicate child PRD: %s", child)
+		}
+		seen[child] = true
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func hierarchyShape(nodes []*HierarchyNode) string {
+	var parts []string
+	for _, n := range nodes {
+		part := n.ID
+		if len(n.Children) > 0 {
+			part += "(" + hierarchyShape(n.Children) + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestHierarchy(t *testing.T) {
+	root := writeFixture(t, map[string]string{
+		"epic.json": `{"id":"EPIC-1","title":"Checkout","status":"review","children":["FEAT-1","FEAT-2","FEAT-9"],
+			"requirements":{"functional":[{"id":"FR-001","description":"Pay"}]}}`,
+		// Names its parent rather than being listed
+		"feat1.json": `{"id":"FEAT-1","title":"Wallet","status":"approved","parent":"EPIC-1",
+			"requirements":{"functional":[{"id":"FR-001","description":"Cards"},{"id":"FR-002","description":"Wallets"}]},
+			"timeline":{"milestones":[{"name":"Beta","target_date":"2025-01","state":"done"},{"name":"GA","target_date":"2025-06"}]}}`,
+		"feat2.json": `{"id":"FEAT-2","title":"Fraud","status":"completed",
+			"requirements":{"functional":[{"id":"FR-001","description":"Score"}],"non_functional":[{"id":"NFR-001","category":"performance","description":"Fast"}]}}`,
+		"story.json":  `{"id":"STORY-1","title":"Card form","status":"draft","parent":"FEAT-1"}`,
+		"other.json":  `{"id":"EPIC-2","title":"Claims FEAT-2","status":"draft","children":["FEAT-2"]}`,
+		"loose.json":  `{"id":"LOOSE","title":"Standalone","status":"draft","parent":"EPIC-404"}`,
+		"cycleA.json": `{"id":"CYC-A","title":"A","status":"draft","parent":"CYC-B"}`,
+		"cycleB.json": `{"id":"CYC-B","title":"B","status":"draft","parent":"CYC-A"}`,
+	})
+	w, err := LoadWorkspace(root)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	h := w.Hierarchy(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	if got := hierarchyShape(h.Roots); got != "CYC-A(CYC-B) EPIC-1(FEAT-1(STORY-1) FEAT-2) EPIC-2 LOOSE" {
+		t.Errorf("Unexpected hierarchy: %s", got)
+	}
+
+	epic := h.Node("EPIC-1")
+	// Feature 1 is 50% through its milestones and feature 2 is completed
+	if epic.Rollup != (HierarchyRollup{PRDs: 4, Requirements: 5, Measured: 2, Progress: 75}) {
+		t.Errorf("Unexpected rollup: %+v", epic.Rollup)
+	}
+	if parent := h.Parent("STORY-1"); parent == nil || parent.ID != "FEAT-1" {
+		t.Errorf("Unexpected parent: %+v", parent)
+	}
+
+	var problems []string
+	for _, p := range h.Problems {
+		problems = append(problems, p.PRD+": "+p.Message)
+	}
+	expected := []string{
+		"CYC-A: parent cycle: CYC-A → CYC-B → CYC-A",
+		"EPIC-1: EPIC-1: child FEAT-9 is not in the workspace",
+		"FEAT-1: FEAT-1 is approved but its parent EPIC-1 is review",
+		"FEAT-2: FEAT-2 has parent EPIC-1 but is listed as a child of EPIC-2",
+		"FEAT-2: FEAT-2 is completed but its parent EPIC-1 is review",
+		"LOOSE: LOOSE: parent EPIC-404 is not in the workspace",
+	}
+	if strings.Join(problems, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected problems:\n%s", strings.Join(problems, "\n"))
+	}
+
+	// Approving the epic resolves its children's problems
+	approved := *epic.PRD
+	approved.Status = StatusApproved
+	if got := w.CheckHierarchy(&approved, epic.Path, time.Now()); len(got) != 1 || !strings.Contains(got[0], "FEAT-9") {
+		t.Errorf("Unexpected epic problems: %v", got)
+	}
+	feature := h.Node("FEAT-1")
+	if got := w.CheckHierarchy(feature.PRD, feature.Path, time.Now()); len(got) != 1 || !strings.Contains(got[0], "approved but its parent") {
+		t.Errorf("Unexpected feature problems: %v", got)
+	}
+}
+
+func TestValidateHierarchy(t *testing.T) {
+	tests := []struct {
+		parent   string
+		children []string
+		want     string
+	}{
+		{"PRD-000", []string{"PRD-002", "PRD-003"}, ""},
+		{"PRD-001", nil, "cannot be its own parent"},
+		{"", []string{"PRD-001"}, "cannot be its own child"},
+		{"PRD-000", []string{"PRD-000"}, "cannot be both the parent and a child"},
+		{"", []string{"PRD-002", "PRD-002"}, "duplicate child PRD: PRD-002"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			p, err := LoadFromFile("example.json")
+			if err != nil {
+				t.Fatalf("Failed to load example PRD: %v", err)
+			}
+			p.Parent, p.Children = tt.parent, tt.children
+			err = p.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
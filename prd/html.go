@@ -0,0 +1,670 @@
+package prd
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// HTMLOptions configures HTML rendering
+type HTMLOptions struct {
+	// GeneratedAt, when set, adds a footer with the generation time
+	GeneratedAt *time.Time
+}
+
+// HTMLLink is a hyperlink to another page in a generated site
+type HTMLLink struct {
+	Title string
+	URL   string
+}
+
+// htmlPage is the data passed to the page template
+type htmlPage struct {
+	PRD         *PRD
+	CSS         template.CSS
+	GeneratedAt string
+	// Gantt is an inline SVG chart of the milestones
+	Gantt template.HTML
+	// Metrics is set when at least one success metric has a goal or
+	// measurements
+	Metrics []MetricReport
+	// RiskHeatMap is set when at least one risk is rated
+	RiskHeatMap []RiskHeatMapRow
+	// Site navigation, only set when rendering as part of a static site
+	InSite    bool
+	IndexURL  string
+	DependsOn []HTMLLink
+	UsedBy    []HTMLLink
+}
+
+var htmlTemplates = template.Must(template.New("prd").Funcs(template.FuncMap{
+	"join":           strings.Join,
+	"keyResultCells": keyResultCells,
+	"sparklineSVG": func(m SuccessMetric) template.HTML {
+		return template.HTML(m.SparklineSVG(sparklineLength))
+	},
+	"lower": func(v any) string {
+		return strings.ToLower(fmt.Sprint(v))
+	},
+	"formatTime": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02 15:04")
+	},
+}).Parse(htmlPageTemplate))
+
+// ToHTML converts the PRD into a standalone HTML document
+func (p *PRD) ToHTML(opts HTMLOptions) (string, error) {
+	var buf bytes.Buffer
+	if err := p.WriteHTML(&buf, opts); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// WriteHTML writes the PRD as a standalone HTML document
+func (p *PRD) WriteHTML(w io.Writer, opts HTMLOptions) error {
+	return writeHTMLPage(w, newHTMLPage(p, opts))
+}
+
+func newHTMLPage(p *PRD, opts HTMLOptions) htmlPage {
+	page := htmlPage{
+		PRD: p,
+		CSS: template.CSS(htmlCSS),
+	}
+	if opts.GeneratedAt != nil {
+		page.GeneratedAt = opts.GeneratedAt.Format("January 2, 2006 at 3:04 PM")
+	}
+	if p.Timeline.hasDatedMilestones() {
+		// A timeline that cannot be scheduled still renders as a table
+		if svg, err := p.ToGanttSVG(GanttOptions{Today: opts.GeneratedAt}); err == nil {
+			page.Gantt = template.HTML(svg)
+		}
+	}
+	for _, m := range p.Objectives.SuccessMetrics {
+		if m.Goal != nil || len(m.Measurements) > 0 {
+			now := time.Now()
+			if opts.GeneratedAt != nil {
+				now = *opts.GeneratedAt
+			}
+			page.Metrics = p.MetricReports(now)
+			break
+		}
+	}
+	if ra := p.RisksAndAssumptions; ra != nil {
+		for _, risk := range ra.Risks {
+			if risk.Score() > 0 {
+				page.RiskHeatMap = ra.HeatMap()
+				break
+			}
+		}
+	}
+	return page
+}
+
+func writeHTMLPage(w io.Writer, page htmlPage) error {
+	if err := htmlTemplates.ExecuteTemplate(w, "page", page); err != nil {
+		return fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return nil
+}
+
+const htmlPageTemplate = `
+{{- define "page" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.PRD.Title}} - PRD</title>
+    <style>{{.CSS}}</style>
+</head>
+<body>
+{{- if .InSite}}
+    <nav class="site-nav"><a href="{{.IndexURL}}">&larr; All PRDs</a></nav>
+{{- end}}
+{{- with .PRD}}
+    <div class="header">
+        <h1>{{.Title}}</h1>
+        <div class="metadata">
+            <span class="badge">{{.Status}}</span>
+            {{- if .Priority}}
+            <span class="badge priority-{{lower .Priority}}">{{.Priority}}</span>
+            {{- end}}
+        </div>
+    </div>
+
+    <div class="doc-info">
+        <div class="info-grid">
+            <div><strong>ID:</strong> {{.ID}}</div>
+            <div><strong>Version:</strong> {{.Version}}</div>
+            <div><strong>Owner:</strong> {{.Owner.Name}}{{if .Owner.Email}} (<a href="mailto:{{.Owner.Email}}">{{.Owner.Email}}</a>){{end}}</div>
+            {{- if .Owner.Team}}
+            <div><strong>Team:</strong> {{.Owner.Team}}</div>
+            {{- end}}
+            <div><strong>Created:</strong> {{.CreatedDate}}</div>
+            {{- if .LastUpdated}}
+            <div><strong>Last Updated:</strong> {{formatTime .LastUpdated}}</div>
+            {{- end}}
+        </div>
+    </div>
+{{- end}}
+{{- if or .DependsOn .UsedBy}}
+
+    <section class="section">
+        <h2>Related PRDs</h2>
+        {{- if .DependsOn}}
+        <div class="subsection">
+            <h3>Depends On</h3>
+            <ul>
+                {{- range .DependsOn}}
+                <li><a href="{{.URL}}">{{.Title}}</a></li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+        {{- if .UsedBy}}
+        <div class="subsection">
+            <h3>Used By</h3>
+            <ul>
+                {{- range .UsedBy}}
+                <li><a href="{{.URL}}">{{.Title}}</a></li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+    </section>
+{{- end}}
+{{- with .PRD}}
+{{- if .Stakeholders}}
+
+    <section class="section">
+        <h2>Stakeholders</h2>
+        <ul>
+            {{- range .Stakeholders}}
+            <li><strong>{{.Name}}</strong> ({{.Role}}){{if .Team}} - {{.Team}}{{end}}{{if .Email}} - {{.Email}}{{end}}</li>
+            {{- end}}
+        </ul>
+    </section>
+{{- end}}
+
+    <section class="section">
+        <h2>Overview</h2>
+        <div class="subsection">
+            <h3>Problem Statement</h3>
+            <p>{{.Overview.ProblemStatement}}</p>
+        </div>
+        <div class="subsection">
+            <h3>Solution Summary</h3>
+            <p>{{.Overview.SolutionSummary}}</p>
+        </div>
+        {{- if .Overview.TargetAudience}}
+        <div class="subsection">
+            <h3>Target Audience</h3>
+            <p>{{.Overview.TargetAudience}}</p>
+        </div>
+        {{- end}}
+        {{- if .Overview.MarketContext}}
+        <div class="subsection">
+            <h3>Market Context</h3>
+            <p>{{.Overview.MarketContext}}</p>
+        </div>
+        {{- end}}
+    </section>
+
+    <section class="section">
+        <h2>Objectives</h2>
+        {{- if .Objectives.BusinessGoals}}
+        <div class="subsection">
+            <h3>Business Goals</h3>
+            <ul>
+                {{- range .Objectives.BusinessGoals}}
+                <li>{{.}}</li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+        {{- if .Objectives.SuccessMetrics}}
+        <div class="subsection">
+            <h3>Success Metrics</h3>
+            <table>
+                <thead>
+                    <tr><th>Metric</th><th>Target</th><th>Measurement Method</th>{{if $.Metrics}}<th>Latest</th><th>Trend</th><th>Status</th>{{end}}</tr>
+                </thead>
+                <tbody>
+                    {{- if $.Metrics}}
+                    {{- range $.Metrics}}
+                    <tr><td>{{.Metric.Metric}}</td><td>{{.Metric.Target}}</td><td>{{.Metric.MeasurementMethod}}</td><td>{{.LatestLabel}}</td><td>{{sparklineSVG .Metric}}</td><td>{{with .Status}}<span class="metric-status metric-{{.}}">{{.}}</span>{{end}}</td></tr>
+                    {{- end}}
+                    {{- else}}
+                    {{- range .Objectives.SuccessMetrics}}
+                    <tr><td>{{.Metric}}</td><td>{{.Target}}</td><td>{{.MeasurementMethod}}</td></tr>
+                    {{- end}}
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+        {{- if .Objectives.OKRs}}
+        <div class="subsection">
+            <h3>OKRs</h3>
+            {{- range $i, $okr := .Objectives.OKRs}}
+            <p><strong>Objective:</strong> {{$okr.Objective}}</p>
+            {{- if $okr.Measurable}}
+            <p><strong>Progress:</strong> {{printf "%.0f" $okr.Progress}}%</p>
+            {{- end}}
+            <table>
+                <thead>
+                    <tr><th>ID</th><th>Key Result</th><th>Baseline</th><th>Target</th><th>Current</th><th>Progress</th><th>Confidence</th></tr>
+                </thead>
+                <tbody>
+                    {{- range $j, $kr := $okr.KeyResults}}
+                    <tr><td>{{$.PRD.Objectives.KeyResultID $i $j}}</td><td>{{$kr.Description}}</td>{{range keyResultCells $kr}}<td>{{.}}</td>{{end}}</tr>
+                    {{- end}}
+                </tbody>
+            </table>
+            {{- end}}
+        </div>
+        {{- end}}
+    </section>
+{{- if .UserPersonas}}
+
+    <section class="section">
+        <h2>User Personas</h2>
+        {{- range .UserPersonas}}
+        <div class="subsection">
+            <h3>{{.Name}}</h3>
+            <p>{{.Description}}</p>
+            {{- if .Goals}}
+            <h4>Goals</h4>
+            <ul>
+                {{- range .Goals}}
+                <li>{{.}}</li>
+                {{- end}}
+            </ul>
+            {{- end}}
+            {{- if .PainPoints}}
+            <h4>Pain Points</h4>
+            <ul>
+                {{- range .PainPoints}}
+                <li>{{.}}</li>
+                {{- end}}
+            </ul>
+            {{- end}}
+        </div>
+        {{- end}}
+    </section>
+{{- end}}
+{{- if .UserStories}}
+
+    <section class="section">
+        <h2>User Stories</h2>
+        {{- range .UserStories}}
+        <div class="subsection">
+            <h3>{{.ID}}{{if .Priority}} <span class="tag">{{.Priority}}</span>{{end}}{{if .EffortEstimate}} <span class="tag">{{.EffortEstimate}}</span>{{end}}</h3>
+            <p>{{.Story}}</p>
+            {{- if .AcceptanceCriteria}}
+            <h4>Acceptance Criteria</h4>
+            <ul>
+                {{- range .AcceptanceCriteria}}
+                <li>{{.}}</li>
+                {{- end}}
+            </ul>
+            {{- end}}
+        </div>
+        {{- end}}
+    </section>
+{{- end}}
+
+    <section class="section">
+        <h2>Requirements</h2>
+        {{- if .Requirements.Functional}}
+        <div class="subsection">
+            <h3>Functional Requirements</h3>
+            <table>
+                <thead>
+                    <tr><th>ID</th><th>Description</th><th>Priority</th><th>Dependencies</th></tr>
+                </thead>
+                <tbody>
+                    {{- range .Requirements.Functional}}
+                    <tr><td>{{.ID}}</td><td>{{.Description}}</td><td>{{.Priority}}</td><td>{{join .Dependencies ", "}}</td></tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+        {{- if .Requirements.NonFunctional}}
+        <div class="subsection">
+            <h3>Non-Functional Requirements</h3>
+            <table>
+                <thead>
+                    <tr><th>ID</th><th>Category</th><th>Description</th><th>Acceptance Criteria</th></tr>
+                </thead>
+                <tbody>
+                    {{- range .Requirements.NonFunctional}}
+                    <tr><td>{{.ID}}</td><td>{{.Category}}</td><td>{{.Description}}</td><td>{{.AcceptanceCriteria}}</td></tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+    </section>
+{{- with .TechnicalSpecifications}}
+
+    <section class="section">
+        <h2>Technical Specifications</h2>
+        {{- if .ArchitectureOverview}}
+        <div class="subsection">
+            <h3>Architecture Overview</h3>
+            <p>{{.ArchitectureOverview}}</p>
+        </div>
+        {{- end}}
+        {{- with .TechnologyStack}}
+        <div class="subsection">
+            <h3>Technology Stack</h3>
+            <ul>
+                {{- if .Frontend}}
+                <li><strong>Frontend:</strong> {{join .Frontend ", "}}</li>
+                {{- end}}
+                {{- if .Backend}}
+                <li><strong>Backend:</strong> {{join .Backend ", "}}</li>
+                {{- end}}
+                {{- if .Database}}
+                <li><strong>Database:</strong> {{join .Database ", "}}</li>
+                {{- end}}
+                {{- if .Infrastructure}}
+                <li><strong>Infrastructure:</strong> {{join .Infrastructure ", "}}</li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+        {{- if .APISpecifications}}
+        <div class="subsection">
+            <h3>API Specifications</h3>
+            <table>
+                <thead>
+                    <tr><th>Method</th><th>Endpoint</th><th>Description</th></tr>
+                </thead>
+                <tbody>
+                    {{- range .APISpecifications}}
+                    <tr><td>{{.Method}}</td><td><code>{{.Endpoint}}</code></td><td>{{.Description}}</td></tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+        {{- if .SecurityConsiderations}}
+        <div class="subsection">
+            <h3>Security Considerations</h3>
+            <ul>
+                {{- range .SecurityConsiderations}}
+                <li>{{.}}</li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+    </section>
+{{- end}}
+{{- with .Timeline}}
+
+    <section class="section">
+        <h2>Timeline</h2>
+        {{- if .LaunchDate}}
+        <p><strong>Target Launch Date:</strong> {{.LaunchDate}}</p>
+        {{- end}}
+        {{- if $.Gantt}}
+        <div class="gantt-chart">
+{{$.Gantt}}        </div>
+        {{- end}}
+        {{- if .Milestones}}
+        <div class="subsection">
+            <h3>Milestones</h3>
+            <table>
+                <thead>
+                    <tr><th>Milestone</th><th>Target Date</th><th>Description</th><th>Dependencies</th></tr>
+                </thead>
+                <tbody>
+                    {{- range .Milestones}}
+                    <tr><td>{{.Name}}</td><td>{{.TargetDate}}</td><td>{{.Description}}</td><td>{{join .Dependencies ", "}}</td></tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+    </section>
+{{- end}}
+{{- with .RisksAndAssumptions}}
+
+    <section class="section">
+        <h2>Risks and Assumptions</h2>
+        {{- if $.RiskHeatMap}}
+        <div class="subsection">
+            <h3>Risk Heat Map</h3>
+            <table class="heatmap">
+                <thead>
+                    <tr><th>Probability \ Impact</th>{{range (index $.RiskHeatMap 0).Cells}}<th>{{.Impact}}</th>{{end}}</tr>
+                </thead>
+                <tbody>
+                    {{- range $.RiskHeatMap}}
+                    <tr><th>{{.Probability}}</th>{{range .Cells}}<td class="risk-{{.Rating}}">{{join .Risks ", "}}</td>{{end}}</tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+        {{- if .Risks}}
+        <div class="subsection">
+            <h3>Risks</h3>
+            <table>
+                <thead>
+                    <tr><th>ID</th><th>Risk</th><th>Impact</th><th>Probability</th><th>Score</th><th>Owner</th><th>Status</th><th>Mitigation Strategy</th></tr>
+                </thead>
+                <tbody>
+                    {{- range $i, $risk := .Risks}}
+                    <tr><td>{{$.PRD.RisksAndAssumptions.RiskID $i}}</td><td>{{.Description}}</td><td>{{.Impact}}</td><td>{{.Probability}}</td><td{{with .Rating}} class="risk-{{.}}"{{end}}>{{with .Score}}{{.}}{{end}}</td><td>{{.Owner}}</td><td>{{.Status}}</td><td>{{.MitigationStrategy}}</td></tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+        {{- if .Assumptions}}
+        <div class="subsection">
+            <h3>Assumptions</h3>
+            <table>
+                <thead>
+                    <tr><th>ID</th><th>Assumption</th><th>Confidence</th><th>Validation Method</th><th>Result</th></tr>
+                </thead>
+                <tbody>
+                    {{- range $i, $a := .Assumptions}}
+                    <tr><td>{{$.PRD.RisksAndAssumptions.AssumptionID $i}}</td><td>{{.Statement}}</td><td>{{.Confidence}}</td><td>{{.ValidationMethod}}</td><td>{{.ResultLabel}}</td></tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+        {{- end}}
+    </section>
+{{- end}}
+{{- if .OutOfScope}}
+
+    <section class="section">
+        <h2>Out of Scope</h2>
+        <ul>
+            {{- range .OutOfScope}}
+            <li>{{.}}</li>
+            {{- end}}
+        </ul>
+    </section>
+{{- end}}
+{{- with .Appendices}}
+
+    <section class="section">
+        <h2>Appendices</h2>
+        {{- if .ResearchData}}
+        <div class="subsection">
+            <h3>Research Data</h3>
+            <p>{{.ResearchData}}</p>
+        </div>
+        {{- end}}
+        {{- if .MockupsWireframes}}
+        <div class="subsection">
+            <h3>Mockups and Wireframes</h3>
+            <ul>
+                {{- range .MockupsWireframes}}
+                <li>{{if .URL}}<a href="{{.URL}}">{{or .Name .URL}}</a>{{else}}{{.Name}}{{end}}{{if .Description}} - {{.Description}}{{end}}</li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+        {{- if .RelatedDocuments}}
+        <div class="subsection">
+            <h3>Related Documents</h3>
+            <ul>
+                {{- range .RelatedDocuments}}
+                <li>{{if .URL}}<a href="{{.URL}}">{{or .Title .URL}}</a>{{else}}{{.Title}}{{end}}{{if .Type}} ({{.Type}}){{end}}</li>
+                {{- end}}
+            </ul>
+        </div>
+        {{- end}}
+    </section>
+{{- end}}
+{{- end}}
+{{- if .GeneratedAt}}
+
+    <footer>
+        <p>Generated on {{.GeneratedAt}}</p>
+    </footer>
+{{- end}}
+</body>
+</html>
+{{end}}`
+
+const htmlCSS = `
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            line-height: 1.6;
+            max-width: 1200px;
+            margin: 0 auto;
+            padding: 20px;
+            color: #333;
+        }
+        a { color: #2c6fbb; }
+        .site-nav { margin-bottom: 1rem; }
+        .gantt-chart { overflow-x: auto; margin: 1rem 0; }
+        .gantt-chart svg { max-width: 100%; height: auto; }
+        .heatmap td { text-align: center; width: 20%; }
+        .risk-low { background: #d5f5e3; }
+        .risk-medium { background: #fcf3cf; }
+        .risk-high { background: #fad7a0; }
+        .risk-critical { background: #f5b7b1; }
+        .sparkline { vertical-align: middle; }
+        .metric-status { padding: 2px 8px; border-radius: 10px; font-size: 0.85em; white-space: nowrap; }
+        .metric-met, .metric-on_track { background: #d5f5e3; }
+        .metric-off_track { background: #fcf3cf; }
+        .metric-missed { background: #f5b7b1; }
+        .metric-no_data { background: #eef1f5; }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 2rem;
+            border-radius: 10px;
+            margin-bottom: 2rem;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 2.5rem;
+        }
+        .metadata {
+            margin-top: 1rem;
+        }
+        .badge {
+            display: inline-block;
+            padding: 0.25rem 0.75rem;
+            background: rgba(255,255,255,0.2);
+            border-radius: 20px;
+            font-size: 0.875rem;
+            margin: 0 0.5rem;
+            text-transform: uppercase;
+        }
+        .tag {
+            display: inline-block;
+            padding: 0 0.5rem;
+            background: #eef1f5;
+            border-radius: 4px;
+            font-size: 0.8rem;
+            font-weight: normal;
+        }
+        .priority-critical { background: #e74c3c; }
+        .priority-high { background: #e67e22; }
+        .priority-medium { background: #f39c12; }
+        .priority-low { background: #27ae60; }
+        .doc-info {
+            background: #f8f9fa;
+            padding: 1.5rem;
+            border-radius: 8px;
+            margin-bottom: 2rem;
+        }
+        .info-grid {
+            display: grid;
+            grid-template-columns: repeat(auto-fit, minmax(200px, 1fr));
+            gap: 1rem;
+        }
+        .section {
+            margin-bottom: 3rem;
+        }
+        .section h2 {
+            color: #2c3e50;
+            border-bottom: 3px solid #3498db;
+            padding-bottom: 0.5rem;
+        }
+        .subsection {
+            margin: 2rem 0;
+        }
+        .subsection h3 {
+            color: #34495e;
+        }
+        table {
+            width: 100%;
+            border-collapse: collapse;
+            margin: 1rem 0;
+        }
+        th, td {
+            text-align: left;
+            padding: 0.75rem;
+            border-bottom: 1px solid #ddd;
+            vertical-align: top;
+        }
+        th {
+            background: #f4f4f4;
+            font-weight: 600;
+        }
+        tr:hover {
+            background: #f9f9f9;
+        }
+        ul, ol {
+            padding-left: 2rem;
+        }
+        li {
+            margin: 0.5rem 0;
+        }
+        input[type=search] {
+            width: 100%;
+            padding: 0.75rem;
+            font-size: 1rem;
+            border: 1px solid #ccc;
+            border-radius: 6px;
+            box-sizing: border-box;
+        }
+        footer {
+            margin-top: 3rem;
+            padding-top: 2rem;
+            border-top: 1px solid #eee;
+            text-align: center;
+            color: #666;
+            font-size: 0.9rem;
+        }
+`
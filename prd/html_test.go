@@ -0,0 +1,35 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTMLEscapesUserText(t *testing.T) {
+	p := &PRD{
+		ID:    "PRD-HTML-001",
+		Title: "Compare a < b & <script>alert(1)</script>",
+		Overview: Overview{
+			ProblemStatement: "Users type <b>markup</b>",
+		},
+		Appendices: &Appendices{
+			RelatedDocuments: []RelatedDocument{
+				{Title: "Bad link", URL: "javascript:alert(1)"},
+			},
+		},
+	}
+
+	html, err := p.ToHTML(HTMLOptions{})
+	if err != nil {
+		t.Fatalf("Failed to render HTML: %v", err)
+	}
+
+	for _, s := range []string{"<script>alert(1)</script>", "<b>markup</b>", `href="javascript:`} {
+		if strings.Contains(html, s) {
+			t.Errorf("Expected HTML not to contain unescaped %q", s)
+		}
+	}
+	if !strings.Contains(html, "Compare a &lt; b &amp; &lt;script&gt;") {
+		t.Error("Expected escaped title in HTML")
+	}
+}
@@ -0,0 +1,278 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Jira wiki colours for status and priority values
+var jiraColours = map[string]string{
+	string(StatusDraft):         "gray",
+	string(StatusReview):        "orange",
+	string(StatusApproved):      "green",
+	string(StatusInDevelopment): "blue",
+	string(StatusCompleted):     "green",
+	string(StatusArchived):      "gray",
+	string(PriorityCritical):    "red",
+	string(PriorityHigh):        "red",
+	string(PriorityMedium):      "orange",
+	string(PriorityLow):         "green",
+	string(MustHave):            "red",
+	string(ShouldHave):          "orange",
+	string(CouldHave):           "blue",
+	string(WontHave):            "gray",
+}
+
+var jiraEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"{", `\{`,
+	"}", `\}`,
+	"[", `\[`,
+	"]", `\]`,
+	"|", `\|`,
+	"*", `\*`,
+	"_", `\_`,
+	"+", `\+`,
+	"^", `\^`,
+	"~", `\~`,
+)
+
+// ToJiraWiki converts the PRD into Jira wiki markup
+func (p *PRD) ToJiraWiki() string {
+	j := &jiraWriter{}
+
+	j.linef("h1. %s", jiraEscape(p.Title))
+	j.line("")
+	j.linef("||ID|%s|", jiraCell(p.ID))
+	j.linef("||Version|%s|", jiraCell(p.Version))
+	j.linef("||Status|%s|", jiraColoured(p.Status))
+	if p.Priority != "" {
+		j.linef("||Priority|%s|", jiraColoured(p.Priority))
+	}
+	j.linef("||Owner|%s|", jiraCell(fmt.Sprintf("%s (%s)", p.Owner.Name, p.Owner.Email)))
+	if p.Owner.Team != "" {
+		j.linef("||Team|%s|", jiraCell(p.Owner.Team))
+	}
+	j.linef("||Created|%s|", jiraCell(p.CreatedDate.String()))
+	j.line("")
+
+	j.line("h2. Overview")
+	j.line("h3. Problem Statement")
+	j.paragraph(p.Overview.ProblemStatement)
+	j.line("h3. Solution Summary")
+	j.paragraph(p.Overview.SolutionSummary)
+	if p.Overview.TargetAudience != "" {
+		j.line("h3. Target Audience")
+		j.paragraph(p.Overview.TargetAudience)
+	}
+	if p.Overview.MarketContext != "" {
+		j.line("h3. Market Context")
+		j.paragraph(p.Overview.MarketContext)
+	}
+
+	j.line("h2. Objectives")
+	if len(p.Objectives.BusinessGoals) > 0 {
+		j.line("h3. Business Goals")
+		j.numbered(p.Objectives.BusinessGoals)
+	}
+	if len(p.Objectives.SuccessMetrics) > 0 {
+		j.line("h3. Success Metrics")
+		var rows [][]string
+		for _, m := range p.Objectives.SuccessMetrics {
+			rows = append(rows, []string{jiraCell(m.Metric), jiraCell(m.Target), jiraCell(m.MeasurementMethod)})
+		}
+		j.table([]string{"Metric", "Target", "Measurement Method"}, rows)
+	}
+	if len(p.Objectives.OKRs) > 0 {
+		j.line("h3. OKRs")
+		for _, okr := range p.Objectives.OKRs {
+			j.linef("*Objective:* %s", jiraEscape(okr.Objective))
+			j.bullets(keyResultBullets(okr.KeyResults))
+		}
+	}
+
+	if len(p.UserStories) > 0 {
+		j.line("h2. User Stories")
+		for _, story := range p.UserStories {
+			heading := jiraEscape(story.ID)
+			if story.Priority != "" {
+				heading += " " + jiraColoured(story.Priority)
+			}
+			j.linef("h3. %s", heading)
+			j.paragraph(story.Story)
+			if story.EffortEstimate != "" {
+				j.linef("*Effort Estimate:* %s", jiraEscape(story.EffortEstimate))
+				j.line("")
+			}
+			if len(story.AcceptanceCriteria) > 0 {
+				j.line("*Acceptance Criteria:*")
+				j.bullets(story.AcceptanceCriteria)
+			}
+		}
+	}
+
+	j.line("h2. Requirements")
+	if len(p.Requirements.Functional) > 0 {
+		j.line("h3. Functional Requirements")
+		var rows [][]string
+		for _, req := range p.Requirements.Functional {
+			rows = append(rows, []string{
+				jiraCell(req.ID),
+				jiraCell(req.Description),
+				jiraColoured(req.Priority),
+				jiraCell(strings.Join(req.Dependencies, ", ")),
+			})
+		}
+		j.table([]string{"ID", "Description", "Priority", "Dependencies"}, rows)
+	}
+	if len(p.Requirements.NonFunctional) > 0 {
+		j.line("h3. Non-Functional Requirements")
+		var rows [][]string
+		for _, req := range p.Requirements.NonFunctional {
+			rows = append(rows, []string{jiraCell(req.ID), jiraCell(string(req.Category)), jiraCell(req.Description), jiraCell(req.AcceptanceCriteria)})
+		}
+		j.table([]string{"ID", "Category", "Description", "Acceptance Criteria"}, rows)
+	}
+
+	if specs := p.TechnicalSpecifications; specs != nil {
+		j.line("h2. Technical Specifications")
+		if specs.ArchitectureOverview != "" {
+			j.line("h3. Architecture Overview")
+			j.paragraph(specs.ArchitectureOverview)
+		}
+		if len(specs.APISpecifications) > 0 {
+			j.line("h3. API Specifications")
+			var rows [][]string
+			for _, api := range specs.APISpecifications {
+				rows = append(rows, []string{jiraCell(api.Method), "{{" + jiraCell(api.Endpoint) + "}}", jiraCell(api.Description)})
+			}
+			j.table([]string{"Method", "Endpoint", "Description"}, rows)
+		}
+		if len(specs.SecurityConsiderations) > 0 {
+			j.line("h3. Security Considerations")
+			j.bullets(specs.SecurityConsiderations)
+		}
+	}
+
+	if timeline := p.Timeline; timeline != nil {
+		j.line("h2. Timeline")
+		if !timeline.LaunchDate.IsZero() {
+			j.linef("*Target Launch Date:* %s", jiraEscape(timeline.LaunchDate.String()))
+			j.line("")
+		}
+		if len(timeline.Milestones) > 0 {
+			var rows [][]string
+			for _, m := range timeline.Milestones {
+				rows = append(rows, []string{jiraCell(m.Name), jiraCell(m.TargetDate.String()), jiraCell(m.Description), jiraCell(strings.Join(m.Dependencies, ", "))})
+			}
+			j.table([]string{"Milestone", "Target Date", "Description", "Dependencies"}, rows)
+		}
+	}
+
+	if ra := p.RisksAndAssumptions; ra != nil {
+		j.line("h2. Risks and Assumptions")
+		if len(ra.Risks) > 0 {
+			var rows [][]string
+			for _, risk := range ra.Risks {
+				rows = append(rows, []string{jiraCell(risk.Description), jiraColoured(risk.Impact), jiraColoured(risk.Probability), jiraCell(risk.MitigationStrategy)})
+			}
+			j.table([]string{"Risk", "Impact", "Probability", "Mitigation Strategy"}, rows)
+		}
+		if len(ra.Assumptions) > 0 {
+			j.line("h3. Assumptions")
+			j.bullets(assumptionBullets(ra.Assumptions))
+		}
+	}
+
+	if len(p.OutOfScope) > 0 {
+		j.line("h2. Out of Scope")
+		j.bullets(p.OutOfScope)
+	}
+
+	return j.b.String()
+}
+
+// ToJiraWiki converts a single user story into Jira wiki markup suitable
+// for pasting into an issue description
+func (s UserStory) ToJiraWiki() string {
+	j := &jiraWriter{}
+	j.paragraph(s.Story)
+	if len(s.AcceptanceCriteria) > 0 {
+		j.line("*Acceptance Criteria:*")
+		j.bullets(s.AcceptanceCriteria)
+	}
+	return j.b.String()
+}
+
+type jiraWriter struct {
+	b strings.Builder
+}
+
+func (j *jiraWriter) line(s string) {
+	j.b.WriteString(s)
+	j.b.WriteString("\n")
+}
+
+func (j *jiraWriter) linef(format string, args ...any) {
+	j.line(fmt.Sprintf(format, args...))
+}
+
+func (j *jiraWriter) paragraph(text string) {
+	j.line(jiraEscape(text))
+	j.line("")
+}
+
+func (j *jiraWriter) bullets(items []string) {
+	for _, item := range items {
+		j.linef("* %s", jiraEscape(item))
+	}
+	j.line("")
+}
+
+func (j *jiraWriter) numbered(items []string) {
+	for _, item := range items {
+		j.linef("# %s", jiraEscape(item))
+	}
+	j.line("")
+}
+
+// table writes a table whose cells are already escaped
+func (j *jiraWriter) table(headers []string, rows [][]string) {
+	j.line("||" + strings.Join(headers, "||") + "||")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			// Jira collapses empty cells, so keep them visible
+			if cell == "" {
+				cell = " "
+			}
+			cells[i] = cell
+		}
+		j.line("|" + strings.Join(cells, "|") + "|")
+	}
+	j.line("")
+}
+
+// jiraEscape escapes characters with special meaning in Jira wiki markup
+func jiraEscape(s string) string {
+	return jiraEscaper.Replace(s)
+}
+
+// jiraCell escapes text for a table cell, where newlines must become
+// explicit line breaks
+func jiraCell(s string) string {
+	s = strings.ReplaceAll(jiraEscape(s), "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", ` \\ `)
+}
+
+// jiraColoured renders a status or priority value as bold coloured text
+func jiraColoured[T ~string](value T) string {
+	if value == "" {
+		return ""
+	}
+	colour, ok := jiraColours[string(value)]
+	if !ok {
+		return "*" + jiraEscape(string(value)) + "*"
+	}
+	return fmt.Sprintf("{color:%s}*%s*{color}", colour, jiraEscape(string(value)))
+}
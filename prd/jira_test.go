@@ -0,0 +1,27 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToJiraWiki(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	p.Requirements.Functional[0].Description = "Support {SSO} | [SAML]\nand OIDC"
+
+	out := p.ToJiraWiki()
+
+	for _, s := range []string{
+		"h1. Mobile App User Authentication System",
+		"||ID||Description||Priority||Dependencies||",
+		`Support \{SSO\} \| \[SAML\] \\ and OIDC`,
+		"{color:red}*must\\_have*{color}",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected Jira wiki output to contain %q", s)
+		}
+	}
+}
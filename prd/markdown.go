@@ -3,358 +3,625 @@ package prd
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
+// Section identifies a top-level section of a rendered PRD
+type Section string
+
+const (
+	SectionOwner        Section = "owner"
+	SectionStakeholders Section = "stakeholders"
+	SectionOverview     Section = "overview"
+	SectionObjectives   Section = "objectives"
+	SectionPersonas     Section = "personas"
+	SectionStories      Section = "stories"
+	SectionRequirements Section = "requirements"
+	SectionTechnical    Section = "technical"
+	SectionTimeline     Section = "timeline"
+	SectionRisks        Section = "risks"
+	SectionOutOfScope   Section = "out_of_scope"
+	SectionAppendices   Section = "appendices"
+)
+
+// Sections returns all sections in document order
+func Sections() []Section {
+	return []Section{
+		SectionOwner,
+		SectionStakeholders,
+		SectionOverview,
+		SectionObjectives,
+		SectionPersonas,
+		SectionStories,
+		SectionRequirements,
+		SectionTechnical,
+		SectionTimeline,
+		SectionRisks,
+		SectionOutOfScope,
+		SectionAppendices,
+	}
+}
+
+// ParseSections parses a comma-separated list of section names
+func ParseSections(s string) ([]Section, error) {
+	var sections []Section
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, section := range Sections() {
+			if string(section) == name {
+				sections = append(sections, section)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown section: %s", name)
+		}
+	}
+	return sections, nil
+}
+
+// MarkdownStyle controls how tabular data is rendered
+type MarkdownStyle string
+
+const (
+	MarkdownStyleTable MarkdownStyle = "table"
+	MarkdownStyleList  MarkdownStyle = "list"
+)
+
+// MarkdownFlavor selects the Markdown dialect to target
+type MarkdownFlavor string
+
+const (
+	// MarkdownFlavorGFM targets GitHub Flavored Markdown, which supports
+	// tables and generates heading anchors used by the table of contents.
+	MarkdownFlavorGFM MarkdownFlavor = "gfm"
+	// MarkdownFlavorCommonMark targets plain CommonMark, which has no
+	// tables, so tabular data is always rendered as lists.
+	MarkdownFlavorCommonMark MarkdownFlavor = "commonmark"
+)
+
+// MarkdownOptions configures Markdown rendering
+type MarkdownOptions struct {
+	// IncludeTOC adds a table of contents after the document header
+	IncludeTOC bool
+	// Sections limits output to the given sections. Empty means all.
+	Sections []Section
+	// ExcludeSections removes the given sections from the output
+	ExcludeSections []Section
+	// HeadingOffset shifts every heading down by the given number of levels
+	HeadingOffset int
+	// Style selects table or list rendering for tabular data
+	Style MarkdownStyle
+	// Flavor selects the Markdown dialect
+	Flavor MarkdownFlavor
+	// GeneratedAt, when set, adds a footer with the generation time
+	GeneratedAt *time.Time
+}
+
+// DefaultMarkdownOptions returns the options used by ToMarkdown, which are
+// also the defaults of the export command: a table of contents, tables and
+// GitHub Flavored Markdown, without a generation time footer
+func DefaultMarkdownOptions() MarkdownOptions {
+	return MarkdownOptions{
+		IncludeTOC: true,
+		Style:      MarkdownStyleTable,
+		Flavor:     MarkdownFlavorGFM,
+	}
+}
+
 // ToMarkdown converts the PRD struct into a formatted Markdown document
 func (p *PRD) ToMarkdown() string {
-	var md strings.Builder
+	return p.ToMarkdownWithOptions(DefaultMarkdownOptions())
+}
 
-	// Header
-	md.WriteString(fmt.Sprintf("# %s\n\n", p.Title))
+// ToMarkdownWithOptions converts the PRD into Markdown using the given options
+func (p *PRD) ToMarkdownWithOptions(opts MarkdownOptions) string {
+	r := &markdownRenderer{prd: p, opts: opts}
+	return r.render()
+}
 
-	// Metadata table
-	md.WriteString("| Field | Value |\n")
-	md.WriteString("|-------|-------|\n")
-	md.WriteString(fmt.Sprintf("| **ID** | %s |\n", p.ID))
-	md.WriteString(fmt.Sprintf("| **Version** | %s |\n", p.Version))
-	md.WriteString(fmt.Sprintf("| **Created Date** | %s |\n", p.CreatedDate))
-	if p.LastUpdated != nil {
-		md.WriteString(fmt.Sprintf("| **Last Updated** | %s |\n", p.LastUpdated.Format("2006-01-02 15:04:05")))
+type markdownRenderer struct {
+	prd  *PRD
+	opts MarkdownOptions
+	md   strings.Builder
+}
+
+type markdownSection struct {
+	section Section
+	title   string
+	present func(*PRD) bool
+	render  func(*markdownRenderer)
+}
+
+var markdownSections = []markdownSection{
+	{SectionOwner, "Owner", func(*PRD) bool { return true }, (*markdownRenderer).renderOwner},
+	{SectionStakeholders, "Stakeholders", func(p *PRD) bool { return len(p.Stakeholders) > 0 }, (*markdownRenderer).renderStakeholders},
+	{SectionOverview, "Overview", func(*PRD) bool { return true }, (*markdownRenderer).renderOverview},
+	{SectionObjectives, "Objectives", func(*PRD) bool { return true }, (*markdownRenderer).renderObjectives},
+	{SectionPersonas, "User Personas", func(p *PRD) bool { return len(p.UserPersonas) > 0 }, (*markdownRenderer).renderPersonas},
+	{SectionStories, "User Stories", func(p *PRD) bool { return len(p.UserStories) > 0 }, (*markdownRenderer).renderStories},
+	{SectionRequirements, "Requirements", func(*PRD) bool { return true }, (*markdownRenderer).renderRequirements},
+	{SectionTechnical, "Technical Specifications", func(p *PRD) bool { return p.TechnicalSpecifications != nil }, (*markdownRenderer).renderTechnical},
+	{SectionTimeline, "Timeline", func(p *PRD) bool { return p.Timeline != nil }, (*markdownRenderer).renderTimeline},
+	{SectionRisks, "Risks and Assumptions", func(p *PRD) bool { return p.RisksAndAssumptions != nil }, (*markdownRenderer).renderRisks},
+	{SectionOutOfScope, "Out of Scope", func(p *PRD) bool { return len(p.OutOfScope) > 0 }, (*markdownRenderer).renderOutOfScope},
+	{SectionAppendices, "Appendices", func(p *PRD) bool { return p.Appendices != nil }, (*markdownRenderer).renderAppendices},
+}
+
+func (r *markdownRenderer) render() string {
+	var sections []markdownSection
+	for _, s := range markdownSections {
+		if r.included(s.section) && s.present(r.prd) {
+			sections = append(sections, s)
+		}
 	}
-	md.WriteString(fmt.Sprintf("| **Status** | %s |\n", p.Status))
-	if p.Priority != "" {
-		md.WriteString(fmt.Sprintf("| **Priority** | %s |\n", p.Priority))
+
+	r.renderHeader()
+
+	if r.opts.IncludeTOC && len(sections) > 0 {
+		r.heading(2, "Table of Contents")
+		for i, s := range sections {
+			if r.opts.Flavor == MarkdownFlavorCommonMark {
+				r.writef("%d. %s\n", i+1, s.title)
+			} else {
+				r.writef("%d. [%s](#%s)\n", i+1, s.title, markdownAnchor(s.title))
+			}
+		}
+		r.write("\n")
 	}
-	md.WriteString("\n")
 
-	// Owner and Stakeholders
-	md.WriteString("## Owner\n\n")
-	md.WriteString(fmt.Sprintf("**Name:** %s  \n", p.Owner.Name))
-	md.WriteString(fmt.Sprintf("**Email:** %s  \n", p.Owner.Email))
-	if p.Owner.Team != "" {
-		md.WriteString(fmt.Sprintf("**Team:** %s  \n", p.Owner.Team))
+	for _, s := range sections {
+		s.render(r)
+	}
+
+	if r.opts.GeneratedAt != nil {
+		r.write("---\n\n")
+		r.writef("*Document generated on %s*\n", r.opts.GeneratedAt.Format("2006-01-02 15:04"))
+	}
+
+	return r.md.String()
+}
+
+func (r *markdownRenderer) included(section Section) bool {
+	for _, s := range r.opts.ExcludeSections {
+		if s == section {
+			return false
+		}
 	}
-	md.WriteString("\n")
+	if len(r.opts.Sections) == 0 {
+		return true
+	}
+	for _, s := range r.opts.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
 
-	if len(p.Stakeholders) > 0 {
-		md.WriteString("## Stakeholders\n\n")
-		for _, stakeholder := range p.Stakeholders {
-			md.WriteString(fmt.Sprintf("- **%s** (%s)", stakeholder.Name, stakeholder.Role))
-			if stakeholder.Email != "" {
-				md.WriteString(fmt.Sprintf(" - %s", stakeholder.Email))
+func (r *markdownRenderer) useTables() bool {
+	return r.opts.Style != MarkdownStyleList && r.opts.Flavor != MarkdownFlavorCommonMark
+}
+
+func (r *markdownRenderer) write(s string) {
+	r.md.WriteString(s)
+}
+
+func (r *markdownRenderer) writef(format string, args ...any) {
+	r.md.WriteString(fmt.Sprintf(format, args...))
+}
+
+func (r *markdownRenderer) heading(level int, text string) {
+	level += r.opts.HeadingOffset
+	if level < 1 {
+		level = 1
+	}
+	if level > 6 {
+		level = 6
+	}
+	r.writef("%s %s\n\n", strings.Repeat("#", level), text)
+}
+
+// table writes rows as a GFM table or, when tables are disabled, as a list
+// with the first column in bold and the remaining columns labelled.
+func (r *markdownRenderer) table(headers []string, rows [][]string) {
+	if r.useTables() {
+		r.write("| " + strings.Join(headers, " | ") + " |\n")
+		seps := make([]string, len(headers))
+		for i, h := range headers {
+			seps[i] = strings.Repeat("-", len(h))
+		}
+		r.write("|" + strings.Join(seps, "|") + "|\n")
+		for _, row := range rows {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = markdownTableCell(cell)
 			}
-			if stakeholder.Team != "" {
-				md.WriteString(fmt.Sprintf(" - Team: %s", stakeholder.Team))
+			r.write("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		r.write("\n")
+		return
+	}
+
+	for _, row := range rows {
+		r.writef("- **%s**", row[0])
+		for i := 1; i < len(row) && i < len(headers); i++ {
+			if row[i] == "" {
+				continue
 			}
-			md.WriteString("\n")
+			r.writef("\n  - %s: %s", headers[i], row[i])
 		}
-		md.WriteString("\n")
+		r.write("\n")
 	}
+	r.write("\n")
+}
 
-	// Overview
-	md.WriteString("## Overview\n\n")
-	md.WriteString("### Problem Statement\n\n")
-	md.WriteString(fmt.Sprintf("%s\n\n", p.Overview.ProblemStatement))
+func (r *markdownRenderer) bulletList(items []string) {
+	for _, item := range items {
+		r.writef("- %s\n", item)
+	}
+	r.write("\n")
+}
 
-	md.WriteString("### Solution Summary\n\n")
-	md.WriteString(fmt.Sprintf("%s\n\n", p.Overview.SolutionSummary))
+func (r *markdownRenderer) renderHeader() {
+	p := r.prd
+	r.heading(1, p.Title)
 
-	if p.Overview.TargetAudience != "" {
-		md.WriteString("### Target Audience\n\n")
-		md.WriteString(fmt.Sprintf("%s\n\n", p.Overview.TargetAudience))
+	rows := [][]string{
+		{"ID", p.ID},
+		{"Version", p.Version},
+		{"Created Date", p.CreatedDate.String()},
+	}
+	if p.LastUpdated != nil {
+		rows = append(rows, []string{"Last Updated", p.LastUpdated.Format("2006-01-02 15:04:05")})
+	}
+	rows = append(rows, []string{"Status", string(p.Status)})
+	if p.Priority != "" {
+		rows = append(rows, []string{"Priority", string(p.Priority)})
+	}
+	if p.Parent != "" {
+		rows = append(rows, []string{"Parent", p.Parent})
+	}
+	if len(p.Children) > 0 {
+		rows = append(rows, []string{"Children", strings.Join(p.Children, ", ")})
 	}
 
-	if p.Overview.MarketContext != "" {
-		md.WriteString("### Market Context\n\n")
-		md.WriteString(fmt.Sprintf("%s\n\n", p.Overview.MarketContext))
+	if r.useTables() {
+		r.write("| Field | Value |\n")
+		r.write("|-------|-------|\n")
+		for _, row := range rows {
+			r.writef("| **%s** | %s |\n", row[0], markdownTableCell(row[1]))
+		}
+		r.write("\n")
+		return
 	}
 
-	// Objectives
-	md.WriteString("## Objectives\n\n")
-	md.WriteString("### Business Goals\n\n")
-	for i, goal := range p.Objectives.BusinessGoals {
-		md.WriteString(fmt.Sprintf("%d. %s\n", i+1, goal))
+	for _, row := range rows {
+		r.writef("**%s:** %s  \n", row[0], row[1])
 	}
-	md.WriteString("\n")
+	r.write("\n")
+}
 
-	if len(p.Objectives.SuccessMetrics) > 0 {
-		md.WriteString("### Success Metrics\n\n")
-		md.WriteString("| Metric | Target | Measurement Method |\n")
-		md.WriteString("|--------|--------|--------------------|")
-		md.WriteString("\n")
-		for _, metric := range p.Objectives.SuccessMetrics {
-			md.WriteString(fmt.Sprintf("| %s | %s | %s |\n", metric.Metric, metric.Target, metric.MeasurementMethod))
-		}
-		md.WriteString("\n")
+func (r *markdownRenderer) renderOwner() {
+	owner := r.prd.Owner
+	r.heading(2, "Owner")
+	r.writef("**Name:** %s  \n", owner.Name)
+	r.writef("**Email:** %s  \n", owner.Email)
+	if owner.Team != "" {
+		r.writef("**Team:** %s  \n", owner.Team)
 	}
+	r.write("\n")
+}
 
-	if len(p.Objectives.OKRs) > 0 {
-		md.WriteString("### OKRs\n\n")
-		for _, okr := range p.Objectives.OKRs {
-			md.WriteString(fmt.Sprintf("**Objective:** %s\n\n", okr.Objective))
-			md.WriteString("**Key Results:**\n")
-			for _, kr := range okr.KeyResults {
-				md.WriteString(fmt.Sprintf("- %s\n", kr))
-			}
-			md.WriteString("\n")
+func (r *markdownRenderer) renderStakeholders() {
+	r.heading(2, "Stakeholders")
+	for _, stakeholder := range r.prd.Stakeholders {
+		r.writef("- **%s** (%s)", stakeholder.Name, stakeholder.Role)
+		if stakeholder.Email != "" {
+			r.writef(" - %s", stakeholder.Email)
+		}
+		if stakeholder.Team != "" {
+			r.writef(" - Team: %s", stakeholder.Team)
 		}
+		r.write("\n")
 	}
+	r.write("\n")
+}
 
-	// User Personas
-	if len(p.UserPersonas) > 0 {
-		md.WriteString("## User Personas\n\n")
-		for _, persona := range p.UserPersonas {
-			md.WriteString(fmt.Sprintf("### %s\n\n", persona.Name))
-			md.WriteString(fmt.Sprintf("%s\n\n", persona.Description))
+func (r *markdownRenderer) renderOverview() {
+	overview := r.prd.Overview
+	r.heading(2, "Overview")
 
-			if len(persona.Goals) > 0 {
-				md.WriteString("**Goals:**\n")
-				for _, goal := range persona.Goals {
-					md.WriteString(fmt.Sprintf("- %s\n", goal))
-				}
-				md.WriteString("\n")
-			}
+	r.heading(3, "Problem Statement")
+	r.writef("%s\n\n", overview.ProblemStatement)
 
-			if len(persona.PainPoints) > 0 {
-				md.WriteString("**Pain Points:**\n")
-				for _, pain := range persona.PainPoints {
-					md.WriteString(fmt.Sprintf("- %s\n", pain))
-				}
-				md.WriteString("\n")
-			}
-		}
+	r.heading(3, "Solution Summary")
+	r.writef("%s\n\n", overview.SolutionSummary)
+
+	if overview.TargetAudience != "" {
+		r.heading(3, "Target Audience")
+		r.writef("%s\n\n", overview.TargetAudience)
 	}
 
-	// User Stories
-	if len(p.UserStories) > 0 {
-		md.WriteString("## User Stories\n\n")
-		for _, story := range p.UserStories {
-			md.WriteString(fmt.Sprintf("### %s\n\n", story.ID))
-			md.WriteString(fmt.Sprintf("**Story:** %s\n\n", story.Story))
+	if overview.MarketContext != "" {
+		r.heading(3, "Market Context")
+		r.writef("%s\n\n", overview.MarketContext)
+	}
+}
 
-			if len(story.AcceptanceCriteria) > 0 {
-				md.WriteString("**Acceptance Criteria:**\n")
-				for _, criteria := range story.AcceptanceCriteria {
-					md.WriteString(fmt.Sprintf("- %s\n", criteria))
-				}
-				md.WriteString("\n")
-			}
+func (r *markdownRenderer) renderObjectives() {
+	objectives := r.prd.Objectives
+	r.heading(2, "Objectives")
+
+	if len(objectives.BusinessGoals) > 0 {
+		r.heading(3, "Business Goals")
+		for i, goal := range objectives.BusinessGoals {
+			r.writef("%d. %s\n", i+1, goal)
+		}
+		r.write("\n")
+	}
 
-			if story.Priority != "" {
-				md.WriteString(fmt.Sprintf("**Priority:** %s\n", story.Priority))
+	if len(objectives.SuccessMetrics) > 0 {
+		r.heading(3, "Success Metrics")
+		var rows [][]string
+		for _, metric := range objectives.SuccessMetrics {
+			rows = append(rows, []string{metric.Metric, metric.Target, metric.MeasurementMethod})
+		}
+		r.table([]string{"Metric", "Target", "Measurement Method"}, rows)
+	}
+
+	if len(objectives.OKRs) > 0 {
+		r.heading(3, "OKRs")
+		for i, okr := range objectives.OKRs {
+			r.writef("**Objective:** %s\n\n", okr.Objective)
+			if okr.Measurable() {
+				r.writef("**Progress:** %.0f%%\n\n", okr.Progress())
 			}
-			if story.EffortEstimate != "" {
-				md.WriteString(fmt.Sprintf("**Effort Estimate:** %s\n", story.EffortEstimate))
+			r.write("**Key Results:**\n\n")
+			var rows [][]string
+			for j, kr := range okr.KeyResults {
+				rows = append(rows, append([]string{objectives.KeyResultID(i, j), kr.Description}, keyResultCells(kr)...))
 			}
-			md.WriteString("\n")
+			r.table([]string{"ID", "Key Result", "Baseline", "Target", "Current", "Progress", "Confidence"}, rows)
 		}
 	}
+}
 
-	// Requirements
-	md.WriteString("## Requirements\n\n")
-	md.WriteString("### Functional Requirements\n\n")
-	for _, req := range p.Requirements.Functional {
-		md.WriteString(fmt.Sprintf("#### %s\n\n", req.ID))
-		md.WriteString(fmt.Sprintf("%s\n\n", req.Description))
+func (r *markdownRenderer) renderPersonas() {
+	r.heading(2, "User Personas")
+	for _, persona := range r.prd.UserPersonas {
+		r.heading(3, persona.Name)
+		r.writef("%s\n\n", persona.Description)
 
-		if req.Priority != "" {
-			md.WriteString(fmt.Sprintf("**Priority:** %s\n", req.Priority))
+		if len(persona.Goals) > 0 {
+			r.write("**Goals:**\n\n")
+			r.bulletList(persona.Goals)
 		}
 
-		if len(req.Dependencies) > 0 {
-			md.WriteString("**Dependencies:**\n")
-			for _, dep := range req.Dependencies {
-				md.WriteString(fmt.Sprintf("- %s\n", dep))
-			}
+		if len(persona.PainPoints) > 0 {
+			r.write("**Pain Points:**\n\n")
+			r.bulletList(persona.PainPoints)
 		}
-		md.WriteString("\n")
 	}
+}
 
-	if len(p.Requirements.NonFunctional) > 0 {
-		md.WriteString("### Non-Functional Requirements\n\n")
-		for _, req := range p.Requirements.NonFunctional {
-			md.WriteString(fmt.Sprintf("#### %s (%s)\n\n", req.ID, req.Category))
-			md.WriteString(fmt.Sprintf("%s\n\n", req.Description))
+func (r *markdownRenderer) renderStories() {
+	r.heading(2, "User Stories")
+	for _, story := range r.prd.UserStories {
+		r.heading(3, story.ID)
+		r.writef("**Story:** %s\n\n", story.Story)
 
-			if req.AcceptanceCriteria != "" {
-				md.WriteString(fmt.Sprintf("**Acceptance Criteria:** %s\n\n", req.AcceptanceCriteria))
-			}
+		if len(story.AcceptanceCriteria) > 0 {
+			r.write("**Acceptance Criteria:**\n\n")
+			r.bulletList(story.AcceptanceCriteria)
+		}
+
+		if story.Priority != "" {
+			r.writef("**Priority:** %s  \n", story.Priority)
+		}
+		if story.EffortEstimate != "" {
+			r.writef("**Effort Estimate:** %s  \n", story.EffortEstimate)
+		}
+		if story.Priority != "" || story.EffortEstimate != "" {
+			r.write("\n")
 		}
 	}
+}
 
-	// Technical Specifications
-	if p.TechnicalSpecifications != nil {
-		md.WriteString("## Technical Specifications\n\n")
+func (r *markdownRenderer) renderRequirements() {
+	requirements := r.prd.Requirements
+	r.heading(2, "Requirements")
 
-		if p.TechnicalSpecifications.ArchitectureOverview != "" {
-			md.WriteString("### Architecture Overview\n\n")
-			md.WriteString(fmt.Sprintf("%s\n\n", p.TechnicalSpecifications.ArchitectureOverview))
+	if len(requirements.Functional) > 0 {
+		r.heading(3, "Functional Requirements")
+		var rows [][]string
+		for _, req := range requirements.Functional {
+			rows = append(rows, []string{req.ID, req.Description, string(req.Priority), strings.Join(req.Dependencies, ", ")})
 		}
+		r.table([]string{"ID", "Description", "Priority", "Dependencies"}, rows)
+	}
 
-		if p.TechnicalSpecifications.TechnologyStack != nil {
-			md.WriteString("### Technology Stack\n\n")
-			stack := p.TechnicalSpecifications.TechnologyStack
-
-			if len(stack.Frontend) > 0 {
-				md.WriteString("**Frontend:**\n")
-				for _, tech := range stack.Frontend {
-					md.WriteString(fmt.Sprintf("- %s\n", tech))
-				}
-				md.WriteString("\n")
-			}
+	if len(requirements.NonFunctional) > 0 {
+		r.heading(3, "Non-Functional Requirements")
+		var rows [][]string
+		for _, req := range requirements.NonFunctional {
+			rows = append(rows, []string{req.ID, string(req.Category), req.Description, req.AcceptanceCriteria})
+		}
+		r.table([]string{"ID", "Category", "Description", "Acceptance Criteria"}, rows)
+	}
+}
 
-			if len(stack.Backend) > 0 {
-				md.WriteString("**Backend:**\n")
-				for _, tech := range stack.Backend {
-					md.WriteString(fmt.Sprintf("- %s\n", tech))
-				}
-				md.WriteString("\n")
-			}
+func (r *markdownRenderer) renderTechnical() {
+	specs := r.prd.TechnicalSpecifications
+	r.heading(2, "Technical Specifications")
 
-			if len(stack.Database) > 0 {
-				md.WriteString("**Database:**\n")
-				for _, tech := range stack.Database {
-					md.WriteString(fmt.Sprintf("- %s\n", tech))
-				}
-				md.WriteString("\n")
-			}
+	if specs.ArchitectureOverview != "" {
+		r.heading(3, "Architecture Overview")
+		r.writef("%s\n\n", specs.ArchitectureOverview)
+	}
 
-			if len(stack.Infrastructure) > 0 {
-				md.WriteString("**Infrastructure:**\n")
-				for _, tech := range stack.Infrastructure {
-					md.WriteString(fmt.Sprintf("- %s\n", tech))
-				}
-				md.WriteString("\n")
+	if specs.TechnologyStack != nil {
+		stack := specs.TechnologyStack
+		r.heading(3, "Technology Stack")
+		for _, layer := range []struct {
+			name  string
+			items []string
+		}{
+			{"Frontend", stack.Frontend},
+			{"Backend", stack.Backend},
+			{"Database", stack.Database},
+			{"Infrastructure", stack.Infrastructure},
+		} {
+			if len(layer.items) > 0 {
+				r.writef("**%s:** %s\n\n", layer.name, strings.Join(layer.items, ", "))
 			}
 		}
+	}
 
-		if len(p.TechnicalSpecifications.APISpecifications) > 0 {
-			md.WriteString("### API Specifications\n\n")
-			for _, api := range p.TechnicalSpecifications.APISpecifications {
-				if api.Endpoint != "" && api.Method != "" {
-					md.WriteString(fmt.Sprintf("#### %s %s\n\n", api.Method, api.Endpoint))
-				}
-				if api.Description != "" {
-					md.WriteString(fmt.Sprintf("%s\n\n", api.Description))
-				}
-				if api.RequestFormat != "" {
-					md.WriteString(fmt.Sprintf("**Request Format:** %s\n", api.RequestFormat))
-				}
-				if api.ResponseFormat != "" {
-					md.WriteString(fmt.Sprintf("**Response Format:** %s\n", api.ResponseFormat))
-				}
-				md.WriteString("\n")
+	if len(specs.APISpecifications) > 0 {
+		r.heading(3, "API Specifications")
+		for _, api := range specs.APISpecifications {
+			if api.Endpoint != "" && api.Method != "" {
+				r.heading(4, fmt.Sprintf("%s %s", api.Method, api.Endpoint))
 			}
-		}
-
-		if len(p.TechnicalSpecifications.SecurityConsiderations) > 0 {
-			md.WriteString("### Security Considerations\n\n")
-			for _, consideration := range p.TechnicalSpecifications.SecurityConsiderations {
-				md.WriteString(fmt.Sprintf("- %s\n", consideration))
+			if api.Description != "" {
+				r.writef("%s\n\n", api.Description)
 			}
-			md.WriteString("\n")
-		}
-	}
-
-	// Timeline
-	if p.Timeline != nil {
-		md.WriteString("## Timeline\n\n")
-
-		if len(p.Timeline.Milestones) > 0 {
-			md.WriteString("### Milestones\n\n")
-			for _, milestone := range p.Timeline.Milestones {
-				md.WriteString(fmt.Sprintf("#### %s\n\n", milestone.Name))
-				if milestone.Description != "" {
-					md.WriteString(fmt.Sprintf("%s\n\n", milestone.Description))
-				}
-				md.WriteString(fmt.Sprintf("**Target Date:** %s\n", milestone.TargetDate))
-
-				if len(milestone.Dependencies) > 0 {
-					md.WriteString("**Dependencies:**\n")
-					for _, dep := range milestone.Dependencies {
-						md.WriteString(fmt.Sprintf("- %s\n", dep))
-					}
-				}
-				md.WriteString("\n")
+			if api.RequestFormat != "" {
+				r.writef("**Request Format:** %s  \n", api.RequestFormat)
 			}
+			if api.ResponseFormat != "" {
+				r.writef("**Response Format:** %s  \n", api.ResponseFormat)
+			}
+			r.write("\n")
 		}
+	}
 
-		if p.Timeline.LaunchDate != "" {
-			md.WriteString(fmt.Sprintf("### Launch Date: %s\n\n", p.Timeline.LaunchDate))
-		}
+	if len(specs.SecurityConsiderations) > 0 {
+		r.heading(3, "Security Considerations")
+		r.bulletList(specs.SecurityConsiderations)
 	}
+}
 
-	// Risks and Assumptions
-	if p.RisksAndAssumptions != nil {
-		md.WriteString("## Risks and Assumptions\n\n")
+func (r *markdownRenderer) renderTimeline() {
+	timeline := r.prd.Timeline
+	r.heading(2, "Timeline")
 
-		if len(p.RisksAndAssumptions.Risks) > 0 {
-			md.WriteString("### Risks\n\n")
-			md.WriteString("| Risk | Impact | Probability | Mitigation Strategy |\n")
-			md.WriteString("|------|--------|-------------|--------------------|\n")
-			for _, risk := range p.RisksAndAssumptions.Risks {
-				md.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
-					risk.Description, risk.Impact, risk.Probability, risk.MitigationStrategy))
-			}
-			md.WriteString("\n")
-		}
+	if !timeline.LaunchDate.IsZero() {
+		r.writef("**Target Launch Date:** %s\n\n", timeline.LaunchDate)
+	}
 
-		if len(p.RisksAndAssumptions.Assumptions) > 0 {
-			md.WriteString("### Assumptions\n\n")
-			for _, assumption := range p.RisksAndAssumptions.Assumptions {
-				md.WriteString(fmt.Sprintf("- %s\n", assumption))
-			}
-			md.WriteString("\n")
+	// GitHub renders Mermaid code blocks as diagrams
+	if r.opts.Flavor != MarkdownFlavorCommonMark && timeline.hasDatedMilestones() {
+		if chart, err := r.prd.ToMermaidGantt(GanttOptions{Today: r.opts.GeneratedAt}); err == nil {
+			r.write("```mermaid\n" + chart + "```\n\n")
 		}
 	}
 
-	// Out of Scope
-	if len(p.OutOfScope) > 0 {
-		md.WriteString("## Out of Scope\n\n")
-		for _, item := range p.OutOfScope {
-			md.WriteString(fmt.Sprintf("- %s\n", item))
+	if len(timeline.Milestones) > 0 {
+		r.heading(3, "Milestones")
+		var rows [][]string
+		for _, milestone := range timeline.Milestones {
+			rows = append(rows, []string{milestone.Name, milestone.TargetDate.String(), milestone.Description, strings.Join(milestone.Dependencies, ", ")})
 		}
-		md.WriteString("\n")
+		r.table([]string{"Milestone", "Target Date", "Description", "Dependencies"}, rows)
 	}
+}
 
-	// Appendices
-	if p.Appendices != nil {
-		md.WriteString("## Appendices\n\n")
+func (r *markdownRenderer) renderRisks() {
+	ra := r.prd.RisksAndAssumptions
+	r.heading(2, "Risks and Assumptions")
 
-		if p.Appendices.ResearchData != "" {
-			md.WriteString("### Research Data\n\n")
-			md.WriteString(fmt.Sprintf("%s\n\n", p.Appendices.ResearchData))
+	if len(ra.Risks) > 0 {
+		r.heading(3, "Risks")
+		var rows [][]string
+		for i, risk := range ra.Risks {
+			rows = append(rows, []string{ra.RiskID(i), risk.Description, string(risk.Impact), string(risk.Probability), riskScoreLabel(risk), risk.Owner, string(risk.Status), risk.MitigationStrategy})
 		}
+		r.table([]string{"ID", "Risk", "Impact", "Probability", "Score", "Owner", "Status", "Mitigation Strategy"}, rows)
+	}
 
-		if len(p.Appendices.MockupsWireframes) > 0 {
-			md.WriteString("### Mockups and Wireframes\n\n")
-			for _, mockup := range p.Appendices.MockupsWireframes {
-				if mockup.Name != "" {
-					md.WriteString(fmt.Sprintf("#### %s\n\n", mockup.Name))
-				}
-				if mockup.Description != "" {
-					md.WriteString(fmt.Sprintf("%s\n\n", mockup.Description))
-				}
-				if mockup.URL != "" {
-					md.WriteString(fmt.Sprintf("[View Mockup](%s)\n\n", mockup.URL))
-				}
+	if len(ra.Assumptions) > 0 {
+		r.heading(3, "Assumptions")
+		var rows [][]string
+		for i, a := range ra.Assumptions {
+			rows = append(rows, []string{ra.AssumptionID(i), a.Statement, string(a.Confidence), a.ValidationMethod, a.ResultLabel()})
+		}
+		r.table([]string{"ID", "Assumption", "Confidence", "Validation Method", "Result"}, rows)
+	}
+}
+
+func (r *markdownRenderer) renderOutOfScope() {
+	r.heading(2, "Out of Scope")
+	r.bulletList(r.prd.OutOfScope)
+}
+
+func (r *markdownRenderer) renderAppendices() {
+	appendices := r.prd.Appendices
+	r.heading(2, "Appendices")
+
+	if appendices.ResearchData != "" {
+		r.heading(3, "Research Data")
+		r.writef("%s\n\n", appendices.ResearchData)
+	}
+
+	if len(appendices.MockupsWireframes) > 0 {
+		r.heading(3, "Mockups and Wireframes")
+		for _, mockup := range appendices.MockupsWireframes {
+			if mockup.Name != "" {
+				r.heading(4, mockup.Name)
+			}
+			if mockup.Description != "" {
+				r.writef("%s\n\n", mockup.Description)
+			}
+			if mockup.URL != "" {
+				r.writef("[View Mockup](%s)\n\n", mockup.URL)
 			}
 		}
+	}
 
-		if len(p.Appendices.RelatedDocuments) > 0 {
-			md.WriteString("### Related Documents\n\n")
-			for _, doc := range p.Appendices.RelatedDocuments {
-				if doc.Title != "" && doc.URL != "" {
-					md.WriteString(fmt.Sprintf("- [%s](%s)", doc.Title, doc.URL))
-					if doc.Type != "" {
-						md.WriteString(fmt.Sprintf(" (%s)", doc.Type))
-					}
-					md.WriteString("\n")
-				}
+	if len(appendices.RelatedDocuments) > 0 {
+		r.heading(3, "Related Documents")
+		for _, doc := range appendices.RelatedDocuments {
+			switch {
+			case doc.Title != "" && doc.URL != "":
+				r.writef("- [%s](%s)", doc.Title, doc.URL)
+			case doc.Title != "":
+				r.writef("- %s", doc.Title)
+			default:
+				continue
 			}
-			md.WriteString("\n")
+			if doc.Type != "" {
+				r.writef(" (%s)", doc.Type)
+			}
+			r.write("\n")
 		}
+		r.write("\n")
 	}
+}
 
-	return md.String()
+// markdownTableCell escapes characters that would break a GFM table row
+func markdownTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// markdownAnchor returns the GitHub-style anchor for a heading
+func markdownAnchor(title string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(title) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_':
+			b.WriteRune(c)
+		case c == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
 }
@@ -0,0 +1,107 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToMarkdownWithOptions(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		opts        MarkdownOptions
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Default",
+			opts:        DefaultMarkdownOptions(),
+			contains:    []string{"# " + p.Title, "| **ID** | PRD-001 |", "## Requirements", "| ID | Description | Priority | Dependencies |", "## Table of Contents"},
+			notContains: []string{"Document generated on"},
+		},
+		{
+			name: "TOC and sections",
+			opts: MarkdownOptions{
+				IncludeTOC: true,
+				Sections:   []Section{SectionOverview, SectionRequirements},
+				Flavor:     MarkdownFlavorGFM,
+			},
+			contains:    []string{"1. [Overview](#overview)", "2. [Requirements](#requirements)"},
+			notContains: []string{"## Timeline", "## Owner"},
+		},
+		{
+			name: "Exclude sections",
+			opts: MarkdownOptions{
+				ExcludeSections: []Section{SectionRisks},
+			},
+			notContains: []string{"## Risks and Assumptions"},
+		},
+		{
+			name: "Heading offset",
+			opts: MarkdownOptions{
+				HeadingOffset: 1,
+			},
+			contains:    []string{"## " + p.Title, "### Overview", "#### Problem Statement"},
+			notContains: []string{"\n## Overview"},
+		},
+		{
+			name: "CommonMark renders lists",
+			opts: MarkdownOptions{
+				IncludeTOC: true,
+				Flavor:     MarkdownFlavorCommonMark,
+			},
+			contains:    []string{"**ID:** PRD-001", "- **FR-001**", "1. Owner\n"},
+			notContains: []string{"| ID |", "](#"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := p.ToMarkdownWithOptions(tt.opts)
+			for _, s := range tt.contains {
+				if !strings.Contains(md, s) {
+					t.Errorf("Expected Markdown to contain %q", s)
+				}
+			}
+			for _, s := range tt.notContains {
+				if strings.Contains(md, s) {
+					t.Errorf("Expected Markdown not to contain %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownTableCellEscaping(t *testing.T) {
+	p := &PRD{
+		Title: "Escaping",
+		Requirements: Requirements{
+			Functional: []FunctionalRequirement{
+				{ID: "FR-001", Description: "Support a | b\nand c"},
+			},
+		},
+	}
+
+	md := p.ToMarkdown()
+	if !strings.Contains(md, "| FR-001 | Support a \\| b<br>and c |") {
+		t.Errorf("Expected escaped table cell, got:\n%s", md)
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	sections, err := ParseSections("overview, requirements")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sections) != 2 || sections[0] != SectionOverview || sections[1] != SectionRequirements {
+		t.Errorf("Unexpected sections: %v", sections)
+	}
+
+	if _, err := ParseSections("overview,bogus"); err == nil {
+		t.Error("Expected error for unknown section")
+	}
+}
@@ -0,0 +1,421 @@
+package prd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sparklineLength is the number of recent measurements a sparkline shows
+const sparklineLength = 30
+
+// sparklineBlocks are the bar characters of a text sparkline, lowest first
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// MetricReport is where a success metric stands against its goal on a date
+type MetricReport struct {
+	Metric SuccessMetric
+	// Latest is the most recent measurement, if any
+	Latest *Measurement
+	// Projected is the value projected at the goal deadline from the trend
+	// of the measurements, if there is a deadline and a trend
+	Projected *float64
+	// Status is empty for metrics without a goal
+	Status MetricStatus
+}
+
+// MetricSample is a measurement read from a data file, for the named metric
+// or, when Metric is empty, for the metric the file is ingested into
+type MetricSample struct {
+	Metric string
+	Measurement
+}
+
+// IngestResult counts the measurements ingested into a success metric
+type IngestResult struct {
+	Metric  string
+	Added   int
+	Updated int
+}
+
+// formatWithUnit formats v followed by its unit, e.g. "95%" or "10 s"
+func formatWithUnit(v float64, unit string) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	switch unit {
+	case "":
+		return s
+	case "%":
+		return s + "%"
+	default:
+		return s + " " + unit
+	}
+}
+
+// String formats the goal, e.g. ">= 95% by 2025-Q3"
+func (g MetricGoal) String() string {
+	s := string(g.Comparator) + " " + formatWithUnit(g.Value, g.Unit)
+	if !g.Deadline.IsZero() {
+		s += " by " + g.Deadline.String()
+	}
+	return s
+}
+
+// FormatValue formats v in the unit of the metric's goal
+func (m SuccessMetric) FormatValue(v float64) string {
+	if m.Goal == nil {
+		return formatWithUnit(v, "")
+	}
+	return formatWithUnit(v, m.Goal.Unit)
+}
+
+// Latest returns the most recent measurement, if any
+func (m SuccessMetric) Latest() (Measurement, bool) {
+	if len(m.Measurements) == 0 {
+		return Measurement{}, false
+	}
+	return m.Measurements[len(m.Measurements)-1], true
+}
+
+// trend fits a least-squares line through the measurements, returning the
+// change per day and the value at the first measurement's date. It needs
+// measurements on at least two dates.
+func (m SuccessMetric) trend() (slope, intercept float64, ok bool) {
+	if len(m.Measurements) < 2 {
+		return 0, 0, false
+	}
+
+	origin := m.Measurements[0].Date.Start()
+	n := float64(len(m.Measurements))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, ms := range m.Measurements {
+		x := ms.Date.Start().Sub(origin).Hours() / 24
+		sumX += x
+		sumY += ms.Value
+		sumXY += x * ms.Value
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, 0, false
+	}
+	slope = (n*sumXY - sumX*sumY) / denominator
+	return slope, (sumY - slope*sumX) / n, true
+}
+
+// Project returns the value the trend of the measurements reaches on date,
+// and false if there are too few measurements for a trend
+func (m SuccessMetric) Project(date Date) (float64, bool) {
+	slope, intercept, ok := m.trend()
+	if !ok {
+		return 0, false
+	}
+	days := date.End().Sub(m.Measurements[0].Date.Start()).Hours() / 24
+	return intercept + slope*days, true
+}
+
+// Report compares the latest measurement with the goal. A metric whose
+// latest value meets the goal is met; otherwise it is missed once the
+// deadline has passed, and on track if the trend of its measurements meets
+// the goal by the deadline, or is moving towards it when there is no
+// deadline.
+func (m SuccessMetric) Report(now time.Time) MetricReport {
+	report := MetricReport{Metric: m}
+	if latest, ok := m.Latest(); ok {
+		report.Latest = &latest
+	}
+	if m.Goal == nil {
+		return report
+	}
+
+	goal := *m.Goal
+	switch {
+	case report.Latest == nil:
+		report.Status = MetricNoData
+		return report
+	case goal.Comparator.Compare(report.Latest.Value, goal.Value):
+		report.Status = MetricMet
+		return report
+	case !goal.Deadline.IsZero() && goal.Deadline.Before(DateOf(now)):
+		report.Status = MetricMissed
+		return report
+	}
+
+	report.Status = MetricOffTrack
+	if goal.Deadline.IsZero() {
+		if slope, _, ok := m.trend(); ok && slope != 0 && (slope > 0) == (goal.Value > report.Latest.Value) {
+			report.Status = MetricOnTrack
+		}
+		return report
+	}
+	if projected, ok := m.Project(goal.Deadline); ok {
+		report.Projected = &projected
+		if goal.Comparator.Compare(projected, goal.Value) {
+			report.Status = MetricOnTrack
+		}
+	}
+	return report
+}
+
+// LatestLabel formats the latest measurement with its date, e.g. "92%
+// (2025-03-01)"
+func (r MetricReport) LatestLabel() string {
+	if r.Latest == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", r.Metric.FormatValue(r.Latest.Value), r.Latest.Date)
+}
+
+// MetricReports reports every success metric as of now
+func (p *PRD) MetricReports(now time.Time) []MetricReport {
+	reports := make([]MetricReport, len(p.Objectives.SuccessMetrics))
+	for i, m := range p.Objectives.SuccessMetrics {
+		reports[i] = m.Report(now)
+	}
+	return reports
+}
+
+// SuccessMetric finds a success metric by name, case-insensitively
+func (o *Objectives) SuccessMetric(name string) (*SuccessMetric, error) {
+	for i := range o.SuccessMetrics {
+		if strings.EqualFold(o.SuccessMetrics[i].Metric, strings.TrimSpace(name)) {
+			return &o.SuccessMetrics[i], nil
+		}
+	}
+	return nil, fmt.Errorf("success metric '%s' not found", name)
+}
+
+// AddMeasurements merges measurements into the metric in date order. A
+// measurement on a date that already has one replaces it.
+func (m *SuccessMetric) AddMeasurements(measurements []Measurement) (added, updated int) {
+	for _, ms := range measurements {
+		i, found := slices.BinarySearchFunc(m.Measurements, ms.Date, func(e Measurement, d Date) int {
+			return e.Date.Compare(d)
+		})
+		if found {
+			m.Measurements[i] = ms
+			updated++
+			continue
+		}
+		m.Measurements = slices.Insert(m.Measurements, i, ms)
+		added++
+	}
+	return added, updated
+}
+
+// IngestMeasurements adds samples to the success metrics they name. Samples
+// without a metric name are added to metric, which is then required.
+func (p *PRD) IngestMeasurements(samples []MetricSample, metric string) ([]IngestResult, error) {
+	var names []string
+	byMetric := map[*SuccessMetric][]Measurement{}
+	for _, sample := range samples {
+		name := sample.Metric
+		if name == "" {
+			name = metric
+		}
+		if name == "" {
+			return nil, fmt.Errorf("measurement on %s names no metric: add a metric column or choose a metric", sample.Date)
+		}
+		m, err := p.Objectives.SuccessMetric(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, seen := byMetric[m]; !seen {
+			names = append(names, m.Metric)
+		}
+		byMetric[m] = append(byMetric[m], sample.Measurement)
+	}
+
+	results := make([]IngestResult, len(names))
+	for i, name := range names {
+		m, _ := p.Objectives.SuccessMetric(name)
+		added, updated := m.AddMeasurements(byMetric[m])
+		results[i] = IngestResult{Metric: m.Metric, Added: added, Updated: updated}
+	}
+	return results, nil
+}
+
+// LoadMeasurements reads a metric time series from a CSV or JSON file,
+// chosen by extension
+func LoadMeasurements(filename string) ([]MetricSample, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read measurements: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ParseMeasurementsCSV(f)
+	case ".json":
+		return ParseMeasurementsJSON(f)
+	default:
+		return nil, fmt.Errorf("unsupported measurements file '%s': expected .csv or .json", filename)
+	}
+}
+
+// ParseMeasurementsCSV reads a CSV time series with a header row naming a
+// date and a value column, and optionally a metric column
+func ParseMeasurementsCSV(r io.Reader) ([]MetricSample, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse measurements CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("measurements CSV is empty")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	dateCol, hasDate := columns["date"]
+	valueCol, hasValue := columns["value"]
+	if !hasDate || !hasValue {
+		return nil, errors.New("measurements CSV requires date and value columns")
+	}
+	metricCol, hasMetric := columns["metric"]
+
+	samples := make([]MetricSample, 0, len(records)-1)
+	for i, record := range records[1:] {
+		var sample MetricSample
+		if sample.Date, err = ParseDate(record[dateCol]); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		if sample.Value, err = strconv.ParseFloat(strings.TrimSpace(record[valueCol]), 64); err != nil {
+			return nil, fmt.Errorf("row %d: invalid value '%s'", i+2, record[valueCol])
+		}
+		if hasMetric {
+			sample.Metric = strings.TrimSpace(record[metricCol])
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// ParseMeasurementsJSON reads a JSON array of {"date", "value"} objects,
+// each optionally naming its "metric"
+func ParseMeasurementsJSON(r io.Reader) ([]MetricSample, error) {
+	var rows []struct {
+		Metric string   `json:"metric"`
+		Date   Date     `json:"date"`
+		Value  *float64 `json:"value"`
+	}
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to parse measurements JSON: %w", err)
+	}
+
+	samples := make([]MetricSample, len(rows))
+	for i, row := range rows {
+		if row.Date.IsZero() || row.Value == nil {
+			return nil, fmt.Errorf("measurement %d requires a date and a value", i+1)
+		}
+		samples[i] = MetricSample{Metric: row.Metric, Measurement: Measurement{Date: row.Date, Value: *row.Value}}
+	}
+	return samples, nil
+}
+
+// Sparkline draws the last n measurements as a line of block characters
+func (m SuccessMetric) Sparkline(n int) string {
+	values := m.values(n)
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := slices.Min(values), slices.Max(values)
+	var b strings.Builder
+	for _, v := range values {
+		level := len(sparklineBlocks) / 2
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparklineBlocks)-1))
+		}
+		b.WriteRune(sparklineBlocks[level])
+	}
+	return b.String()
+}
+
+// SparklineSVG draws the last n measurements as a small inline SVG line
+// chart, with the goal as a dashed line. It is empty without measurements.
+func (m SuccessMetric) SparklineSVG(n int) string {
+	const width, height, pad = 120.0, 28.0, 3.0
+
+	values := m.values(n)
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := slices.Min(values), slices.Max(values)
+	if m.Goal != nil {
+		lo, hi = min(lo, m.Goal.Value), max(hi, m.Goal.Value)
+	}
+	y := func(v float64) float64 {
+		if hi == lo {
+			return height / 2
+		}
+		return height - pad - (v-lo)/(hi-lo)*(height-2*pad)
+	}
+	x := func(i int) float64 {
+		if len(values) == 1 {
+			return width / 2
+		}
+		return pad + float64(i)*(width-2*pad)/float64(len(values)-1)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`, width, height, width, height)
+	if m.Goal != nil {
+		fmt.Fprintf(&b, `<line x1="0" y1="%.1f" x2="%.0f" y2="%.1f" stroke="#27ae60" stroke-dasharray="3,2"/>`, y(m.Goal.Value), width, y(m.Goal.Value))
+	}
+	points := make([]string, len(values))
+	for i, v := range values {
+		points[i] = fmt.Sprintf("%.1f,%.1f", x(i), y(v))
+	}
+	fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#2c6fbb" stroke-width="1.5"/>`, strings.Join(points, " "))
+	last := len(values) - 1
+	fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="2" fill="#2c6fbb"/>`, x(last), y(values[last]))
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+// values returns the last n measured values
+func (m SuccessMetric) values(n int) []float64 {
+	measurements := m.Measurements[max(len(m.Measurements)-n, 0):]
+	values := make([]float64, len(measurements))
+	for i, ms := range measurements {
+		values[i] = ms.Value
+	}
+	return values
+}
+
+func (o *Objectives) validateSuccessMetrics() error {
+	for _, m := range o.SuccessMetrics {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks a success metric's goal comparator and that its
+// measurements are dated and in date order
+func (m SuccessMetric) Validate() error {
+	if m.Goal != nil && !m.Goal.Comparator.Valid() {
+		return fmt.Errorf("invalid comparator for success metric %s: '%s'", m.Metric, m.Goal.Comparator)
+	}
+	for i, ms := range m.Measurements {
+		if ms.Date.IsZero() {
+			return fmt.Errorf("measurement of success metric %s requires a date", m.Metric)
+		}
+		if i > 0 && ms.Date.Compare(m.Measurements[i-1].Date) <= 0 {
+			return fmt.Errorf("measurements of success metric %s must be in date order without repeats: %s follows %s", m.Metric, ms.Date, m.Measurements[i-1].Date)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func measurements(values ...float64) []Measurement {
+	start := MustParseDate("2025-01-01").Start()
+	ms := make([]Measurement, len(values))
+	for i, v := range values {
+		ms[i] = Measurement{Date: DateOf(start.AddDate(0, 0, 7*i)), Value: v}
+	}
+	return ms
+}
+
+func TestMetricReport(t *testing.T) {
+	now := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	goal := func(c Comparator, v float64, deadline string) *MetricGoal {
+		g := &MetricGoal{Comparator: c, Value: v, Unit: "%"}
+		if deadline != "" {
+			g.Deadline = MustParseDate(deadline)
+		}
+		return g
+	}
+
+	tests := []struct {
+		name   string
+		metric SuccessMetric
+		status MetricStatus
+	}{
+		{"no goal", SuccessMetric{Measurements: measurements(1, 2)}, ""},
+		{"no data", SuccessMetric{Goal: goal(AtLeast, 90, "2025-06")}, MetricNoData},
+		{"met", SuccessMetric{Goal: goal(AtLeast, 90, "2025-06"), Measurements: measurements(85, 91)}, MetricMet},
+		// +1 a week reaches 90 by the end of June
+		{"on track", SuccessMetric{Goal: goal(AtLeast, 90, "2025-06"), Measurements: measurements(70, 71, 72, 73)}, MetricOnTrack},
+		{"off track", SuccessMetric{Goal: goal(AtLeast, 90, "2025-03"), Measurements: measurements(70, 71, 72, 73)}, MetricOffTrack},
+		{"single measurement", SuccessMetric{Goal: goal(AtLeast, 90, "2025-06"), Measurements: measurements(70)}, MetricOffTrack},
+		{"reduction on track", SuccessMetric{Goal: goal(AtMost, 10, ""), Measurements: measurements(25, 20, 16)}, MetricOnTrack},
+		{"reduction regressing", SuccessMetric{Goal: goal(Below, 10, ""), Measurements: measurements(16, 20)}, MetricOffTrack},
+		{"missed", SuccessMetric{Goal: goal(AtLeast, 90, "2025-01"), Measurements: measurements(70, 80)}, MetricMissed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.metric.Report(now).Status; got != tt.status {
+				t.Errorf("Status = %q, expected %q", got, tt.status)
+			}
+		})
+	}
+
+	report := SuccessMetric{Goal: goal(AtLeast, 90, "2025-06"), Measurements: measurements(70, 71, 72, 73)}.Report(now)
+	if report.Projected == nil || *report.Projected < 90 || report.LatestLabel() != "73% (2025-01-22)" {
+		t.Errorf("Unexpected report: projected %v, latest %s", report.Projected, report.LatestLabel())
+	}
+}
+
+func TestIngestMeasurements(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	samples, err := ParseMeasurementsCSV(strings.NewReader("Date,Value\n2024-02-01,87\n2024-04-01,92\n2023-12-01,80\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := p.IngestMeasurements(samples, ""); err == nil || !strings.Contains(err.Error(), "names no metric") {
+		t.Errorf("Expected an error for rows without a metric, got %v", err)
+	}
+
+	results, err := p.IngestMeasurements(samples, "login success rate")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0] != (IngestResult{Metric: "Login Success Rate", Added: 2, Updated: 1}) {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	m := p.Objectives.SuccessMetrics[0]
+	var dates []string
+	for _, ms := range m.Measurements {
+		dates = append(dates, ms.Date.String())
+	}
+	if strings.Join(dates, ",") != "2023-12-01,2024-01-01,2024-02-01,2024-03-01,2024-04-01" || m.Measurements[2].Value != 87 {
+		t.Errorf("Expected measurements merged in date order, got %v", m.Measurements)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected ingested measurements to validate, got %v", err)
+	}
+
+	if _, err := p.IngestMeasurements([]MetricSample{{Metric: "Revenue"}}, ""); err == nil || !strings.Contains(err.Error(), "success metric 'Revenue' not found") {
+		t.Errorf("Expected unknown metric error, got %v", err)
+	}
+}
+
+func TestLoadMeasurements(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		file    string
+		content string
+		want    string
+	}{
+		{"series.csv", "metric,date,value\nNPS,2025-01,41\nNPS,2025-02,44.5\n", ""},
+		{"series.json", `[{"metric":"NPS","date":"2025-01","value":41},{"metric":"NPS","date":"2025-02-01T00:00:00Z","value":44.5}]`, ""},
+		{"bad.csv", "date,score\n2025-01,41\n", "requires date and value columns"},
+		{"bad-value.csv", "date,value\n2025-01,n/a\n", "row 2: invalid value 'n/a'"},
+		{"missing.json", `[{"date":"2025-01"}]`, "requires a date and a value"},
+		{"series.xlsx", "", "unsupported measurements file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			samples, err := LoadMeasurements(path)
+			if tt.want != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("Expected error containing %q, got %v", tt.want, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(samples) != 2 || samples[0].Metric != "NPS" || samples[1].Value != 44.5 {
+				t.Errorf("Unexpected samples: %+v", samples)
+			}
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	m := SuccessMetric{Goal: &MetricGoal{Comparator: AtLeast, Value: 100}, Measurements: measurements(5, 1, 8, 3, 10)}
+	if got := m.Sparkline(4); got != "▁▆▂█" {
+		t.Errorf("Sparkline(4) = %q", got)
+	}
+	if got := (SuccessMetric{Measurements: measurements(2, 2)}).Sparkline(10); got != "▅▅" {
+		t.Errorf("Expected a flat sparkline, got %q", got)
+	}
+
+	svg := m.SparklineSVG(30)
+	if !strings.HasPrefix(svg, `<svg class="sparkline"`) || strings.Count(svg, ",") != 6 || !strings.Contains(svg, "stroke-dasharray") {
+		t.Errorf("Unexpected sparkline SVG: %s", svg)
+	}
+	if (SuccessMetric{}).SparklineSVG(30) != "" {
+		t.Error("Expected no sparkline without measurements")
+	}
+}
+
+func TestValidateSuccessMetrics(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	m := &p.Objectives.SuccessMetrics[0]
+	m.Measurements[0], m.Measurements[1] = m.Measurements[1], m.Measurements[0]
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "must be in date order") {
+		t.Errorf("Expected date order error, got %v", err)
+	}
+
+	if err := m.Validate(); err == nil || !strings.Contains(err.Error(), "must be in date order") {
+		t.Errorf("Expected the metric to fail on its own, got %v", err)
+	}
+	if err := p.Objectives.SuccessMetrics[1].Validate(); err != nil {
+		t.Errorf("Expected other metrics to validate, got %v", err)
+	}
+
+	m.Measurements = nil
+	m.Goal.Comparator = "~"
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "invalid comparator for success metric Login Success Rate") {
+		t.Errorf("Expected invalid comparator error, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package prd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// UnmarshalJSON also accepts a plain string, read as the description of a
+// key result without a target, so PRDs written before key results were
+// measurable still load
+func (kr *KeyResult) UnmarshalJSON(data []byte) error {
+	var description string
+	if err := json.Unmarshal(data, &description); err == nil {
+		*kr = KeyResult{Description: description}
+		return nil
+	}
+
+	type keyResult KeyResult
+	var v keyResult
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*kr = KeyResult(v)
+	return nil
+}
+
+// Measurable reports whether the key result has a target to measure
+// progress against
+func (kr KeyResult) Measurable() bool {
+	return kr.Target != nil
+}
+
+// Value returns the current value, or the baseline if there is none
+func (kr KeyResult) Value() float64 {
+	if kr.Current != nil {
+		return *kr.Current
+	}
+	return kr.Baseline
+}
+
+// Progress returns how far the current value has moved from the baseline
+// to the target, from 0 to 100. It is 0 for key results without a target.
+func (kr KeyResult) Progress() float64 {
+	if !kr.Measurable() {
+		return 0
+	}
+
+	target := *kr.Target
+	if target == kr.Baseline {
+		if kr.Value() == target {
+			return 100
+		}
+		return 0
+	}
+	return min(max((kr.Value()-kr.Baseline)*100/(target-kr.Baseline), 0), 100)
+}
+
+// FormatValue formats v in the key result's unit, e.g. "95%" or "10 s"
+func (kr KeyResult) FormatValue(v float64) string {
+	return formatWithUnit(v, kr.Unit)
+}
+
+// ProgressLabel formats the current value against the target with the
+// progress, e.g. "90% → 95% (60%)". It is empty without a target.
+func (kr KeyResult) ProgressLabel() string {
+	if !kr.Measurable() {
+		return ""
+	}
+	return fmt.Sprintf("%s → %s (%.0f%%)", kr.FormatValue(kr.Value()), kr.FormatValue(*kr.Target), kr.Progress())
+}
+
+// LastCheckIn returns the most recent check-in, if any
+func (kr KeyResult) LastCheckIn() (CheckIn, bool) {
+	if len(kr.CheckIns) == 0 {
+		return CheckIn{}, false
+	}
+	return kr.CheckIns[len(kr.CheckIns)-1], true
+}
+
+// RecordCheckIn adds a check-in, keeping check-ins in date order, and
+// updates the current value and confidence from the latest one
+func (kr *KeyResult) RecordCheckIn(c CheckIn) {
+	i, _ := slices.BinarySearchFunc(kr.CheckIns, c, func(a, b CheckIn) int {
+		if cmp := a.Date.Compare(b.Date); cmp != 0 {
+			return cmp
+		}
+		// Check-ins on the same date stay in the order they were recorded
+		return -1
+	})
+	kr.CheckIns = slices.Insert(kr.CheckIns, i, c)
+
+	latest := kr.CheckIns[len(kr.CheckIns)-1]
+	kr.Current = &latest.Value
+	if latest.Confidence != "" {
+		kr.Confidence = latest.Confidence
+	}
+}
+
+// Measurable reports whether any of the objective's key results has a target
+func (o OKR) Measurable() bool {
+	return slices.ContainsFunc(o.KeyResults, KeyResult.Measurable)
+}
+
+// Progress returns the mean progress of the objective's measurable key
+// results, or 0 if none are measurable
+func (o OKR) Progress() float64 {
+	var total float64
+	measured := 0
+	for _, kr := range o.KeyResults {
+		if kr.Measurable() {
+			total += kr.Progress()
+			measured++
+		}
+	}
+	if measured == 0 {
+		return 0
+	}
+	return total / float64(measured)
+}
+
+// KeyResultID returns the ID of the j-th key result of the i-th objective,
+// or "KR<i>.<j>" if it has none
+func (o *Objectives) KeyResultID(i, j int) string {
+	if id := o.OKRs[i].KeyResults[j].ID; id != "" {
+		return id
+	}
+	return fmt.Sprintf("KR%d.%d", i+1, j+1)
+}
+
+// KeyResult finds a key result by its ID, case-insensitively
+func (o *Objectives) KeyResult(id string) (*KeyResult, error) {
+	for i := range o.OKRs {
+		for j := range o.OKRs[i].KeyResults {
+			if strings.EqualFold(o.KeyResultID(i, j), id) {
+				return &o.OKRs[i].KeyResults[j], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("key result '%s' not found", id)
+}
+
+func (o *Objectives) validateOKRs() error {
+	seen := map[string]bool{}
+	for i, okr := range o.OKRs {
+		for j, kr := range okr.KeyResults {
+			id := o.KeyResultID(i, j)
+			if seen[strings.ToLower(id)] {
+				return fmt.Errorf("duplicate key result ID: %s", id)
+			}
+			seen[strings.ToLower(id)] = true
+
+			if kr.Confidence != "" && !kr.Confidence.Valid() {
+				return fmt.Errorf("invalid confidence for key result %s: %s", id, kr.Confidence)
+			}
+			for _, c := range kr.CheckIns {
+				if c.Date.IsZero() {
+					return fmt.Errorf("check-in for key result %s requires a date", id)
+				}
+				if c.Confidence != "" && !c.Confidence.Valid() {
+					return fmt.Errorf("invalid confidence for key result %s check-in on %s: %s", id, c.Date, c.Confidence)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// keyResultCells returns the baseline, target, current value, progress and
+// confidence of a key result as table cells. The measured cells are empty
+// without a target.
+func keyResultCells(kr KeyResult) []string {
+	if !kr.Measurable() {
+		return []string{"", "", "", "", string(kr.Confidence)}
+	}
+	return []string{
+		kr.FormatValue(kr.Baseline),
+		kr.FormatValue(*kr.Target),
+		kr.FormatValue(kr.Value()),
+		fmt.Sprintf("%.0f%%", kr.Progress()),
+		string(kr.Confidence),
+	}
+}
+
+// keyResultBullets formats key results as list items, with their progress
+// when they have a target
+func keyResultBullets(keyResults []KeyResult) []string {
+	bullets := make([]string, len(keyResults))
+	for i, kr := range keyResults {
+		bullets[i] = kr.Description
+		if label := kr.ProgressLabel(); label != "" {
+			bullets[i] += ": " + label
+		}
+	}
+	return bullets
+}
@@ -0,0 +1,124 @@
+package prd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestKeyResultUnmarshalJSON(t *testing.T) {
+	var okr OKR
+	data := `{"objective":"Grow","key_results":[
+		"Launch in two markets",
+		{"id":"REV","description":"Reach $1M ARR","baseline":200000,"target":1000000,"unit":"USD","confidence":"medium"}
+	]}`
+	if err := json.Unmarshal([]byte(data), &okr); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if kr := okr.KeyResults[0]; kr.Description != "Launch in two markets" || kr.Measurable() {
+		t.Errorf("Expected a plain string to load as a key result without a target, got %+v", kr)
+	}
+	if kr := okr.KeyResults[1]; kr.ID != "REV" || *kr.Target != 1000000 || kr.Confidence != ConfidenceMedium {
+		t.Errorf("Unexpected structured key result: %+v", kr)
+	}
+
+	if err := json.Unmarshal([]byte(`{"key_results":[{"description":"x","confidence":"certain"}]}`), &okr); err == nil || !strings.Contains(err.Error(), "invalid confidence 'certain'") {
+		t.Errorf("Expected invalid confidence error, got %v", err)
+	}
+}
+
+func TestKeyResultProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		kr       KeyResult
+		expected float64
+	}{
+		{"no target", KeyResult{Current: floatPtr(5)}, 0},
+		{"no current", KeyResult{Baseline: 10, Target: floatPtr(20)}, 0},
+		{"increase", KeyResult{Baseline: 80, Target: floatPtr(95), Current: floatPtr(89)}, 60},
+		{"decrease", KeyResult{Baseline: 25, Target: floatPtr(10), Current: floatPtr(16)}, 60},
+		{"exceeded", KeyResult{Target: floatPtr(100), Current: floatPtr(120)}, 100},
+		{"regressed", KeyResult{Baseline: 50, Target: floatPtr(60), Current: floatPtr(40)}, 0},
+		{"target is baseline", KeyResult{Baseline: 1, Target: floatPtr(1)}, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.kr.Progress(); got != tt.expected {
+				t.Errorf("Progress() = %.1f, expected %.1f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestObjectiveProgress(t *testing.T) {
+	okr := OKR{KeyResults: []KeyResult{
+		{Description: "Unmeasured"},
+		{Target: floatPtr(10), Current: floatPtr(5)},
+		{Target: floatPtr(10), Current: floatPtr(10)},
+	}}
+	if !okr.Measurable() || okr.Progress() != 75 {
+		t.Errorf("Expected 75%% from the measurable key results, got %.1f", okr.Progress())
+	}
+
+	unmeasured := OKR{KeyResults: []KeyResult{{Description: "Unmeasured"}}}
+	if unmeasured.Measurable() || unmeasured.Progress() != 0 {
+		t.Errorf("Expected no progress without targets, got %.1f", unmeasured.Progress())
+	}
+}
+
+func TestRecordCheckIn(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	kr, err := p.Objectives.KeyResult("kr1.1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A backdated check-in is kept in order without changing the current value
+	kr.RecordCheckIn(CheckIn{Date: MustParseDate("2024-02-15"), Value: 88})
+	if *kr.Current != 90 || kr.CheckIns[1].Value != 88 {
+		t.Errorf("Expected the backdated check-in in the middle, got %+v", kr.CheckIns)
+	}
+
+	kr.RecordCheckIn(CheckIn{Date: MustParseDate("2024-04-01"), Value: 93, Confidence: ConfidenceLow})
+	if *kr.Current != 93 || kr.Confidence != ConfidenceLow || len(kr.CheckIns) != 4 {
+		t.Errorf("Expected the latest check-in to set current and confidence, got %+v", kr)
+	}
+	if got := kr.ProgressLabel(); got != "93% → 95% (85%)" {
+		t.Errorf("Unexpected progress label: %s", got)
+	}
+
+	if _, err := p.Objectives.KeyResult("KR9.9"); err == nil {
+		t.Error("Expected an error for an unknown key result")
+	}
+}
+
+func TestValidateOKRs(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Objectives)
+		want   string
+	}{
+		{"duplicate", func(o *Objectives) { o.OKRs[0].KeyResults[1].ID = "kr1.1" }, "duplicate key result ID: kr1.1"},
+		{"confidence", func(o *Objectives) { o.OKRs[0].KeyResults[0].Confidence = "certain" }, "invalid confidence for key result KR1.1"},
+		{"check-in date", func(o *Objectives) { o.OKRs[0].KeyResults[0].CheckIns[0].Date = Date{} }, "requires a date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := LoadFromFile("example.json")
+			if err != nil {
+				t.Fatalf("Failed to load example PRD: %v", err)
+			}
+			tt.modify(&p.Objectives)
+			if err := p.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
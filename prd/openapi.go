@@ -0,0 +1,660 @@
+package prd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIVersion is the OpenAPI version generated by ToOpenAPI
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPIDocument is the subset of an OpenAPI 3.x document needed to map
+// operations to and from APISpecification entries
+type OpenAPIDocument struct {
+	OpenAPI string                     `json:"openapi" yaml:"openapi"`
+	Info    OpenAPIInfo                `json:"info" yaml:"info"`
+	Servers []OpenAPIServer            `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths   map[string]OpenAPIPathItem `json:"paths" yaml:"paths"`
+}
+
+// OpenAPIServer is an OpenAPI server object
+type OpenAPIServer struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// BasePaths returns the distinct path parts of the server URLs, such as
+// "/api/v1" for "https://example.com/api/v1", or "" without servers
+func (d *OpenAPIDocument) BasePaths() []string {
+	var bases []string
+	for _, server := range d.Servers {
+		base := server.URL
+		if i := strings.Index(base, "://"); i >= 0 {
+			base = base[i+3:]
+			if j := strings.Index(base, "/"); j >= 0 {
+				base = base[j:]
+			} else {
+				base = ""
+			}
+		}
+		base = strings.TrimSuffix(base, "/")
+		if !slices.Contains(bases, base) {
+			bases = append(bases, base)
+		}
+	}
+	if len(bases) == 0 {
+		bases = []string{""}
+	}
+	return bases
+}
+
+// OpenAPIInfo is the OpenAPI info object
+type OpenAPIInfo struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// OpenAPIPathItem holds the operations of a single path
+type OpenAPIPathItem struct {
+	Get     *OpenAPIOperation `json:"get,omitempty" yaml:"get,omitempty"`
+	Put     *OpenAPIOperation `json:"put,omitempty" yaml:"put,omitempty"`
+	Post    *OpenAPIOperation `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete  *OpenAPIOperation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options *OpenAPIOperation `json:"options,omitempty" yaml:"options,omitempty"`
+	Head    *OpenAPIOperation `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch   *OpenAPIOperation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Trace   *OpenAPIOperation `json:"trace,omitempty" yaml:"trace,omitempty"`
+}
+
+// OpenAPIOperation is an OpenAPI operation object
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses,omitempty" yaml:"responses,omitempty"`
+}
+
+// OpenAPIParameter is an OpenAPI parameter object
+type OpenAPIParameter struct {
+	Name     string         `json:"name" yaml:"name"`
+	In       string         `json:"in" yaml:"in"`
+	Required bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   map[string]any `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody is an OpenAPI request body object
+type OpenAPIRequestBody struct {
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// OpenAPIResponse is an OpenAPI response object
+type OpenAPIResponse struct {
+	Description string                      `json:"description" yaml:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// OpenAPIMediaType is an OpenAPI media type object
+type OpenAPIMediaType struct {
+	Schema  map[string]any `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example any            `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// Operations returns the path item's operations keyed by upper-case method
+func (pi OpenAPIPathItem) Operations() map[string]*OpenAPIOperation {
+	ops := map[string]*OpenAPIOperation{}
+	for method, op := range map[string]*OpenAPIOperation{
+		"GET": pi.Get, "PUT": pi.Put, "POST": pi.Post, "DELETE": pi.Delete,
+		"OPTIONS": pi.Options, "HEAD": pi.Head, "PATCH": pi.Patch, "TRACE": pi.Trace,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+func (pi *OpenAPIPathItem) setOperation(method string, op *OpenAPIOperation) error {
+	switch method {
+	case "GET":
+		pi.Get = op
+	case "PUT":
+		pi.Put = op
+	case "POST":
+		pi.Post = op
+	case "DELETE":
+		pi.Delete = op
+	case "OPTIONS":
+		pi.Options = op
+	case "HEAD":
+		pi.Head = op
+	case "PATCH":
+		pi.Patch = op
+	case "TRACE":
+		pi.Trace = op
+	default:
+		return fmt.Errorf("unsupported HTTP method '%s'", method)
+	}
+	return nil
+}
+
+// LoadOpenAPI loads an OpenAPI document from a JSON or YAML file
+func LoadOpenAPI(filename string) (*OpenAPIDocument, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	var doc OpenAPIDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version '%s': expected 3.x", doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// ToOpenAPI generates an OpenAPI skeleton from the PRD's API specifications.
+// Request and response formats containing a JSON example become schema
+// stubs inferred from that example; other formats are kept as descriptions.
+func (p *PRD) ToOpenAPI() (*OpenAPIDocument, error) {
+	version := p.Version
+	if version == "" {
+		version = "0.1.0"
+	}
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       p.Title,
+			Version:     version,
+			Description: p.Overview.SolutionSummary,
+		},
+		Paths: map[string]OpenAPIPathItem{},
+	}
+
+	if p.TechnicalSpecifications == nil {
+		return doc, nil
+	}
+
+	for _, api := range p.TechnicalSpecifications.APISpecifications {
+		if api.Endpoint == "" {
+			continue
+		}
+		method, path := NormalizeAPIOperation(api.Method, api.Endpoint)
+
+		op := &OpenAPIOperation{
+			OperationID: openAPIOperationID(method, path),
+			Summary:     api.Description,
+			Parameters:  openAPIPathParameters(path),
+			Responses: map[string]OpenAPIResponse{
+				"200": {
+					Description: firstNonEmpty(api.ResponseFormat, "Successful response"),
+					Content:     openAPIContent(api.ResponseFormat),
+				},
+			},
+		}
+		if api.RequestFormat != "" {
+			switch method {
+			case "GET", "HEAD", "DELETE":
+				op.Description = "Request: " + api.RequestFormat
+			default:
+				op.RequestBody = &OpenAPIRequestBody{
+					Description: api.RequestFormat,
+					Required:    true,
+					Content:     openAPIContent(api.RequestFormat),
+				}
+			}
+		}
+
+		item := doc.Paths[path]
+		if _, exists := item.Operations()[method]; exists {
+			return nil, fmt.Errorf("duplicate API specification for %s %s", method, path)
+		}
+		if err := item.setOperation(method, op); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", api.Method, api.Endpoint, err)
+		}
+		doc.Paths[path] = item
+	}
+
+	return doc, nil
+}
+
+// Marshal encodes the document as YAML when yamlOutput is true,
+// otherwise as indented JSON
+func (d *OpenAPIDocument) Marshal(yamlOutput bool) ([]byte, error) {
+	if yamlOutput {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(d); err != nil {
+			return nil, fmt.Errorf("failed to marshal OpenAPI to YAML: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI to JSON: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// APISpecifications converts the document's operations to API
+// specifications, sorted by endpoint and method. Endpoints include the base
+// path of the first server.
+func (d *OpenAPIDocument) APISpecifications() []APISpecification {
+	base := d.BasePaths()[0]
+	var specs []APISpecification
+	for path, item := range d.Paths {
+		for method, op := range item.Operations() {
+			spec := APISpecification{
+				Endpoint:    base + path,
+				Method:      method,
+				Description: firstNonEmpty(op.Summary, op.Description),
+			}
+			if op.RequestBody != nil {
+				spec.RequestFormat = openAPIFormat(op.RequestBody.Description, op.RequestBody.Content)
+			}
+			if code, resp, ok := successResponse(op.Responses); ok {
+				desc := resp.Description
+				if code != "200" && desc == "" {
+					desc = code + " response"
+				}
+				spec.ResponseFormat = openAPIFormat(desc, resp.Content)
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		if specs[i].Endpoint != specs[j].Endpoint {
+			return specs[i].Endpoint < specs[j].Endpoint
+		}
+		return specs[i].Method < specs[j].Method
+	})
+	return specs
+}
+
+// MergeAPISpecifications replaces API specifications with the same method
+// and endpoint and appends new ones. It returns the number added and updated.
+func (p *PRD) MergeAPISpecifications(specs []APISpecification) (added, updated int) {
+	if p.TechnicalSpecifications == nil {
+		p.TechnicalSpecifications = &TechnicalSpecifications{}
+	}
+
+	index := map[string]int{}
+	for i, api := range p.TechnicalSpecifications.APISpecifications {
+		method, path := NormalizeAPIOperation(api.Method, api.Endpoint)
+		index[method+" "+path] = i
+	}
+
+	for _, spec := range specs {
+		method, path := NormalizeAPIOperation(spec.Method, spec.Endpoint)
+		if i, ok := index[method+" "+path]; ok {
+			p.TechnicalSpecifications.APISpecifications[i] = spec
+			updated++
+			continue
+		}
+		index[method+" "+path] = len(p.TechnicalSpecifications.APISpecifications)
+		p.TechnicalSpecifications.APISpecifications = append(p.TechnicalSpecifications.APISpecifications, spec)
+		added++
+	}
+	return added, updated
+}
+
+var expressPathParam = regexp.MustCompile(`/:([A-Za-z_][A-Za-z0-9_]*)`)
+
+// NormalizeAPIOperation upper-cases the method (defaulting to GET), strips
+// query strings and trailing slashes, and converts ":id" path parameters to
+// OpenAPI "{id}" form
+func NormalizeAPIOperation(method, endpoint string) (string, string) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = "GET"
+	}
+
+	path := strings.TrimSpace(endpoint)
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	path = expressPathParam.ReplaceAllString(path, "/{$1}")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return method, path
+}
+
+var openAPIPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+func openAPIPathParameters(path string) []OpenAPIParameter {
+	var params []OpenAPIParameter
+	for _, m := range openAPIPathParam.FindAllStringSubmatch(path, -1) {
+		params = append(params, OpenAPIParameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   map[string]any{"type": "string"},
+		})
+	}
+	return params
+}
+
+func openAPIOperationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	upper := true
+	for _, r := range path {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			b.WriteRune(r)
+		} else {
+			upper = true
+		}
+	}
+	return b.String()
+}
+
+// openAPIContent returns a JSON media type with a schema inferred from a JSON
+// example embedded in the format text, or a described object stub
+func openAPIContent(format string) map[string]OpenAPIMediaType {
+	if format == "" {
+		return nil
+	}
+	if example, ok := extractJSONExample(format); ok {
+		return map[string]OpenAPIMediaType{
+			"application/json": {Schema: SchemaFromExample(example), Example: example},
+		}
+	}
+	return map[string]OpenAPIMediaType{
+		"application/json": {Schema: map[string]any{"type": "object", "description": format}},
+	}
+}
+
+// openAPIFormat describes a request or response in APISpecification form,
+// preferring an example so JSON round-trips through ToOpenAPI
+func openAPIFormat(description string, content map[string]OpenAPIMediaType) string {
+	media, ok := content["application/json"]
+	if !ok {
+		return description
+	}
+	if media.Example != nil {
+		if data, err := json.Marshal(normalizeYAML(media.Example)); err == nil {
+			return string(data)
+		}
+	}
+	if description == "" {
+		if desc, ok := media.Schema["description"].(string); ok {
+			return desc
+		}
+	}
+	return description
+}
+
+func successResponse(responses map[string]OpenAPIResponse) (string, OpenAPIResponse, bool) {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return "", OpenAPIResponse{}, false
+	}
+	sort.Strings(codes)
+	return codes[0], responses[codes[0]], true
+}
+
+// extractJSONExample decodes the first JSON object or array in s
+func extractJSONExample(s string) (any, bool) {
+	i := strings.IndexAny(s, "{[")
+	if i < 0 {
+		return nil, false
+	}
+	dec := json.NewDecoder(strings.NewReader(s[i:]))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	return convertJSONNumbers(v), true
+}
+
+// convertJSONNumbers replaces json.Number values with int64 or float64 so
+// integers stay distinguishable and examples encode as numbers in YAML
+func convertJSONNumbers(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			val[k] = convertJSONNumbers(child)
+		}
+	case []any:
+		for i, child := range val {
+			val[i] = convertJSONNumbers(child)
+		}
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return n
+		}
+		f, _ := val.Float64()
+		return f
+	}
+	return v
+}
+
+// SchemaFromExample infers a JSON Schema stub from a decoded JSON example
+func SchemaFromExample(v any) map[string]any {
+	switch val := v.(type) {
+	case map[string]any:
+		props := map[string]any{}
+		required := make([]string, 0, len(val))
+		for k, child := range val {
+			props[k] = SchemaFromExample(child)
+			required = append(required, k)
+		}
+		sort.Strings(required)
+		schema := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	case []any:
+		schema := map[string]any{"type": "array"}
+		if len(val) > 0 {
+			schema["items"] = SchemaFromExample(val[0])
+		}
+		return schema
+	case float64:
+		return map[string]any{"type": "number"}
+	case int, int64:
+		return map[string]any{"type": "integer"}
+	case string:
+		return map[string]any{"type": "string"}
+	case bool:
+		return map[string]any{"type": "boolean"}
+	default:
+		return map[string]any{"type": "null"}
+	}
+}
+
+// normalizeYAML converts map[any]any values produced by YAML decoding so
+// they can be JSON encoded
+func normalizeYAML(v any) any {
+	switch val := v.(type) {
+	case map[any]any:
+		m := map[string]any{}
+		for k, child := range val {
+			m[fmt.Sprint(k)] = normalizeYAML(child)
+		}
+		return m
+	case map[string]any:
+		for k, child := range val {
+			val[k] = normalizeYAML(child)
+		}
+		return val
+	case []any:
+		for i, child := range val {
+			val[i] = normalizeYAML(child)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// OpenAPIOperationRef identifies an API operation and where it is declared
+type OpenAPIOperationRef struct {
+	Method      string
+	Endpoint    string
+	Description string
+	// PRDID is set for operations declared in a PRD
+	PRDID string
+}
+
+// OpenAPIMismatch is an operation whose PRD and spec descriptions differ
+type OpenAPIMismatch struct {
+	Method   string
+	Endpoint string
+	PRDID    string
+	PRD      string
+	Spec     string
+}
+
+// OpenAPIReport is the result of checking PRDs against an OpenAPI document
+type OpenAPIReport struct {
+	// Missing lists PRD operations that are not in the spec
+	Missing []OpenAPIOperationRef
+	// Uncovered lists spec operations that no PRD declares
+	Uncovered []OpenAPIOperationRef
+	// Mismatches lists operations whose descriptions differ
+	Mismatches []OpenAPIMismatch
+}
+
+// Valid reports whether every PRD operation exists in the spec
+func (r OpenAPIReport) Valid() bool {
+	return len(r.Missing) == 0
+}
+
+// Check compares the API specifications of one or more PRDs with the
+// document. Path parameters match regardless of name, so /users/:id matches
+// /users/{userId}. PRD endpoints may include a server base path, so
+// /api/v1/users/:id matches /users/{id} on a server at /api/v1.
+func (d *OpenAPIDocument) Check(docs ...*PRD) OpenAPIReport {
+	var ops []OpenAPIOperationRef
+	spec := map[string]int{}
+	bases := d.BasePaths()
+	for path, item := range d.Paths {
+		for method, op := range item.Operations() {
+			_, p := NormalizeAPIOperation(method, path)
+			spec[openAPIMatchKey(method, p)] = len(ops)
+			for _, base := range bases {
+				spec[openAPIMatchKey(method, base+p)] = len(ops)
+			}
+			ops = append(ops, OpenAPIOperationRef{
+				Method:      method,
+				Endpoint:    bases[0] + path,
+				Description: firstNonEmpty(op.Summary, op.Description),
+			})
+		}
+	}
+
+	var report OpenAPIReport
+	covered := map[int]bool{}
+	for _, p := range docs {
+		if p.TechnicalSpecifications == nil {
+			continue
+		}
+		for _, api := range p.TechnicalSpecifications.APISpecifications {
+			if api.Endpoint == "" {
+				continue
+			}
+			method, path := NormalizeAPIOperation(api.Method, api.Endpoint)
+			i, ok := spec[openAPIMatchKey(method, path)]
+			if !ok {
+				report.Missing = append(report.Missing, OpenAPIOperationRef{
+					Method:      method,
+					Endpoint:    api.Endpoint,
+					Description: api.Description,
+					PRDID:       p.ID,
+				})
+				continue
+			}
+			op := ops[i]
+			covered[i] = true
+			if !descriptionsMatch(api.Description, op.Description) {
+				report.Mismatches = append(report.Mismatches, OpenAPIMismatch{
+					Method:   method,
+					Endpoint: op.Endpoint,
+					PRDID:    p.ID,
+					PRD:      api.Description,
+					Spec:     op.Description,
+				})
+			}
+		}
+	}
+
+	for i, op := range ops {
+		if !covered[i] {
+			report.Uncovered = append(report.Uncovered, op)
+		}
+	}
+	sort.Slice(report.Uncovered, func(i, j int) bool {
+		a, b := report.Uncovered[i], report.Uncovered[j]
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		return a.Method < b.Method
+	})
+
+	return report
+}
+
+func openAPIMatchKey(method, path string) string {
+	return method + " " + openAPIPathParam.ReplaceAllString(path, "{}")
+}
+
+// descriptionsMatch compares descriptions ignoring case, punctuation and
+// whitespace, treating one containing the other as a match. Empty
+// descriptions always match.
+func descriptionsMatch(a, b string) bool {
+	a, b = normalizeDescription(a), normalizeDescription(b)
+	if a == "" || b == "" {
+		return true
+	}
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+func normalizeDescription(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,244 @@
+package prd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAPIOperation(t *testing.T) {
+	tests := []struct {
+		method, endpoint string
+		wantMethod       string
+		wantPath         string
+	}{
+		{"post", "/api/auth/login", "POST", "/api/auth/login"},
+		{"", "api/users/", "GET", "/api/users"},
+		{"GET", "/users/:id/posts/:postId?limit=10", "GET", "/users/{id}/posts/{postId}"},
+		{"DELETE", "/", "DELETE", "/"},
+	}
+
+	for _, tt := range tests {
+		method, path := NormalizeAPIOperation(tt.method, tt.endpoint)
+		if method != tt.wantMethod || path != tt.wantPath {
+			t.Errorf("NormalizeAPIOperation(%q, %q) = %q, %q, expected %q, %q",
+				tt.method, tt.endpoint, method, path, tt.wantMethod, tt.wantPath)
+		}
+	}
+}
+
+func TestSchemaFromExample(t *testing.T) {
+	example, ok := extractJSONExample(`JSON like {"id": 7, "score": 0.5, "tags": ["a"], "active": true, "owner": null}`)
+	if !ok {
+		t.Fatal("Expected JSON example to be extracted")
+	}
+
+	got := SchemaFromExample(example)
+	expected := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":     map[string]any{"type": "integer"},
+			"score":  map[string]any{"type": "number"},
+			"tags":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"active": map[string]any{"type": "boolean"},
+			"owner":  map[string]any{"type": "null"},
+		},
+		"required": []string{"active", "id", "owner", "score", "tags"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SchemaFromExample() = %#v", got)
+	}
+
+	if _, ok := extractJSONExample("JSON with JWT token"); ok {
+		t.Error("Expected prose format not to parse as JSON")
+	}
+}
+
+func TestToOpenAPI(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	p.TechnicalSpecifications.APISpecifications = append(p.TechnicalSpecifications.APISpecifications, APISpecification{
+		Endpoint:       "/api/users/:id",
+		Method:         "get",
+		Description:    "Fetch a user",
+		ResponseFormat: `{"id": "u1", "email": "a@example.com"}`,
+	})
+
+	doc, err := p.ToOpenAPI()
+	if err != nil {
+		t.Fatalf("ToOpenAPI failed: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" || doc.Info.Title != p.Title {
+		t.Errorf("Unexpected header: %+v", doc)
+	}
+
+	login := doc.Paths["/api/auth/login"].Post
+	if login == nil || login.OperationID != "postApiAuthLogin" {
+		t.Fatalf("Expected POST /api/auth/login operation, got %+v", login)
+	}
+	if login.RequestBody.Content["application/json"].Schema["description"] != "JSON with email/password or OAuth token" {
+		t.Errorf("Expected prose request format kept as description, got %+v", login.RequestBody)
+	}
+
+	user := doc.Paths["/api/users/{id}"].Get
+	if user == nil {
+		t.Fatal("Expected GET /api/users/{id} operation")
+	}
+	if len(user.Parameters) != 1 || user.Parameters[0].Name != "id" || user.Parameters[0].In != "path" {
+		t.Errorf("Expected id path parameter, got %+v", user.Parameters)
+	}
+	schema := user.Responses["200"].Content["application/json"].Schema
+	if props, ok := schema["properties"].(map[string]any); !ok || len(props) != 2 {
+		t.Errorf("Expected schema inferred from response example, got %+v", schema)
+	}
+
+	p.TechnicalSpecifications.APISpecifications = append(p.TechnicalSpecifications.APISpecifications,
+		APISpecification{Endpoint: "/api/auth/login/", Method: "POST"})
+	if _, err := p.ToOpenAPI(); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("Expected duplicate operation error, got %v", err)
+	}
+}
+
+func TestOpenAPIRoundTrip(t *testing.T) {
+	p := &PRD{
+		Title: "Orders",
+		TechnicalSpecifications: &TechnicalSpecifications{
+			APISpecifications: []APISpecification{
+				{Endpoint: "/orders", Method: "POST", Description: "Create order", RequestFormat: `{"qty":2,"sku":"A1"}`, ResponseFormat: `{"id":"o1"}`},
+				{Endpoint: "/orders/{id}", Method: "GET", Description: "Get order", ResponseFormat: "Order details"},
+			},
+		},
+	}
+
+	doc, err := p.ToOpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, yamlOutput := range []bool{false, true} {
+		data, err := doc.Marshal(yamlOutput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filename := filepath.Join(t.TempDir(), "openapi.json")
+		if yamlOutput {
+			filename = strings.TrimSuffix(filename, ".json") + ".yaml"
+		}
+		if err := os.WriteFile(filename, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded, err := LoadOpenAPI(filename)
+		if err != nil {
+			t.Fatalf("LoadOpenAPI(%s) failed: %v", filename, err)
+		}
+		specs := loaded.APISpecifications()
+		if !reflect.DeepEqual(specs, p.TechnicalSpecifications.APISpecifications) {
+			got, _ := json.Marshal(specs)
+			t.Errorf("Round trip (yaml=%t) mismatch: %s", yamlOutput, got)
+		}
+	}
+}
+
+func TestMergeAPISpecifications(t *testing.T) {
+	p := &PRD{TechnicalSpecifications: &TechnicalSpecifications{
+		APISpecifications: []APISpecification{
+			{Endpoint: "/users/:id", Method: "get", Description: "old"},
+			{Endpoint: "/health", Method: "GET"},
+		},
+	}}
+
+	added, updated := p.MergeAPISpecifications([]APISpecification{
+		{Endpoint: "/users/{id}", Method: "GET", Description: "new"},
+		{Endpoint: "/users", Method: "POST"},
+	})
+	if added != 1 || updated != 1 {
+		t.Errorf("Expected 1 added and 1 updated, got %d and %d", added, updated)
+	}
+	specs := p.TechnicalSpecifications.APISpecifications
+	if len(specs) != 3 || specs[0].Description != "new" || specs[2].Endpoint != "/users" {
+		t.Errorf("Unexpected merged specifications: %+v", specs)
+	}
+}
+
+func TestLoadOpenAPIRejectsSwagger(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "swagger.yaml")
+	if err := os.WriteFile(filename, []byte("swagger: \"2.0\"\npaths: {}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOpenAPI(filename); err == nil {
+		t.Error("Expected Swagger 2.0 document to be rejected")
+	}
+}
+
+func TestOpenAPICheck(t *testing.T) {
+	doc := &OpenAPIDocument{
+		OpenAPI: "3.0.3",
+		Paths: map[string]OpenAPIPathItem{
+			"/users/{userId}": {
+				Get:    &OpenAPIOperation{Summary: "Get a user."},
+				Delete: &OpenAPIOperation{Summary: "Remove user"},
+			},
+			"/orders": {
+				Post: &OpenAPIOperation{Description: "Create an order"},
+			},
+		},
+	}
+	p := &PRD{ID: "PRD-001", TechnicalSpecifications: &TechnicalSpecifications{
+		APISpecifications: []APISpecification{
+			{Endpoint: "/users/:id", Method: "GET", Description: "get a user"},
+			{Endpoint: "/orders", Method: "POST", Description: "Cancel an order"},
+			{Endpoint: "/orders/{id}", Method: "PATCH", Description: "Update order"},
+		},
+	}}
+
+	report := doc.Check(p)
+
+	if report.Valid() || len(report.Missing) != 1 || report.Missing[0].Endpoint != "/orders/{id}" || report.Missing[0].PRDID != "PRD-001" {
+		t.Errorf("Expected PATCH /orders/{id} missing, got %+v", report.Missing)
+	}
+	if len(report.Uncovered) != 1 || report.Uncovered[0].Method != "DELETE" {
+		t.Errorf("Expected DELETE /users/{userId} uncovered, got %+v", report.Uncovered)
+	}
+	if len(report.Mismatches) != 1 || report.Mismatches[0].Spec != "Create an order" {
+		t.Errorf("Expected POST /orders description mismatch, got %+v", report.Mismatches)
+	}
+}
+
+func TestOpenAPIServerBasePath(t *testing.T) {
+	doc := &OpenAPIDocument{
+		OpenAPI: "3.0.3",
+		Servers: []OpenAPIServer{{URL: "https://api.example.com/api/v1/"}, {URL: "/api/v1"}},
+		Paths: map[string]OpenAPIPathItem{
+			"/users/{id}": {Get: &OpenAPIOperation{Summary: "Get a user"}},
+			"/orders":     {Post: &OpenAPIOperation{Summary: "Create an order"}},
+		},
+	}
+	if bases := doc.BasePaths(); len(bases) != 1 || bases[0] != "/api/v1" {
+		t.Errorf("Unexpected base paths: %v", bases)
+	}
+
+	// PRD endpoints may include the base path or leave it out
+	p := &PRD{ID: "PRD-001", TechnicalSpecifications: &TechnicalSpecifications{
+		APISpecifications: []APISpecification{
+			{Endpoint: "/api/v1/users/:id", Method: "GET", Description: "Get a user"},
+			{Endpoint: "/orders", Method: "POST", Description: "Create an order"},
+		},
+	}}
+	if report := doc.Check(p); !report.Valid() || len(report.Uncovered) != 0 {
+		t.Errorf("Expected endpoints to match under the base path, got %+v", report)
+	}
+
+	specs := doc.APISpecifications()
+	if len(specs) != 2 || specs[0].Endpoint != "/api/v1/orders" || specs[1].Endpoint != "/api/v1/users/{id}" {
+		t.Errorf("Expected imported endpoints to include the base path, got %+v", specs)
+	}
+	if bases := (&OpenAPIDocument{}).BasePaths(); len(bases) != 1 || bases[0] != "" {
+		t.Errorf("Expected an empty base path without servers, got %v", bases)
+	}
+}
@@ -0,0 +1,428 @@
+package prd
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PortfolioSortKeys are the fields a portfolio can be sorted by
+var PortfolioSortKeys = []string{"path", "id", "title", "status", "priority", "owner", "team", "launch", "updated"}
+
+// PortfolioOptions configures LoadPortfolio
+type PortfolioOptions struct {
+	// Recursive descends into subdirectories
+	Recursive bool
+	// SkipDirs are directory names not descended into
+	SkipDirs []string
+}
+
+// DefaultPortfolioOptions returns the default portfolio options
+func DefaultPortfolioOptions() PortfolioOptions {
+	return PortfolioOptions{
+		Recursive: true,
+		SkipDirs:  []string{".git", ".hg", ".svn", ".prd", "node_modules", "vendor", "dist", "build"},
+	}
+}
+
+// Portfolio is the set of PRDs found under a directory
+type Portfolio struct {
+	Items []PortfolioItem `json:"prds"`
+	// Errors are JSON files that look like PRDs but could not be loaded
+	Errors []PortfolioError `json:"errors,omitempty"`
+	// Ignored are JSON files that are not PRDs, such as data or mapping files
+	Ignored []string `json:"ignored,omitempty"`
+}
+
+// PortfolioItem is one PRD in a portfolio
+type PortfolioItem struct {
+	// Path is relative to the portfolio root, with forward slashes
+	Path        string     `json:"path"`
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Status      Status     `json:"status"`
+	Priority    Priority   `json:"priority"`
+	Owner       string     `json:"owner"`
+	Team        string     `json:"team,omitempty"`
+	LaunchDate  Date       `json:"launch_date,omitzero"`
+	LastUpdated *time.Time `json:"last_updated,omitempty"`
+	PRD         *PRD       `json:"-"`
+}
+
+// PortfolioError is a file that could not be loaded as a PRD
+type PortfolioError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// PortfolioSummary counts the PRDs of a portfolio
+type PortfolioSummary struct {
+	Total      int              `json:"total"`
+	ByStatus   map[Status]int   `json:"by_status"`
+	ByPriority map[Priority]int `json:"by_priority"`
+	// ByTeam counts PRDs by owner team, case-insensitively; PRDs without a
+	// team are counted under ""
+	ByTeam map[string]int `json:"by_team"`
+	// UpcomingLaunches are the PRDs launching within the summary window, in
+	// launch order
+	UpcomingLaunches []PortfolioItem `json:"upcoming_launches"`
+}
+
+// PortfolioFilter selects PRDs by field. Empty fields match every PRD.
+type PortfolioFilter struct {
+	Statuses   []Status
+	Priorities []Priority
+	// Teams match owner teams case-insensitively
+	Teams []string
+	// Owner matches part of the owner's name or email, case-insensitively
+	Owner string
+}
+
+// LoadPortfolio loads every PRD in a directory, or a single PRD file. JSON
+// files that are not objects with an id or title are ignored as not being
+// PRDs; any other file that fails to load is reported in Errors.
+func LoadPortfolio(root string, opts PortfolioOptions) (*Portfolio, error) {
+	pf := &Portfolio{}
+	err := walkPRDFiles(root, opts, func(rel, path string) {
+		pf.add(rel, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pf, nil
+}
+
+// walkPRDFiles calls fn for each JSON file under root, or for root itself
+// if it is a file, with its slash-separated path relative to root
+func walkPRDFiles(root string, opts PortfolioOptions, fn func(rel, path string)) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		fn(filepath.Base(root), root)
+		return nil
+	}
+
+	skip := map[string]bool{}
+	for _, d := range opts.SkipDirs {
+		skip[d] = true
+	}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (!opts.Recursive || skip[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !strings.EqualFold(filepath.Ext(path), ".json") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+		fn(filepath.ToSlash(rel), path)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return nil
+}
+
+// add loads the PRD at path, recording it as an item, an error or ignored
+func (pf *Portfolio) add(rel, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		pf.Errors = append(pf.Errors, PortfolioError{Path: rel, Error: err.Error()})
+		return
+	}
+
+	p, err := parsePRDFile(data)
+	switch {
+	case err != nil:
+		pf.Errors = append(pf.Errors, PortfolioError{Path: rel, Error: err.Error()})
+	case p == nil:
+		pf.Ignored = append(pf.Ignored, rel)
+	default:
+		pf.Items = append(pf.Items, newPortfolioItem(rel, p))
+	}
+}
+
+// parsePRDFile parses a JSON file found in a portfolio. It returns nil
+// without an error for JSON that is not a PRD: values that are not objects,
+// such as a measurement series, and objects with neither an id nor a title.
+func parsePRDFile(data []byte) (*PRD, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if fields["id"] == nil && fields["title"] == nil {
+		return nil, nil
+	}
+	return parsePRD(data)
+}
+
+func newPortfolioItem(path string, p *PRD) PortfolioItem {
+	item := PortfolioItem{
+		Path:        path,
+		ID:          p.ID,
+		Title:       p.Title,
+		Status:      p.Status,
+		Priority:    p.Priority,
+		Owner:       p.Owner.Name,
+		Team:        p.Owner.Team,
+		LastUpdated: p.LastUpdated,
+		PRD:         p,
+	}
+	if p.Timeline != nil {
+		item.LaunchDate = p.Timeline.LaunchDate
+	}
+	return item
+}
+
+// Filter returns the portfolio's PRDs that match f, keeping its errors and
+// ignored files
+func (pf *Portfolio) Filter(f PortfolioFilter) *Portfolio {
+	filtered := &Portfolio{Errors: pf.Errors, Ignored: pf.Ignored}
+	owner := strings.ToLower(f.Owner)
+	for _, item := range pf.Items {
+		switch {
+		case len(f.Statuses) > 0 && !slices.Contains(f.Statuses, item.Status),
+			len(f.Priorities) > 0 && !slices.Contains(f.Priorities, item.Priority),
+			len(f.Teams) > 0 && !slices.ContainsFunc(f.Teams, func(team string) bool { return strings.EqualFold(team, item.Team) }),
+			owner != "" && !strings.Contains(strings.ToLower(item.Owner), owner) &&
+				!strings.Contains(strings.ToLower(item.PRD.Owner.Email), owner):
+			continue
+		}
+		filtered.Items = append(filtered.Items, item)
+	}
+	return filtered
+}
+
+// Sort orders the portfolio's PRDs by one of PortfolioSortKeys. Statuses
+// sort in lifecycle order and priorities from critical to low. PRDs without
+// a launch date or last update sort last, even when reversed. Ties are
+// ordered by path.
+func (pf *Portfolio) Sort(key string, reverse bool) error {
+	var compare func(a, b PortfolioItem) int
+	missing := func(PortfolioItem) bool { return false }
+	switch key {
+	case "", "path":
+		compare = func(a, b PortfolioItem) int { return cmp.Compare(a.Path, b.Path) }
+	case "id":
+		compare = func(a, b PortfolioItem) int { return cmp.Compare(a.ID, b.ID) }
+	case "title":
+		compare = func(a, b PortfolioItem) int { return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) }
+	case "status":
+		order := Status("").Values()
+		compare = func(a, b PortfolioItem) int {
+			return cmp.Compare(enumIndex(a.Status, order), enumIndex(b.Status, order))
+		}
+	case "priority":
+		order := Priority("").Values()
+		compare = func(a, b PortfolioItem) int {
+			return cmp.Compare(enumIndex(a.Priority, order), enumIndex(b.Priority, order))
+		}
+	case "owner":
+		compare = func(a, b PortfolioItem) int { return cmp.Compare(strings.ToLower(a.Owner), strings.ToLower(b.Owner)) }
+	case "team":
+		compare = func(a, b PortfolioItem) int { return cmp.Compare(strings.ToLower(a.Team), strings.ToLower(b.Team)) }
+	case "launch":
+		missing = func(item PortfolioItem) bool { return item.LaunchDate.IsZero() }
+		compare = func(a, b PortfolioItem) int { return a.LaunchDate.Compare(b.LaunchDate) }
+	case "updated":
+		missing = func(item PortfolioItem) bool { return item.LastUpdated == nil }
+		compare = func(a, b PortfolioItem) int { return a.LastUpdated.Compare(*b.LastUpdated) }
+	default:
+		return fmt.Errorf("invalid sort key '%s': expected one of %s", key, strings.Join(PortfolioSortKeys, ", "))
+	}
+
+	slices.SortStableFunc(pf.Items, func(a, b PortfolioItem) int {
+		if missing(a) || missing(b) {
+			if c := cmp.Compare(boolRank(missing(a)), boolRank(missing(b))); c != 0 {
+				return c
+			}
+		} else if c := compare(a, b); c != 0 {
+			if reverse {
+				return -c
+			}
+			return c
+		}
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return nil
+}
+
+// Summary counts the portfolio's PRDs and lists those launching between
+// now and the given number of days from now
+func (pf *Portfolio) Summary(now time.Time, days int) PortfolioSummary {
+	summary := PortfolioSummary{
+		Total:      len(pf.Items),
+		ByStatus:   map[Status]int{},
+		ByPriority: map[Priority]int{},
+		ByTeam:     map[string]int{},
+	}
+
+	today := DateOf(now)
+	horizon := DateOf(now.AddDate(0, 0, days))
+	// Teams are grouped case-insensitively under their first spelling
+	teams := map[string]string{}
+	for _, item := range pf.Items {
+		summary.ByStatus[item.Status]++
+		summary.ByPriority[item.Priority]++
+		team, ok := teams[strings.ToLower(item.Team)]
+		if !ok {
+			team = item.Team
+			teams[strings.ToLower(item.Team)] = team
+		}
+		summary.ByTeam[team]++
+		if !item.LaunchDate.IsZero() && !item.LaunchDate.Before(today) && !item.LaunchDate.After(horizon) {
+			summary.UpcomingLaunches = append(summary.UpcomingLaunches, item)
+		}
+	}
+	slices.SortStableFunc(summary.UpcomingLaunches, func(a, b PortfolioItem) int {
+		return a.LaunchDate.Compare(b.LaunchDate)
+	})
+	return summary
+}
+
+// Teams returns the summary's teams by descending count, then name, with
+// PRDs without a team last
+func (s PortfolioSummary) Teams() []string {
+	teams := make([]string, 0, len(s.ByTeam))
+	for team := range s.ByTeam {
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if (teams[i] == "") != (teams[j] == "") {
+			return teams[j] == ""
+		}
+		if s.ByTeam[teams[i]] != s.ByTeam[teams[j]] {
+			return s.ByTeam[teams[i]] > s.ByTeam[teams[j]]
+		}
+		return teams[i] < teams[j]
+	})
+	return teams
+}
+
+// portfolioColumns are the columns of portfolio CSV and Markdown tables
+var portfolioColumns = []string{"Path", "ID", "Title", "Status", "Priority", "Owner", "Team", "Launch", "Last Updated"}
+
+func (item PortfolioItem) row() []string {
+	lastUpdated := ""
+	if item.LastUpdated != nil {
+		lastUpdated = item.LastUpdated.Format("2006-01-02 15:04")
+	}
+	return []string{item.Path, item.ID, item.Title, string(item.Status), string(item.Priority), item.Owner, item.Team, item.LaunchDate.String(), lastUpdated}
+}
+
+// WritePortfolioCSV writes one row per PRD in the portfolio
+func WritePortfolioCSV(w io.Writer, pf *Portfolio) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(portfolioColumns); err != nil {
+		return err
+	}
+	for _, item := range pf.Items {
+		if err := cw.Write(item.row()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// PortfolioMarkdown renders the portfolio as a Markdown report: a table of
+// PRDs, the summary and any files that could not be loaded
+func PortfolioMarkdown(pf *Portfolio, summary PortfolioSummary, days int) string {
+	var b bytes.Buffer
+	b.WriteString("# PRD Portfolio\n\n")
+
+	b.WriteString("| " + strings.Join(portfolioColumns, " | ") + " |\n")
+	b.WriteString(strings.Repeat("|---", len(portfolioColumns)) + "|\n")
+	for _, item := range pf.Items {
+		cells := item.row()
+		for i, cell := range cells {
+			cells[i] = markdownTableCell(cell)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	fmt.Fprintf(&b, "\n## Summary\n\n**Total:** %d PRDs\n\n", summary.Total)
+	b.WriteString("**By status:** " + countList(Status("").Values(), summary.ByStatus) + "\n\n")
+	b.WriteString("**By priority:** " + countList(Priority("").Values(), summary.ByPriority) + "\n\n")
+	var teams []string
+	for _, team := range summary.Teams() {
+		teams = append(teams, fmt.Sprintf("%s %d", teamLabel(team), summary.ByTeam[team]))
+	}
+	b.WriteString("**By team:** " + strings.Join(teams, ", ") + "\n\n")
+
+	fmt.Fprintf(&b, "### Launches in the Next %d Days\n\n", days)
+	if len(summary.UpcomingLaunches) == 0 {
+		b.WriteString("None.\n")
+	}
+	for _, item := range summary.UpcomingLaunches {
+		fmt.Fprintf(&b, "- %s: %s (%s)\n", item.LaunchDate, item.Title, item.ID)
+	}
+
+	if len(pf.Errors) > 0 {
+		b.WriteString("\n## Unreadable Files\n\n")
+		for _, e := range pf.Errors {
+			fmt.Fprintf(&b, "- `%s`: %s\n", e.Path, e.Error)
+		}
+	}
+	return b.String()
+}
+
+// countList formats non-zero counts in the order of values, e.g. "draft 2,
+// approved 1", followed by the count of unset values
+func countList[T ~string](values []T, counts map[T]int) string {
+	var parts []string
+	for _, v := range values {
+		if n := counts[v]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", v, n))
+		}
+	}
+	if n := counts[""]; n > 0 {
+		parts = append(parts, fmt.Sprintf("(none) %d", n))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// teamLabel names the team of PRDs whose owner has none
+func teamLabel(team string) string {
+	return cmp.Or(team, "(no team)")
+}
+
+func enumIndex[T comparable](v T, values []T) int {
+	if i := slices.Index(values, v); i >= 0 {
+		return i
+	}
+	return len(values)
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+package prd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// portfolioFiles is a directory of PRDs mixed with other files
+var portfolioFiles = map[string]string{
+	"auth.json":               `{"id":"PRD-002","title":"Auth","status":"approved","priority":"high","owner":{"name":"Jane Smith","email":"jane@example.com","team":"Identity"},"timeline":{"launch_date":"2025-03-01"}}`,
+	"payments/checkout.json":  `{"id":"PRD-001","title":"Checkout","status":"draft","priority":"critical","owner":{"name":"Raj Patel","email":"raj@example.com","team":"Payments"},"timeline":{"launch_date":"2025-08"}}`,
+	"payments/refunds.json":   `{"id":"PRD-003","title":"refunds","status":"review","priority":"high","owner":{"name":"Ana Lima","email":"ana@example.com","team":"payments"}}`,
+	"payments/metrics.json":   `[{"date":"2025-01-01","value":1}]`,
+	"mapping.json":            `{"fields":{"summary":"Title"}}`,
+	"broken.json":             `{"id":"PRD-004","status":"shipped"}`,
+	"truncated.json":          `{"id":`,
+	"notes.md":                "# not a PRD",
+	"node_modules/pkg/x.json": `{"id":"PKG","title":"Package"}`,
+	"archive/old/legacy.json": `{"id":"PRD-000","title":"Legacy","status":"archived","priority":"low","owner":{"name":"Jane Smith","email":"jane@example.com"}}`,
+}
+
+func portfolioPaths(pf *Portfolio) string {
+	var paths []string
+	for _, item := range pf.Items {
+		paths = append(paths, item.Path)
+	}
+	return strings.Join(paths, ",")
+}
+
+func TestLoadPortfolio(t *testing.T) {
+	root := writeFixture(t, portfolioFiles)
+
+	pf, err := LoadPortfolio(root, DefaultPortfolioOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := portfolioPaths(pf); got != "archive/old/legacy.json,auth.json,payments/checkout.json,payments/refunds.json" {
+		t.Errorf("Unexpected PRDs: %s", got)
+	}
+	if len(pf.Errors) != 2 || pf.Errors[0].Path != "broken.json" || !strings.Contains(pf.Errors[0].Error, "invalid status 'shipped'") ||
+		!strings.Contains(pf.Errors[1].Error, "invalid JSON") {
+		t.Errorf("Unexpected errors: %+v", pf.Errors)
+	}
+	if strings.Join(pf.Ignored, ",") != "mapping.json,payments/metrics.json" {
+		t.Errorf("Unexpected ignored files: %v", pf.Ignored)
+	}
+
+	opts := DefaultPortfolioOptions()
+	opts.Recursive = false
+	flat, err := LoadPortfolio(root, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := portfolioPaths(flat); got != "auth.json" {
+		t.Errorf("Expected only top-level PRDs, got %s", got)
+	}
+}
+
+func TestPortfolioFilterAndSort(t *testing.T) {
+	pf, err := LoadPortfolio(writeFixture(t, portfolioFiles), DefaultPortfolioOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   PortfolioFilter
+		sortKey  string
+		reverse  bool
+		expected string
+	}{
+		{"team", PortfolioFilter{Teams: []string{"PAYMENTS"}}, "title", false, "payments/checkout.json,payments/refunds.json"},
+		{"status", PortfolioFilter{Statuses: []Status{StatusDraft, StatusReview}}, "status", true, "payments/refunds.json,payments/checkout.json"},
+		{"owner email", PortfolioFilter{Owner: "JANE@"}, "id", false, "archive/old/legacy.json,auth.json"},
+		{"priority", PortfolioFilter{Priorities: []Priority{PriorityHigh}}, "path", false, "auth.json,payments/refunds.json"},
+		{"priority order", PortfolioFilter{}, "priority", false, "payments/checkout.json,auth.json,payments/refunds.json,archive/old/legacy.json"},
+		{"undated launches last", PortfolioFilter{}, "launch", true, "payments/checkout.json,auth.json,archive/old/legacy.json,payments/refunds.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := pf.Filter(tt.filter)
+			if err := filtered.Sort(tt.sortKey, tt.reverse); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := portfolioPaths(filtered); got != tt.expected {
+				t.Errorf("Got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+
+	if err := pf.Sort("size", false); err == nil || !strings.Contains(err.Error(), "invalid sort key 'size'") {
+		t.Errorf("Expected invalid sort key error, got %v", err)
+	}
+}
+
+func TestPortfolioSummary(t *testing.T) {
+	pf, err := LoadPortfolio(writeFixture(t, portfolioFiles), DefaultPortfolioOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	now := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	summary := pf.Summary(now, 200)
+	if summary.Total != 4 || summary.ByStatus[StatusApproved] != 1 || summary.ByPriority[PriorityHigh] != 2 {
+		t.Errorf("Unexpected counts: %+v", summary)
+	}
+	// "" is the legacy PRD without a team
+	if got := strings.Join(summary.Teams(), ","); got != "Payments,Identity," {
+		t.Errorf("Unexpected teams: %q", got)
+	}
+	if len(summary.UpcomingLaunches) != 2 || summary.UpcomingLaunches[0].ID != "PRD-002" || summary.UpcomingLaunches[1].ID != "PRD-001" {
+		t.Errorf("Unexpected upcoming launches: %+v", summary.UpcomingLaunches)
+	}
+	if soon := pf.Summary(now, 30); len(soon.UpcomingLaunches) != 1 {
+		t.Errorf("Expected one launch within 30 days, got %d", len(soon.UpcomingLaunches))
+	}
+
+	var buf bytes.Buffer
+	if err := WritePortfolioCSV(&buf, pf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 5 || lines[0] != "Path,ID,Title,Status,Priority,Owner,Team,Launch,Last Updated" {
+		t.Errorf("Unexpected CSV: %s", buf.String())
+	}
+
+	md := PortfolioMarkdown(pf, summary, 200)
+	for _, want := range []string{"| auth.json | PRD-002 | Auth |", "**By status:** draft 1, review 1, approved 1, archived 1", "- 2025-03-01: Auth (PRD-002)", "- `broken.json`:"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+}
@@ -12,12 +12,14 @@ type PRD struct {
 	ID                      string                   `json:"id"`
 	Title                   string                   `json:"title"`
 	Version                 string                   `json:"version"`
-	CreatedDate             string                   `json:"created_date"`
+	CreatedDate             Date                     `json:"created_date"`
 	LastUpdated             *time.Time               `json:"last_updated,omitempty"`
 	Owner                   Owner                    `json:"owner"`
 	Stakeholders            []Stakeholder            `json:"stakeholders,omitempty"`
-	Status                  string                   `json:"status"`
-	Priority                string                   `json:"priority,omitempty"`
+	Status                  Status                   `json:"status"`
+	Priority                Priority                 `json:"priority,omitempty"`
+	Parent                  string                   `json:"parent,omitempty"`
+	Children                []string                 `json:"children,omitempty"`
 	Overview                Overview                 `json:"overview"`
 	Objectives              Objectives               `json:"objectives"`
 	UserPersonas            []UserPersona            `json:"user_personas,omitempty"`
@@ -62,15 +64,56 @@ type Objectives struct {
 
 // SuccessMetric represents a measurable success indicator
 type SuccessMetric struct {
-	Metric            string `json:"metric"`
-	Target            string `json:"target"`
-	MeasurementMethod string `json:"measurement_method,omitempty"`
+	Metric            string      `json:"metric"`
+	Target            string      `json:"target"`
+	MeasurementMethod string      `json:"measurement_method,omitempty"`
+	Goal              *MetricGoal `json:"goal,omitempty"`
+	// DataSource is the CSV or JSON file measurements are ingested from,
+	// relative to the PRD file
+	DataSource   string        `json:"data_source,omitempty"`
+	Measurements []Measurement `json:"measurements,omitempty"`
+}
+
+// MetricGoal is the machine-readable form of a success metric's target,
+// e.g. ">= 95 % by 2025-Q3"
+type MetricGoal struct {
+	Comparator Comparator `json:"comparator"`
+	Value      float64    `json:"value"`
+	Unit       string     `json:"unit,omitempty"`
+	Deadline   Date       `json:"deadline,omitzero"`
+}
+
+// Measurement is the value of a success metric on a date
+type Measurement struct {
+	Date  Date    `json:"date"`
+	Value float64 `json:"value"`
 }
 
 // OKR represents an Objective and Key Results
 type OKR struct {
-	Objective  string   `json:"objective"`
-	KeyResults []string `json:"key_results"`
+	Objective  string      `json:"objective"`
+	KeyResults []KeyResult `json:"key_results"`
+}
+
+// KeyResult is a measurable outcome of an objective. Progress runs from
+// Baseline to Target, so a target below the baseline measures a reduction.
+type KeyResult struct {
+	ID          string     `json:"id,omitempty"`
+	Description string     `json:"description"`
+	Baseline    float64    `json:"baseline,omitempty"`
+	Target      *float64   `json:"target,omitempty"`
+	Current     *float64   `json:"current,omitempty"`
+	Unit        string     `json:"unit,omitempty"`
+	Confidence  Confidence `json:"confidence,omitempty"`
+	CheckIns    []CheckIn  `json:"check_ins,omitempty"`
+}
+
+// CheckIn records the value of a key result on a date
+type CheckIn struct {
+	Date       Date       `json:"date"`
+	Value      float64    `json:"value"`
+	Confidence Confidence `json:"confidence,omitempty"`
+	Note       string     `json:"note,omitempty"`
 }
 
 // UserPersona represents a target user persona
@@ -83,11 +126,20 @@ type UserPersona struct {
 
 // UserStory represents a user story
 type UserStory struct {
-	ID                 string   `json:"id"`
-	Story              string   `json:"story"`
-	AcceptanceCriteria []string `json:"acceptance_criteria"`
-	Priority           string   `json:"priority,omitempty"`
-	EffortEstimate     string   `json:"effort_estimate,omitempty"`
+	ID                 string       `json:"id"`
+	Story              string       `json:"story"`
+	AcceptanceCriteria []string     `json:"acceptance_criteria"`
+	Priority           MoSCoW       `json:"priority,omitempty"`
+	EffortEstimate     string       `json:"effort_estimate,omitempty"`
+	Tracker            *TrackerLink `json:"tracker,omitempty"`
+}
+
+// TrackerLink links a PRD element to an issue in an external tracker
+type TrackerLink struct {
+	Key      string     `json:"key"`
+	URL      string     `json:"url,omitempty"`
+	Status   string     `json:"status,omitempty"`
+	SyncedAt *time.Time `json:"synced_at,omitempty"`
 }
 
 // Requirements contains functional and non-functional requirements
@@ -98,18 +150,19 @@ type Requirements struct {
 
 // FunctionalRequirement represents a functional requirement
 type FunctionalRequirement struct {
-	ID           string   `json:"id"`
-	Description  string   `json:"description"`
-	Priority     string   `json:"priority,omitempty"`
-	Dependencies []string `json:"dependencies,omitempty"`
+	ID           string       `json:"id"`
+	Description  string       `json:"description"`
+	Priority     MoSCoW       `json:"priority,omitempty"`
+	Dependencies []string     `json:"dependencies,omitempty"`
+	Tracker      *TrackerLink `json:"tracker,omitempty"`
 }
 
 // NonFunctionalRequirement represents a non-functional requirement
 type NonFunctionalRequirement struct {
-	ID                 string `json:"id"`
-	Category           string `json:"category"`
-	Description        string `json:"description"`
-	AcceptanceCriteria string `json:"acceptance_criteria,omitempty"`
+	ID                 string      `json:"id"`
+	Category           NFRCategory `json:"category"`
+	Description        string      `json:"description"`
+	AcceptanceCriteria string      `json:"acceptance_criteria,omitempty"`
 }
 
 // TechnicalSpecifications contains technical details
@@ -140,29 +193,51 @@ type APISpecification struct {
 // Timeline contains project timeline information
 type Timeline struct {
 	Milestones []Milestone `json:"milestones,omitempty"`
-	LaunchDate string      `json:"launch_date,omitempty"`
+	LaunchDate Date        `json:"launch_date,omitzero"`
 }
 
 // Milestone represents a project milestone
 type Milestone struct {
-	Name         string   `json:"name"`
-	Description  string   `json:"description,omitempty"`
-	TargetDate   string   `json:"target_date"`
-	Dependencies []string `json:"dependencies,omitempty"`
+	Name             string         `json:"name"`
+	Description      string         `json:"description,omitempty"`
+	TargetDate       Date           `json:"target_date"`
+	Dependencies     []string       `json:"dependencies,omitempty"`
+	State            MilestoneState `json:"state,omitempty"`
+	ActualStart      Date           `json:"actual_start,omitzero"`
+	ActualCompletion Date           `json:"actual_completion,omitzero"`
+	PercentComplete  *int           `json:"percent_complete,omitempty"`
+	// Requirements lists the IDs of requirements delivered by the milestone
+	Requirements []string `json:"requirements,omitempty"`
 }
 
 // RisksAndAssumptions contains risks and assumptions
 type RisksAndAssumptions struct {
-	Risks       []Risk   `json:"risks,omitempty"`
-	Assumptions []string `json:"assumptions,omitempty"`
+	Risks       []Risk       `json:"risks,omitempty"`
+	Assumptions []Assumption `json:"assumptions,omitempty"`
 }
 
 // Risk represents a project risk
 type Risk struct {
-	Description        string `json:"description"`
-	Impact             string `json:"impact"`
-	Probability        string `json:"probability"`
-	MitigationStrategy string `json:"mitigation_strategy,omitempty"`
+	ID                 string     `json:"id,omitempty"`
+	Description        string     `json:"description"`
+	Impact             RiskLevel  `json:"impact"`
+	Probability        RiskLevel  `json:"probability"`
+	MitigationStrategy string     `json:"mitigation_strategy,omitempty"`
+	Owner              string     `json:"owner,omitempty"`
+	Status             RiskStatus `json:"status,omitempty"`
+	ReviewDate         Date       `json:"review_date,omitzero"`
+	// Trigger is the event or signal that shows the risk is materializing
+	Trigger string `json:"trigger,omitempty"`
+}
+
+// Assumption is a belief the PRD depends on, and how it is being tested
+type Assumption struct {
+	ID               string           `json:"id,omitempty"`
+	Statement        string           `json:"statement"`
+	Confidence       Confidence       `json:"confidence,omitempty"`
+	ValidationMethod string           `json:"validation_method,omitempty"`
+	Result           AssumptionResult `json:"result,omitempty"`
+	ValidatedDate    Date             `json:"validated_date,omitzero"`
 }
 
 // Appendices contains supporting documents and references
@@ -193,11 +268,14 @@ func LoadFromFile(filename string) (*PRD, error) {
 		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
 	}
 
+	return parsePRD(data)
+}
+
+func parsePRD(data []byte) (*PRD, error) {
 	var prd PRD
 	if err := json.Unmarshal(data, &prd); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
-
 	return &prd, nil
 }
 
@@ -269,18 +347,55 @@ func (p *PRD) Validate() error {
 		return fmt.Errorf("at least one functional requirement is required")
 	}
 
-	// Validate status enum
-	validStatuses := map[string]bool{
-		"draft":          true,
-		"review":         true,
-		"approved":       true,
-		"in_development": true,
-		"completed":      true,
-		"archived":       true,
-	}
-	if !validStatuses[p.Status] {
+	// Validate enums, which may have been set in code rather than unmarshalled
+	if !p.Status.Valid() {
 		return fmt.Errorf("invalid status: %s", p.Status)
 	}
+	if p.Priority != "" && !p.Priority.Valid() {
+		return fmt.Errorf("invalid priority: %s", p.Priority)
+	}
+	for _, story := range p.UserStories {
+		if story.Priority != "" && !story.Priority.Valid() {
+			return fmt.Errorf("invalid priority for user story %s: %s", story.ID, story.Priority)
+		}
+	}
+	for _, req := range p.Requirements.Functional {
+		if req.Priority != "" && !req.Priority.Valid() {
+			return fmt.Errorf("invalid priority for requirement %s: %s", req.ID, req.Priority)
+		}
+	}
+	for _, req := range p.Requirements.NonFunctional {
+		if !req.Category.Valid() {
+			return fmt.Errorf("invalid category for requirement %s: %s", req.ID, req.Category)
+		}
+	}
+
+	if err := p.Objectives.validateOKRs(); err != nil {
+		return err
+	}
+	if err := p.Objectives.validateSuccessMetrics(); err != nil {
+		return err
+	}
+
+	if p.Timeline != nil {
+		if err := p.validateMilestones(); err != nil {
+			return err
+		}
+	}
+	if err := p.validateReferences(); err != nil {
+		return err
+	}
+	if err := p.validateHierarchy(); err != nil {
+		return err
+	}
+	if p.RisksAndAssumptions != nil {
+		if err := p.RisksAndAssumptions.validateRisks(); err != nil {
+			return err
+		}
+		if err := p.validateAssumptions(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,184 @@
+package prd
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeliveryProgress summarizes delivery from milestone and requirement states
+type DeliveryProgress struct {
+	Milestones     int
+	MilestonesDone int
+	// MilestonePercent is the mean completion of all milestones
+	MilestonePercent float64
+	// States counts milestones by their effective state
+	States map[MilestoneState]int
+	// AtRisk lists milestones that are at risk or have slipped
+	AtRisk []string
+
+	// Requirements counts functional and non-functional requirements
+	Requirements           int
+	RequirementsDone       int
+	RequirementsInProgress int
+	// RequirementsTracked counts requirements linked to an issue tracker;
+	// requirement progress is only known for these
+	RequirementsTracked int
+	// RequirementPercent is the share of tracked requirements that are done
+	RequirementPercent float64
+
+	// Overall averages milestone and requirement progress, ignoring either
+	// when there is nothing to measure
+	Overall float64
+}
+
+// Done reports whether the milestone is complete, either by state or by
+// having an actual completion date
+func (m Milestone) Done() bool {
+	return m.State == MilestoneDone || !m.ActualCompletion.IsZero()
+}
+
+// StateAt returns the milestone's effective state. Without an explicit
+// state it is derived from the actual dates. A milestone that is not done is
+// slipped once its target date has passed.
+func (m Milestone) StateAt(now time.Time) MilestoneState {
+	if m.Done() {
+		return MilestoneDone
+	}
+	if !m.TargetDate.IsZero() && m.TargetDate.Before(DateOf(now)) {
+		return MilestoneSlipped
+	}
+	if m.State != "" {
+		return m.State
+	}
+	if !m.ActualStart.IsZero() {
+		return MilestoneInProgress
+	}
+	return MilestonePlanned
+}
+
+// MilestoneProgress returns the completion percentage of a milestone: 100
+// when done, else its PercentComplete, else the share of its linked
+// requirements whose tracker issues are done. Non-functional requirements
+// have no tracker issues, so they count as not done.
+func (p *PRD) MilestoneProgress(m Milestone) float64 {
+	switch {
+	case m.Done():
+		return 100
+	case m.PercentComplete != nil:
+		return float64(*m.PercentComplete)
+	}
+
+	statuses := p.requirementStatuses()
+	linked, done := 0, 0
+	for _, id := range m.Requirements {
+		status, ok := statuses[id]
+		if !ok {
+			continue
+		}
+		linked++
+		if status == "done" {
+			done++
+		}
+	}
+	if linked == 0 {
+		return 0
+	}
+	return float64(done) * 100 / float64(linked)
+}
+
+// DeliveryProgress computes delivery progress from milestone states and the
+// tracker status of requirements
+func (p *PRD) DeliveryProgress(now time.Time) DeliveryProgress {
+	progress := DeliveryProgress{States: map[MilestoneState]int{}}
+
+	if p.Timeline != nil {
+		var total float64
+		for _, m := range p.Timeline.Milestones {
+			state := m.StateAt(now)
+			progress.Milestones++
+			progress.States[state]++
+			if state == MilestoneDone {
+				progress.MilestonesDone++
+			}
+			if state == MilestoneAtRisk || state == MilestoneSlipped {
+				progress.AtRisk = append(progress.AtRisk, m.Name)
+			}
+			total += p.MilestoneProgress(m)
+		}
+		if progress.Milestones > 0 {
+			progress.MilestonePercent = total / float64(progress.Milestones)
+		}
+	}
+
+	for _, status := range p.requirementStatuses() {
+		progress.Requirements++
+		if status == "" {
+			continue
+		}
+		progress.RequirementsTracked++
+		switch status {
+		case "done":
+			progress.RequirementsDone++
+		case "in_progress":
+			progress.RequirementsInProgress++
+		}
+	}
+	if progress.RequirementsTracked > 0 {
+		progress.RequirementPercent = float64(progress.RequirementsDone) * 100 / float64(progress.RequirementsTracked)
+	}
+
+	switch {
+	case progress.Milestones > 0 && progress.RequirementsTracked > 0:
+		progress.Overall = (progress.MilestonePercent + progress.RequirementPercent) / 2
+	case progress.Milestones > 0:
+		progress.Overall = progress.MilestonePercent
+	default:
+		progress.Overall = progress.RequirementPercent
+	}
+	return progress
+}
+
+// requirementStatuses maps requirement IDs to their tracker status, which
+// is empty for requirements not linked to a tracker, including every
+// non-functional requirement
+func (p *PRD) requirementStatuses() map[string]string {
+	statuses := map[string]string{}
+	for _, req := range p.Requirements.Functional {
+		statuses[req.ID] = ""
+		if req.Tracker != nil {
+			statuses[req.ID] = req.Tracker.Status
+		}
+	}
+	for _, req := range p.Requirements.NonFunctional {
+		statuses[req.ID] = ""
+	}
+	return statuses
+}
+
+func (p *PRD) validateMilestones() error {
+	ids := map[string]bool{}
+	for _, req := range p.Requirements.Functional {
+		ids[req.ID] = true
+	}
+	for _, req := range p.Requirements.NonFunctional {
+		ids[req.ID] = true
+	}
+
+	for _, m := range p.Timeline.Milestones {
+		if m.State != "" && !m.State.Valid() {
+			return fmt.Errorf("invalid state for milestone %s: %s", m.Name, m.State)
+		}
+		if m.PercentComplete != nil && (*m.PercentComplete < 0 || *m.PercentComplete > 100) {
+			return fmt.Errorf("percent complete for milestone %s must be between 0 and 100: %d", m.Name, *m.PercentComplete)
+		}
+		if !m.ActualStart.IsZero() && !m.ActualCompletion.IsZero() && m.ActualCompletion.Before(m.ActualStart) {
+			return fmt.Errorf("milestone %s completed (%s) before it started (%s)", m.Name, m.ActualCompletion, m.ActualStart)
+		}
+		for _, id := range m.Requirements {
+			if !ids[id] {
+				return fmt.Errorf("milestone %s references unknown requirement %s", m.Name, id)
+			}
+		}
+	}
+	return nil
+}
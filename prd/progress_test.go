@@ -0,0 +1,110 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func progressFixture() *PRD {
+	sixty := 60
+	return &PRD{
+		Status: StatusInDevelopment,
+		Requirements: Requirements{Functional: []FunctionalRequirement{
+			{ID: "FR-001", Tracker: &TrackerLink{Key: "1", Status: "done"}},
+			{ID: "FR-002", Tracker: &TrackerLink{Key: "2", Status: "in_progress"}},
+			{ID: "FR-003"},
+			{ID: "FR-004", Tracker: &TrackerLink{Key: "4", Status: "done"}},
+		}, NonFunctional: []NonFunctionalRequirement{
+			{ID: "NFR-001"},
+		}},
+		Timeline: &Timeline{Milestones: []Milestone{
+			{Name: "Design", TargetDate: MustParseDate("2025-03-01"), ActualCompletion: MustParseDate("2025-02-27")},
+			{Name: "Backend", TargetDate: MustParseDate("2025-05-01"), ActualStart: MustParseDate("2025-03-03"), PercentComplete: &sixty},
+			{Name: "Frontend", TargetDate: MustParseDate("2025-06-01"), State: MilestoneAtRisk, Requirements: []string{"FR-001", "FR-002", "FR-003", "FR-004", "NFR-001"}},
+			{Name: "Beta", TargetDate: MustParseDate("2025-03-15")},
+		}},
+	}
+}
+
+func TestMilestoneStateAt(t *testing.T) {
+	now := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	p := progressFixture()
+
+	expected := []MilestoneState{MilestoneDone, MilestoneInProgress, MilestoneAtRisk, MilestoneSlipped}
+	for i, m := range p.Timeline.Milestones {
+		if got := m.StateAt(now); got != expected[i] {
+			t.Errorf("%s: expected %s, got %s", m.Name, expected[i], got)
+		}
+	}
+
+	planned := Milestone{Name: "GA", TargetDate: MustParseDate("2025-Q4")}
+	if got := planned.StateAt(now); got != MilestonePlanned {
+		t.Errorf("Expected planned, got %s", got)
+	}
+}
+
+func TestDeliveryProgress(t *testing.T) {
+	now := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	p := progressFixture()
+
+	// Frontend: 2 of its 5 requirements are done; FR-003 is not tracked yet
+	// and NFR-001 cannot be
+	if got := p.MilestoneProgress(p.Timeline.Milestones[2]); got != 40 {
+		t.Errorf("Expected Frontend progress from linked requirements, got %.1f", got)
+	}
+
+	progress := p.DeliveryProgress(now)
+	if progress.Milestones != 4 || progress.MilestonesDone != 1 {
+		t.Errorf("Expected 1/4 milestones done, got %d/%d", progress.MilestonesDone, progress.Milestones)
+	}
+	if strings.Join(progress.AtRisk, ",") != "Frontend,Beta" {
+		t.Errorf("Unexpected at-risk milestones: %v", progress.AtRisk)
+	}
+	if progress.Requirements != 5 || progress.RequirementsDone != 2 || progress.RequirementsInProgress != 1 || progress.RequirementsTracked != 3 {
+		t.Errorf("Unexpected requirement counts: %+v", progress)
+	}
+	// 2 of the 3 tracked requirements are done
+	if got := fmt.Sprintf("%.1f", progress.RequirementPercent); got != "66.7" {
+		t.Errorf("Expected 66.7%% of tracked requirements done, got %s", got)
+	}
+	// Milestones: (100 + 60 + 40 + 0) / 4 = 50; overall (50 + 66.7) / 2
+	if got := fmt.Sprintf("%.1f", progress.Overall); got != "58.3" {
+		t.Errorf("Unexpected overall progress: %s", got)
+	}
+
+	empty := (&PRD{}).DeliveryProgress(now)
+	if empty.Overall != 0 || empty.Milestones != 0 {
+		t.Errorf("Expected no progress for an empty PRD, got %+v", empty)
+	}
+}
+
+func TestValidateMilestones(t *testing.T) {
+	over := 120
+	tests := []struct {
+		name   string
+		modify func(*Milestone)
+		want   string
+	}{
+		{"state", func(m *Milestone) { m.State = "blocked" }, "invalid state for milestone"},
+		{"percent", func(m *Milestone) { m.PercentComplete = &over }, "must be between 0 and 100"},
+		{"dates", func(m *Milestone) {
+			m.ActualStart = MustParseDate("2024-03-01")
+			m.ActualCompletion = MustParseDate("2024-02-01")
+		}, "before it started"},
+		{"requirement", func(m *Milestone) { m.Requirements = []string{"FR-999"} }, "unknown requirement FR-999"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := LoadFromFile("example.json")
+			if err != nil {
+				t.Fatalf("Failed to load example PRD: %v", err)
+			}
+			tt.modify(&p.Timeline.Milestones[0])
+			if err := p.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
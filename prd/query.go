@@ -0,0 +1,545 @@
+package prd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query is a compiled query over the JSON form of a PRD, for example
+//
+//	status = approved and requirements.functional[priority = must_have].description ~ "SSO"
+//
+// Paths are dot-separated JSON field names. Arrays along a path are searched
+// element by element, and can be narrowed with a predicate evaluated against
+// each element ("[priority = must_have]") or an index ("[0]"). A comparison
+// holds if any value the path reaches satisfies it, except for != and !~,
+// which hold if none of them equals or contains the value. A path on its own
+// holds if it reaches a value that is not empty, false or zero.
+//
+// Operators are = and != (case-insensitive equality), ~ and !~
+// (case-insensitive substring), and <, <=, > and >= (numeric for numbers,
+// chronological for dates such as 2024-12-01 or 2024-Q1, otherwise
+// lexical). Values are quoted strings, numbers, true, false or bare words
+// such as approved or FR-001.
+// Comparisons combine with and, or, not and parentheses.
+type Query struct {
+	source string
+	expr   queryExpr
+}
+
+// QueryMatch is a value inside an array element that satisfied a
+// comparison, such as the description of a matching requirement
+type QueryMatch struct {
+	// Path locates the value, e.g. "requirements.functional[2].description"
+	Path string `json:"path"`
+	// ID is the id of the nearest enclosing element that has one
+	ID    string `json:"id,omitempty"`
+	Value any    `json:"value"`
+}
+
+// queryNode is a value reached by a path
+type queryNode struct {
+	value any
+	path  string
+	id    string
+	// inElement is set once the path has passed through an array element
+	inElement bool
+}
+
+type queryExpr interface {
+	eval(n queryNode) (bool, []QueryMatch)
+}
+
+type queryAnd struct{ left, right queryExpr }
+
+type queryOr struct{ left, right queryExpr }
+
+type queryNot struct{ expr queryExpr }
+
+type queryComparison struct {
+	path  []querySegment
+	op    string
+	value string
+	// quoted values are always compared as strings
+	quoted bool
+}
+
+type querySegment struct {
+	name   string
+	filter queryExpr
+	index  *int
+}
+
+// ParseQuery compiles a query expression
+func ParseQuery(source string) (*Query, error) {
+	p := &queryParser{src: source}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, p.errorf("unexpected '%s'", p.src[p.pos:])
+	}
+	return &Query{source: source, expr: expr}, nil
+}
+
+// String returns the query's source
+func (q *Query) String() string {
+	return q.source
+}
+
+// Match reports whether the PRD satisfies the query, with the array
+// elements that matched its comparisons
+func (q *Query) Match(p *PRD) (bool, []QueryMatch, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to marshal PRD: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false, nil, fmt.Errorf("failed to unmarshal PRD: %w", err)
+	}
+
+	ok, matches := q.expr.eval(queryNode{value: doc})
+	if !ok {
+		return false, nil, nil
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Path < matches[j].Path })
+	unique := matches[:0]
+	for i, m := range matches {
+		if i == 0 || m.Path != matches[i-1].Path {
+			unique = append(unique, m)
+		}
+	}
+	return true, unique, nil
+}
+
+func (e queryAnd) eval(n queryNode) (bool, []QueryMatch) {
+	ok, left := e.left.eval(n)
+	if !ok {
+		return false, nil
+	}
+	ok, right := e.right.eval(n)
+	if !ok {
+		return false, nil
+	}
+	return true, append(left, right...)
+}
+
+func (e queryOr) eval(n queryNode) (bool, []QueryMatch) {
+	leftOK, left := e.left.eval(n)
+	rightOK, right := e.right.eval(n)
+	return leftOK || rightOK, append(left, right...)
+}
+
+func (e queryNot) eval(n queryNode) (bool, []QueryMatch) {
+	ok, _ := e.expr.eval(n)
+	return !ok, nil
+}
+
+func (e queryComparison) eval(n queryNode) (bool, []QueryMatch) {
+	nodes := resolveQueryPath(n, e.path)
+
+	if e.op == "!=" || e.op == "!~" {
+		positive := queryComparison{path: e.path, op: e.op[1:], value: e.value, quoted: e.quoted}
+		for _, node := range nodes {
+			if positive.test(node.value) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	var matches []QueryMatch
+	for _, node := range nodes {
+		if e.test(node.value) {
+			if !node.inElement {
+				// A document-level value: the query matches without an element
+				return true, matches
+			}
+			matches = append(matches, QueryMatch{Path: node.path, ID: node.id, Value: node.value})
+		}
+	}
+	return len(matches) > 0, matches
+}
+
+// test compares one value with the comparison's value
+func (e queryComparison) test(v any) bool {
+	if e.op == "" {
+		return queryTruthy(v)
+	}
+
+	if !e.quoted {
+		if literal, err := strconv.ParseFloat(e.value, 64); err == nil {
+			if number, ok := v.(float64); ok {
+				return compareOrdered(e.op, number, literal)
+			}
+		}
+		if literal, err := strconv.ParseBool(e.value); err == nil && (e.value == "true" || e.value == "false") {
+			if b, ok := v.(bool); ok {
+				return (e.op == "=") == (b == literal)
+			}
+		}
+	}
+
+	text := queryText(v)
+	switch e.op {
+	case "=":
+		return strings.EqualFold(text, e.value)
+	case "~":
+		return strings.Contains(strings.ToLower(text), strings.ToLower(e.value))
+	default:
+		if a, err := ParseDate(text); err == nil {
+			if b, err := ParseDate(e.value); err == nil {
+				return compareOrdered(e.op, float64(a.Compare(b)), 0)
+			}
+		}
+		return compareOrdered(e.op, strings.ToLower(text), strings.ToLower(e.value))
+	}
+}
+
+func compareOrdered[T float64 | string](op string, a, b T) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+// queryTruthy reports whether a value is present and not empty, false or
+// zero
+func queryTruthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	return true
+}
+
+// queryText is the text a value is compared as. Objects and arrays are
+// the text of their values, so "~" searches a whole element.
+func queryText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = queryText(e)
+		}
+		return strings.Join(parts, "\n")
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = queryText(v[k])
+		}
+		return strings.Join(parts, "\n")
+	}
+	return fmt.Sprint(v)
+}
+
+// resolveQueryPath returns the values a path reaches from n, descending
+// into every array element along the way
+func resolveQueryPath(n queryNode, path []querySegment) []queryNode {
+	nodes := []queryNode{n}
+	for _, seg := range path {
+		var next []queryNode
+		for _, node := range nodes {
+			obj, ok := node.value.(map[string]any)
+			if !ok {
+				continue
+			}
+			v, ok := obj[seg.name]
+			if !ok {
+				continue
+			}
+			child := queryNode{value: v, path: joinQueryPath(node.path, seg.name), id: node.id, inElement: node.inElement}
+
+			elements, isArray := v.([]any)
+			if !isArray {
+				if seg.index == nil && (seg.filter == nil || queryFilter(seg.filter, child)) {
+					next = append(next, child)
+				}
+				continue
+			}
+			for i, e := range elements {
+				if seg.index != nil && *seg.index != i {
+					continue
+				}
+				element := queryNode{value: e, path: fmt.Sprintf("%s[%d]", child.path, i), id: child.id, inElement: true}
+				if m, ok := e.(map[string]any); ok {
+					if id, ok := m["id"].(string); ok && id != "" {
+						element.id = id
+					}
+				}
+				if seg.filter != nil && !queryFilter(seg.filter, element) {
+					continue
+				}
+				next = append(next, element)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func queryFilter(filter queryExpr, n queryNode) bool {
+	ok, _ := filter.eval(n)
+	return ok
+}
+
+func joinQueryPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// queryParser is a recursive descent parser for query expressions
+type queryParser struct {
+	src string
+	pos int
+}
+
+func (p *queryParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("query: %s at position %d", fmt.Sprintf(format, args...), p.pos+1)
+}
+
+func (p *queryParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// keyword consumes a case-insensitive keyword followed by a word boundary
+func (p *queryParser) keyword(word string) bool {
+	p.skipSpace()
+	end := p.pos + len(word)
+	if end > len(p.src) || !strings.EqualFold(p.src[p.pos:end], word) {
+		return false
+	}
+	if end < len(p.src) && isQueryWordChar(p.src[end]) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *queryParser) consume(s string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = queryAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (queryExpr, error) {
+	if p.keyword("not") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{expr}, nil
+	}
+	if p.consume("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (queryExpr, error) {
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	cmp := queryComparison{path: path}
+
+	p.skipSpace()
+	for _, op := range []string{"!=", "!~", "<=", ">=", "=", "~", "<", ">"} {
+		if p.consume(op) {
+			cmp.op = op
+			break
+		}
+	}
+	if cmp.op == "" {
+		return cmp, nil
+	}
+	if cmp.value, cmp.quoted, err = p.parseValue(); err != nil {
+		return nil, err
+	}
+	return cmp, nil
+}
+
+func (p *queryParser) parsePath() ([]querySegment, error) {
+	var path []querySegment
+	for {
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.src) && (isQueryWordChar(p.src[p.pos]) && p.src[p.pos] != '-') {
+			p.pos++
+		}
+		if p.pos == start {
+			return nil, p.errorf("expected a field name")
+		}
+		seg := querySegment{name: p.src[start:p.pos]}
+
+		if p.pos < len(p.src) && p.src[p.pos] == '[' {
+			p.pos++
+			p.skipSpace()
+			end := p.pos
+			for end < len(p.src) && p.src[end] >= '0' && p.src[end] <= '9' {
+				end++
+			}
+			if rest := strings.TrimLeftFunc(p.src[end:], unicode.IsSpace); end > p.pos && strings.HasPrefix(rest, "]") {
+				index, _ := strconv.Atoi(p.src[p.pos:end])
+				seg.index = &index
+				p.pos = end
+			} else {
+				filter, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				seg.filter = filter
+			}
+			if !p.consume("]") {
+				return nil, p.errorf("expected ']'")
+			}
+		}
+		path = append(path, seg)
+
+		if p.pos >= len(p.src) || p.src[p.pos] != '.' {
+			return path, nil
+		}
+		p.pos++
+	}
+}
+
+// parseValue reads a quoted string or a bare word
+func (p *queryParser) parseValue() (string, bool, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return "", false, p.errorf("expected a value")
+	}
+
+	if quote := p.src[p.pos]; quote == '"' || quote == '\'' {
+		var b strings.Builder
+		for i := p.pos + 1; i < len(p.src); i++ {
+			switch c := p.src[i]; {
+			case c == '\\' && i+1 < len(p.src):
+				i++
+				b.WriteByte(p.src[i])
+			case c == quote:
+				p.pos = i + 1
+				return b.String(), true, nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", false, p.errorf("unterminated string")
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && !unicode.IsSpace(rune(p.src[p.pos])) && !strings.ContainsRune("()[]", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", false, p.errorf("expected a value")
+	}
+	return p.src[start:p.pos], false, nil
+}
+
+func isQueryWordChar(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// QueryResult is a PRD that matched a query, with the elements that matched
+type QueryResult struct {
+	PortfolioItem
+	Matches []QueryMatch `json:"matches,omitempty"`
+}
+
+// Search returns the portfolio's PRDs that match the query
+func (q *Query) Search(pf *Portfolio) ([]QueryResult, error) {
+	var results []QueryResult
+	for _, item := range pf.Items {
+		ok, matches, err := q.Match(item.PRD)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", item.Path, err)
+		}
+		if ok {
+			results = append(results, QueryResult{PortfolioItem: item, Matches: matches})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,130 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryMatch(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	tests := []struct {
+		query   string
+		match   bool
+		matches string
+	}{
+		{`status = approved`, true, ""},
+		{`status = APPROVED and priority != low`, true, ""},
+		{`status = draft or priority = critical`, false, ""},
+		{`not status = draft`, true, ""},
+		{`requirements.functional[priority = must_have].description ~ "biometric"`, true, "requirements.functional[0].description"},
+		{`requirements.functional[priority = must_have].description ~ "social"`, false, ""},
+		{`requirements.functional[priority = must_have]`, true, "requirements.functional[0],requirements.functional[2]"},
+		{`requirements.functional[1].id = FR-002`, true, "requirements.functional[1].id"},
+		{`requirements.functional.id != FR-009`, true, ""},
+		{`requirements.functional.id != FR-001`, false, ""},
+		{`requirements.functional ~ 'password reset'`, true, "requirements.functional[2]"},
+		{`(status = draft or id = PRD-001) and user_stories`, true, "user_stories[0],user_stories[1]"},
+		{`missing.field`, false, ""},
+		{`version >= "1.0"`, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Unexpected parse error: %v", err)
+			}
+			match, matches, err := q.Match(p)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if match != tt.match {
+				t.Errorf("Match = %v, expected %v", match, tt.match)
+			}
+			var paths []string
+			for _, m := range matches {
+				paths = append(paths, m.Path)
+			}
+			if got := strings.Join(paths, ","); got != tt.matches {
+				t.Errorf("Matches = %q, expected %q", got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestQueryMatchNumbers(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	q, err := ParseQuery(`objectives.success_metrics[goal.value >= 90].metric ~ success`)
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	match, matches, err := q.Match(p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !match || len(matches) != 1 || matches[0].Value != "Login Success Rate" {
+		t.Errorf("Unexpected result: %v %+v", match, matches)
+	}
+}
+
+func TestQueryMatchDates(t *testing.T) {
+	p := &PRD{ID: "PRD-001", Timeline: &Timeline{
+		LaunchDate: MustParseDate("2024-Q1"),
+		Milestones: []Milestone{
+			{Name: "Beta", TargetDate: MustParseDate("2024-12-01")},
+			{Name: "GA", TargetDate: MustParseDate("2025-02")},
+		},
+	}}
+
+	tests := []struct {
+		query string
+		match bool
+	}{
+		{`timeline.launch_date > 2024-12-01`, false},
+		{`timeline.launch_date < 2024-12-01`, true},
+		{`timeline.launch_date >= 2023-12-31`, true},
+		{`timeline.launch_date <= 2024-03-31`, true},
+		{`timeline.milestones[target_date > 2024-Q4].name = GA`, true},
+		{`timeline.milestones[target_date < 2025-Q1].name = Beta`, true},
+		{`timeline.milestones[target_date >= 2025-01].name = Beta`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Unexpected parse error: %v", err)
+			}
+			if match, _, err := q.Match(p); err != nil || match != tt.match {
+				t.Errorf("Match = %v (%v), expected %v", match, err, tt.match)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{``, "expected a field name at position 1"},
+		{`status =`, "expected a value at position 9"},
+		{`status = "approved`, "unterminated string"},
+		{`(status = draft`, "expected ')'"},
+		{`requirements.functional[priority = must_have`, "expected ']'"},
+		{`status = draft priority = high`, "unexpected 'priority = high'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := ParseQuery(tt.query); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package prd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Reference is a dependency on another PRD, written "PRD-042" for the whole
+// document or "PRD-042#FR-007" for one of its requirements or milestones
+type Reference struct {
+	PRD string `json:"prd"`
+	// Element is a requirement ID or milestone name, empty for the whole PRD
+	Element string `json:"element,omitempty"`
+}
+
+// ParseReference splits a dependency into a PRD ID and element. ok is false
+// for dependencies without a "#", which may name a PRD or a local element.
+func ParseReference(s string) (ref Reference, ok bool) {
+	id, element, ok := strings.Cut(strings.TrimSpace(s), "#")
+	return Reference{PRD: strings.TrimSpace(id), Element: strings.TrimSpace(element)}, ok
+}
+
+// String returns the reference in its written form
+func (r Reference) String() string {
+	if r.Element == "" {
+		return r.PRD
+	}
+	return r.PRD + "#" + r.Element
+}
+
+// Link is a dependency of a requirement or milestone in one PRD on another
+// PRD of a workspace
+type Link struct {
+	// From is the ID of the PRD with the dependency
+	From     string `json:"from"`
+	FromPath string `json:"from_path,omitempty"`
+	// Kind is "requirement" or "milestone"
+	Kind string `json:"kind"`
+	// Source is the requirement ID or milestone name with the dependency
+	Source string    `json:"source"`
+	Target Reference `json:"target"`
+	// ToPath is the file of the target PRD, empty if it was not found
+	ToPath string `json:"to_path,omitempty"`
+	// TargetTitle and TargetStatus describe the target PRD, if found
+	TargetTitle  string `json:"target_title,omitempty"`
+	TargetStatus Status `json:"target_status,omitempty"`
+	// Problem explains why the reference is invalid
+	Problem string `json:"problem,omitempty"`
+}
+
+// String describes the link from its source, e.g.
+// "requirement FR-003 → PRD-042#FR-007"
+func (l Link) String() string {
+	return fmt.Sprintf("%s %s → %s", l.Kind, l.Source, l.Target)
+}
+
+// Workspace is a directory of PRDs whose dependencies on each other are
+// resolved by ID
+type Workspace struct {
+	Root      string
+	Portfolio *Portfolio
+	// IDPrefix is the prefix of PRD IDs, "PRD-" unless the configuration
+	// sets another
+	IDPrefix string
+	byID     map[string][]PortfolioItem
+}
+
+// FindWorkspaceRoot returns the nearest directory at or above path that
+// contains a .prd or .git directory, or the directory of path if there is
+// none
+func FindWorkspaceRoot(path string) string {
+	start, err := filepath.Abs(path)
+	if err != nil {
+		start = path
+	}
+	if info, err := os.Stat(start); err != nil || !info.IsDir() {
+		start = filepath.Dir(start)
+	}
+	for dir := start; ; {
+		for _, marker := range []string{".prd", ".git"} {
+			if info, err := os.Stat(filepath.Join(dir, marker)); err == nil && info.IsDir() {
+				return dir
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return start
+		}
+		dir = parent
+	}
+}
+
+// LoadWorkspace loads every PRD under root
+func LoadWorkspace(root string) (*Workspace, error) {
+	pf, err := LoadPortfolio(root, DefaultPortfolioOptions())
+	if err != nil {
+		return nil, err
+	}
+	w := &Workspace{Root: root, Portfolio: pf, IDPrefix: DefaultConfig().IDs.PRD, byID: map[string][]PortfolioItem{}}
+	for _, item := range pf.Items {
+		if item.ID != "" {
+			w.byID[item.ID] = append(w.byID[item.ID], item)
+		}
+	}
+	return w, nil
+}
+
+// PRD returns the workspace PRD with the given ID
+func (w *Workspace) PRD(id string) (PortfolioItem, bool) {
+	items := w.byID[id]
+	if len(items) == 0 {
+		return PortfolioItem{}, false
+	}
+	return items[0], true
+}
+
+// Outbound returns the dependencies of p on other PRDs of the workspace,
+// with any problems resolving them. A dependency with a "#" always names a
+// PRD; one without names a PRD only if it is not a requirement or milestone
+// of p and is the ID of a workspace PRD or starts with the PRD ID prefix.
+func (w *Workspace) Outbound(p *PRD, path string) []Link {
+	var links []Link
+	for _, dep := range p.dependencies() {
+		ref, explicit := ParseReference(dep.target)
+		if !explicit && (ref.PRD == p.ID || p.hasElement(ref.PRD) || !w.isPRDID(ref.PRD)) {
+			continue
+		}
+		link := Link{From: p.ID, FromPath: path, Kind: dep.kind, Source: dep.source, Target: ref}
+		switch {
+		case explicit && (ref.PRD == "" || ref.Element == ""):
+			link.Problem = fmt.Sprintf("invalid reference '%s'", dep.target)
+		case ref.PRD == p.ID:
+			// An explicit reference to this PRD is resolved locally
+			link.ToPath, link.TargetTitle, link.TargetStatus = path, p.Title, p.Status
+			if !p.hasElement(ref.Element) {
+				link.Problem = fmt.Sprintf("%s has no requirement or milestone %s", ref.PRD, ref.Element)
+			}
+		default:
+			link.Problem = w.resolve(&link)
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// isPRDID reports whether id is the ID of a workspace PRD or looks like one
+func (w *Workspace) isPRDID(id string) bool {
+	return len(w.byID[id]) > 0 || (w.IDPrefix != "" && strings.HasPrefix(id, w.IDPrefix))
+}
+
+// resolve fills in the target of a link, returning why it is invalid
+func (w *Workspace) resolve(link *Link) string {
+	ref := link.Target
+	items := w.byID[ref.PRD]
+	if len(items) == 0 {
+		return fmt.Sprintf("%s is not in the workspace", ref.PRD)
+	}
+	target := items[0]
+	link.ToPath, link.TargetTitle, link.TargetStatus = target.Path, target.Title, target.Status
+	switch {
+	case len(items) > 1:
+		return fmt.Sprintf("%s is defined by both %s and %s", ref.PRD, items[0].Path, items[1].Path)
+	case target.Status == StatusArchived:
+		return fmt.Sprintf("%s is archived", ref.PRD)
+	case ref.Element != "" && !target.PRD.hasElement(ref.Element):
+		return fmt.Sprintf("%s has no requirement or milestone %s", ref.PRD, ref.Element)
+	}
+	return ""
+}
+
+// Inbound returns the dependencies of other workspace PRDs on the PRD with
+// the given ID
+func (w *Workspace) Inbound(id string) []Link {
+	var links []Link
+	for _, item := range w.Portfolio.Items {
+		if item.ID == id {
+			continue
+		}
+		for _, link := range w.Outbound(item.PRD, item.Path) {
+			if link.Target.PRD == id {
+				links = append(links, link)
+			}
+		}
+	}
+	sort.SliceStable(links, func(i, j int) bool { return links[i].From < links[j].From })
+	return links
+}
+
+// CheckReferences returns the problems with the dependencies of p on other
+// PRDs, one message per invalid reference
+func (w *Workspace) CheckReferences(p *PRD, path string) []string {
+	var problems []string
+	for _, link := range w.Outbound(p, path) {
+		if link.Problem != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", link, link.Problem))
+		}
+	}
+	return problems
+}
+
+// dependency is a requirement or milestone dependency
+type dependency struct {
+	kind, source, target string
+}
+
+func (p *PRD) dependencies() []dependency {
+	var deps []dependency
+	for _, req := range p.Requirements.Functional {
+		for _, d := range req.Dependencies {
+			deps = append(deps, dependency{"requirement", req.ID, d})
+		}
+	}
+	if p.Timeline != nil {
+		for _, m := range p.Timeline.Milestones {
+			for _, d := range m.Dependencies {
+				deps = append(deps, dependency{"milestone", m.Name, d})
+			}
+		}
+	}
+	return deps
+}
+
+// hasElement reports whether p has a requirement with the given ID or a
+// milestone with the given name
+func (p *PRD) hasElement(element string) bool {
+	for _, req := range p.Requirements.Functional {
+		if req.ID == element {
+			return true
+		}
+	}
+	for _, req := range p.Requirements.NonFunctional {
+		if req.ID == element {
+			return true
+		}
+	}
+	if p.Timeline != nil {
+		for _, m := range p.Timeline.Milestones {
+			if milestoneKey(m.Name) == milestoneKey(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateReferences checks that cross-document references are well formed
+// and that references to p itself resolve
+func (p *PRD) validateReferences() error {
+	for _, dep := range p.dependencies() {
+		ref, explicit := ParseReference(dep.target)
+		if !explicit {
+			continue
+		}
+		if ref.PRD == "" || ref.Element == "" {
+			return fmt.Errorf("invalid reference '%s' in %s %s: expected PRD-ID#element", dep.target, dep.kind, dep.source)
+		}
+		if ref.PRD == p.ID && !p.hasElement(ref.Element) {
+			return fmt.Errorf("%s %s references unknown element %s", dep.kind, dep.source, ref)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// workspaceFiles is a workspace of PRDs with dependencies on each other
+var workspaceFiles = map[string]string{
+	"epic.json": `{"id":"PRD-100","title":"Checkout Epic","status":"draft","owner":{"name":"A"},
+		"requirements":{"functional":[
+			{"id":"FR-001","description":"Pay","dependencies":["FR-002","PRD-101#FR-001","PRD-101"]},
+			{"id":"FR-002","description":"Refund","dependencies":["PRD-101#FR-009","PRD-103#FR-001","PRD-999#FR-001","PRD-100#FR-003","PRD-998"]}]},
+		"timeline":{"milestones":[{"name":"Beta","target_date":"2025-03","dependencies":["PRD-102#launch","Security review"]}]}}`,
+	"features/wallet.json":   `{"id":"PRD-101","title":"Wallet","status":"approved","owner":{"name":"B"},"requirements":{"functional":[{"id":"FR-001","description":"Store cards"}]}}`,
+	"features/fraud.json":    `{"id":"PRD-102","title":"Fraud","status":"review","owner":{"name":"C"},"timeline":{"milestones":[{"name":"Launch","target_date":"2025-02"}]},"requirements":{"functional":[{"id":"FR-001","description":"Score","dependencies":["PRD-100#Beta"]}]}}`,
+	"archive/legacy.json":    `{"id":"PRD-103","title":"Legacy","status":"archived","owner":{"name":"D"},"requirements":{"functional":[{"id":"FR-001","description":"Old"}]}}`,
+	"archive/duplicate.json": `{"id":"PRD-102","title":"Fraud copy","status":"draft","owner":{"name":"E"}}`,
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Reference
+		explicit bool
+	}{
+		{"PRD-042#FR-007", Reference{"PRD-042", "FR-007"}, true},
+		{" PRD-042 # Beta launch ", Reference{"PRD-042", "Beta launch"}, true},
+		{"PRD-042", Reference{PRD: "PRD-042"}, false},
+		{"#FR-001", Reference{Element: "FR-001"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ref, explicit := ParseReference(tt.input)
+			if ref != tt.expected || explicit != tt.explicit {
+				t.Errorf("Got %+v %v, expected %+v %v", ref, explicit, tt.expected, tt.explicit)
+			}
+		})
+	}
+	if s := (Reference{"PRD-042", "FR-007"}).String(); s != "PRD-042#FR-007" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+}
+
+func TestWorkspaceReferences(t *testing.T) {
+	root := writeFixture(t, workspaceFiles)
+	w, err := LoadWorkspace(root)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	epic, ok := w.PRD("PRD-100")
+	if !ok {
+		t.Fatal("Expected PRD-100 in the workspace")
+	}
+
+	links := w.Outbound(epic.PRD, epic.Path)
+	var got []string
+	for _, l := range links {
+		got = append(got, l.String()+" ["+l.Problem+"]")
+	}
+	expected := []string{
+		"requirement FR-001 → PRD-101#FR-001 []",
+		"requirement FR-001 → PRD-101 []",
+		"requirement FR-002 → PRD-101#FR-009 [PRD-101 has no requirement or milestone FR-009]",
+		"requirement FR-002 → PRD-103#FR-001 [PRD-103 is archived]",
+		"requirement FR-002 → PRD-999#FR-001 [PRD-999 is not in the workspace]",
+		"requirement FR-002 → PRD-100#FR-003 [PRD-100 has no requirement or milestone FR-003]",
+		"requirement FR-002 → PRD-998 [PRD-998 is not in the workspace]",
+		"milestone Beta → PRD-102#launch [PRD-102 is defined by both archive/duplicate.json and features/fraud.json]",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected links:\n%s", strings.Join(got, "\n"))
+	}
+	if links[0].ToPath != "features/wallet.json" || links[0].TargetTitle != "Wallet" || links[0].TargetStatus != StatusApproved {
+		t.Errorf("Expected the target to be resolved, got %+v", links[0])
+	}
+	if problems := w.CheckReferences(epic.PRD, epic.Path); len(problems) != 6 {
+		t.Errorf("Expected 6 problems, got %v", problems)
+	}
+
+	inbound := w.Inbound("PRD-101")
+	if len(inbound) != 3 || inbound[0].From != "PRD-100" || inbound[0].FromPath != "epic.json" {
+		t.Errorf("Unexpected inbound links: %+v", inbound)
+	}
+	if inbound := w.Inbound("PRD-100"); len(inbound) != 1 || inbound[0].Source != "FR-001" || inbound[0].Target.Element != "Beta" {
+		t.Errorf("Unexpected inbound links: %+v", inbound)
+	}
+
+	// Bare dependencies only look like PRD IDs with the configured prefix
+	w.IDPrefix = "EPIC-"
+	if links := w.Outbound(epic.PRD, epic.Path); len(links) != len(expected)-1 {
+		t.Errorf("Expected PRD-998 to be skipped with another prefix, got %d links", len(links))
+	}
+}
+
+func TestFindWorkspaceRoot(t *testing.T) {
+	root := writeFixture(t, workspaceFiles)
+	if got := FindWorkspaceRoot(filepath.Join(root, "features", "wallet.json")); got != filepath.Join(root, "features") {
+		t.Errorf("Expected the file's directory without markers, got %s", got)
+	}
+	if err := os.Mkdir(filepath.Join(root, ".prd"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindWorkspaceRoot(filepath.Join(root, "features", "wallet.json")); got != root {
+		t.Errorf("Expected %s, got %s", root, got)
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	p.Requirements.Functional[1].Dependencies = []string{"PRD-042#FR-007", "PRD-001#FR-001"}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected cross-document references to validate, got %v", err)
+	}
+
+	p.Requirements.Functional[1].Dependencies = []string{"PRD-042#"}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "invalid reference 'PRD-042#'") {
+		t.Errorf("Expected invalid reference error, got %v", err)
+	}
+
+	p.Requirements.Functional[1].Dependencies = []string{"PRD-001#FR-404"}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "references unknown element PRD-001#FR-404") {
+		t.Errorf("Expected unknown element error, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package prd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RiskHighScore is the score from which a risk must have an owner and a
+// mitigation strategy
+const RiskHighScore = 8
+
+// RiskHeatMapCell is one probability×impact cell of a risk heat map
+type RiskHeatMapCell struct {
+	Probability RiskLevel
+	Impact      RiskLevel
+	Score       int
+	Rating      RiskLevel
+	// Risks are the IDs of the risks in the cell
+	Risks []string
+}
+
+// RiskHeatMapRow is one probability row of a risk heat map
+type RiskHeatMapRow struct {
+	Probability RiskLevel
+	Cells       []RiskHeatMapCell
+}
+
+// Score returns impact × probability, from 1 to 16, or 0 if either is unset
+func (r Risk) Score() int {
+	return r.Impact.Score() * r.Probability.Score()
+}
+
+// Rating returns the risk level of the risk's score
+func (r Risk) Rating() RiskLevel {
+	return RiskRating(r.Score())
+}
+
+// RiskRating maps a score to a level: 12 and above is critical, from
+// RiskHighScore high, from 4 medium and below that low
+func RiskRating(score int) RiskLevel {
+	switch {
+	case score >= 12:
+		return RiskCritical
+	case score >= RiskHighScore:
+		return RiskHigh
+	case score >= 4:
+		return RiskMedium
+	case score > 0:
+		return RiskLow
+	default:
+		return ""
+	}
+}
+
+// riskScoreLabel formats a risk's score with its rating, e.g. "9 (high)"
+func riskScoreLabel(r Risk) string {
+	if r.Score() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d (%s)", r.Score(), r.Rating())
+}
+
+// RiskID returns the ID of the i-th risk, or "R<n>" if it has none
+func (ra *RisksAndAssumptions) RiskID(i int) string {
+	if id := ra.Risks[i].ID; id != "" {
+		return id
+	}
+	return fmt.Sprintf("R%d", i+1)
+}
+
+// HeatMap places every rated risk that is not closed on a probability×impact
+// grid. Rows run from critical to low probability, cells from low to
+// critical impact.
+func (ra *RisksAndAssumptions) HeatMap() []RiskHeatMapRow {
+	levels := RiskLevel("").Values()
+	rows := make([]RiskHeatMapRow, len(levels))
+	for i := range levels {
+		probability := levels[len(levels)-1-i]
+		rows[i].Probability = probability
+		for _, impact := range levels {
+			score := probability.Score() * impact.Score()
+			rows[i].Cells = append(rows[i].Cells, RiskHeatMapCell{
+				Probability: probability,
+				Impact:      impact,
+				Score:       score,
+				Rating:      RiskRating(score),
+			})
+		}
+	}
+
+	for i, risk := range ra.Risks {
+		if risk.Score() == 0 || risk.Status == RiskClosed {
+			continue
+		}
+		cell := &rows[len(levels)-risk.Probability.Score()].Cells[risk.Impact.Score()-1]
+		cell.Risks = append(cell.Risks, ra.RiskID(i))
+	}
+	return rows
+}
+
+// CheckRisks returns warnings for open risks that are past their review date
+// or that score RiskHighScore or more without a review date
+func (p *PRD) CheckRisks(now time.Time) []string {
+	if p.RisksAndAssumptions == nil {
+		return nil
+	}
+
+	today := DateOf(now)
+	var warnings []string
+	for i, risk := range p.RisksAndAssumptions.Risks {
+		if risk.Status == RiskClosed {
+			continue
+		}
+		id := p.RisksAndAssumptions.RiskID(i)
+		switch {
+		case !risk.ReviewDate.IsZero() && risk.ReviewDate.Before(today):
+			warnings = append(warnings, fmt.Sprintf("Risk %s was due for review on %s", id, risk.ReviewDate))
+		case risk.ReviewDate.IsZero() && risk.Score() >= RiskHighScore:
+			warnings = append(warnings, fmt.Sprintf("Risk %s has score %d but no review date", id, risk.Score()))
+		}
+	}
+	return warnings
+}
+
+// Problems returns every validation problem in the risk register: unknown
+// levels or statuses, and risks that score RiskHighScore or more without a
+// mitigation strategy or owner, unless they are closed
+func (ra *RisksAndAssumptions) Problems() []string {
+	var problems []string
+	for i, risk := range ra.Risks {
+		id := ra.RiskID(i)
+		if risk.Impact != "" && !risk.Impact.Valid() {
+			problems = append(problems, fmt.Sprintf("invalid impact for risk %s: %s", id, risk.Impact))
+		}
+		if risk.Probability != "" && !risk.Probability.Valid() {
+			problems = append(problems, fmt.Sprintf("invalid probability for risk %s: %s", id, risk.Probability))
+		}
+		if risk.Status != "" && !risk.Status.Valid() {
+			problems = append(problems, fmt.Sprintf("invalid status for risk %s: %s", id, risk.Status))
+		}
+		if risk.Score() >= RiskHighScore && risk.Status != RiskClosed {
+			if risk.MitigationStrategy == "" {
+				problems = append(problems, fmt.Sprintf("risk %s has score %d and requires a mitigation strategy", id, risk.Score()))
+			}
+			if risk.Owner == "" {
+				problems = append(problems, fmt.Sprintf("risk %s has score %d and requires an owner", id, risk.Score()))
+			}
+		}
+	}
+	return problems
+}
+
+func (ra *RisksAndAssumptions) validateRisks() error {
+	if problems := ra.Problems(); len(problems) > 0 {
+		return errors.New(problems[0])
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package prd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRiskScore(t *testing.T) {
+	tests := []struct {
+		impact      RiskLevel
+		probability RiskLevel
+		score       int
+		rating      RiskLevel
+	}{
+		{RiskLow, RiskLow, 1, RiskLow},
+		{RiskHigh, RiskMedium, 6, RiskMedium},
+		{RiskCritical, RiskMedium, 8, RiskHigh},
+		{RiskHigh, RiskHigh, 9, RiskHigh},
+		{RiskCritical, RiskCritical, 16, RiskCritical},
+		{RiskHigh, "", 0, ""},
+	}
+	for _, tt := range tests {
+		r := Risk{Impact: tt.impact, Probability: tt.probability}
+		if r.Score() != tt.score || r.Rating() != tt.rating {
+			t.Errorf("%s×%s: got %d (%s), expected %d (%s)", tt.impact, tt.probability, r.Score(), r.Rating(), tt.score, tt.rating)
+		}
+	}
+}
+
+func TestRiskLevelUnmarshalJSON(t *testing.T) {
+	var risk Risk
+	if err := json.Unmarshal([]byte(`{"impact":" High","probability":"MEDIUM","status":"open"}`), &risk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if risk.Impact != RiskHigh || risk.Probability != RiskMedium {
+		t.Errorf("Expected normalized levels, got %s/%s", risk.Impact, risk.Probability)
+	}
+
+	if err := json.Unmarshal([]byte(`{"impact":"severe"}`), &risk); err == nil || !strings.Contains(err.Error(), "invalid risk level 'severe'") {
+		t.Errorf("Expected invalid risk level error, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"status":"resolved"}`), &risk); err == nil || !strings.Contains(err.Error(), "invalid risk status") {
+		t.Errorf("Expected invalid risk status error, got %v", err)
+	}
+}
+
+func TestRiskHeatMap(t *testing.T) {
+	ra := &RisksAndAssumptions{Risks: []Risk{
+		{ID: "RISK-1", Impact: RiskHigh, Probability: RiskCritical},
+		{Impact: RiskHigh, Probability: RiskCritical},
+		{Impact: RiskLow, Probability: RiskLow},
+		{Impact: RiskCritical, Probability: RiskCritical, Status: RiskClosed},
+		{Description: "Unrated"},
+	}}
+
+	rows := ra.HeatMap()
+	if len(rows) != 4 || rows[0].Probability != RiskCritical || rows[3].Probability != RiskLow {
+		t.Fatalf("Expected rows from critical to low probability, got %+v", rows)
+	}
+
+	cell := rows[0].Cells[2]
+	if cell.Impact != RiskHigh || cell.Score != 12 || cell.Rating != RiskCritical || strings.Join(cell.Risks, ",") != "RISK-1,R2" {
+		t.Errorf("Unexpected critical×high cell: %+v", cell)
+	}
+	if got := rows[3].Cells[0].Risks; len(got) != 1 || got[0] != "R3" {
+		t.Errorf("Expected R3 in the low×low cell, got %v", got)
+	}
+	if got := rows[0].Cells[3].Risks; len(got) != 0 {
+		t.Errorf("Expected closed risks to be left off the heat map, got %v", got)
+	}
+}
+
+func TestRiskValidation(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	risk := &p.RisksAndAssumptions.Risks[1]
+	risk.Probability = RiskHigh
+	risk.Owner = ""
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "risk R2 has score 9 and requires an owner") {
+		t.Errorf("Expected missing owner error, got %v", err)
+	}
+
+	risk.Status = RiskClosed
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected closed risks to need no owner, got %v", err)
+	}
+
+	risk.Status = "resolved"
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "invalid status for risk R2") {
+		t.Errorf("Expected invalid status error, got %v", err)
+	}
+}
+
+func TestCheckRisks(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	p := &PRD{RisksAndAssumptions: &RisksAndAssumptions{Risks: []Risk{
+		{ID: "RISK-1", Impact: RiskLow, Probability: RiskLow, ReviewDate: MustParseDate("2025-05")},
+		{ID: "RISK-2", Impact: RiskHigh, Probability: RiskHigh},
+		{ID: "RISK-3", Impact: RiskHigh, Probability: RiskHigh, ReviewDate: MustParseDate("2025-Q3")},
+		{ID: "RISK-4", Impact: RiskLow, Probability: RiskLow, ReviewDate: MustParseDate("2025-01-01"), Status: RiskClosed},
+	}}}
+
+	warnings := p.CheckRisks(now)
+	if len(warnings) != 2 ||
+		!strings.Contains(warnings[0], "RISK-1 was due for review on 2025-05") ||
+		!strings.Contains(warnings[1], "RISK-2 has score 9 but no review date") {
+		t.Errorf("Unexpected warnings: %v", warnings)
+	}
+}
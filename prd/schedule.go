@@ -0,0 +1,334 @@
+package prd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScheduledMilestone is a dated milestone with its computed schedule
+type ScheduledMilestone struct {
+	Name       string
+	TargetDate Date
+	// Dependencies are the milestones of this PRD that must finish first
+	Dependencies []string
+	// External are dependencies that do not name a milestone of this PRD
+	External []string
+	// DurationDays is the time between the latest dependency and the target
+	// date. It is zero for milestones without dependencies.
+	DurationDays int
+	// SlackDays is how far the milestone can slip before it moves the launch
+	// date, or the last milestone when there is no launch date
+	SlackDays int
+	Critical  bool
+	Overdue   bool
+	// ScheduledBefore lists dependencies that are due after this milestone
+	ScheduledBefore []string
+}
+
+// TimelineAnalysis is the result of scheduling a PRD's milestones
+type TimelineAnalysis struct {
+	// Milestones are in dependency order
+	Milestones   []ScheduledMilestone
+	CriticalPath []string
+	// Undated are milestones without a target date, which are not scheduled
+	Undated    []string
+	LaunchDate Date
+	// Finish is the target date of the last milestone
+	Finish time.Time
+	// BufferDays is the time between Finish and the launch date. It is
+	// negative when milestones are due after launch.
+	BufferDays int
+
+	index      map[string]int
+	successors map[string][]string
+}
+
+// MilestoneMove is a milestone pushed back by a slip
+type MilestoneMove struct {
+	Name string
+	// From is the milestone's projected date before the slip: its target
+	// date, or later if it is due before its dependencies
+	From time.Time
+	To   time.Time
+}
+
+// SlipProjection is the effect of moving one milestone on the rest of the
+// timeline
+type SlipProjection struct {
+	Milestone string
+	Days      int
+	// Moved lists the slipped milestone and every dependent it pushes back
+	Moved           []MilestoneMove
+	Launch          time.Time
+	ProjectedLaunch time.Time
+	LaunchSlipDays  int
+}
+
+// Milestone returns the scheduled milestone with the given name, matched
+// case-insensitively
+func (a *TimelineAnalysis) Milestone(name string) (ScheduledMilestone, bool) {
+	i, ok := a.index[milestoneKey(name)]
+	if !ok {
+		return ScheduledMilestone{}, false
+	}
+	return a.Milestones[i], true
+}
+
+// AnalyzeTimeline schedules the PRD's milestones by their dependencies,
+// which name other milestones. It computes the slack of each milestone and
+// the critical path, and flags milestones due before their dependencies or,
+// unless they or the PRD are completed, before today.
+func (p *PRD) AnalyzeTimeline(now time.Time) (*TimelineAnalysis, error) {
+	analysis := &TimelineAnalysis{
+		index:      map[string]int{},
+		successors: map[string][]string{},
+	}
+	if p.Timeline == nil {
+		return analysis, nil
+	}
+	analysis.LaunchDate = p.Timeline.LaunchDate
+
+	byName := map[string]Milestone{}
+	var names []string
+	for _, m := range p.Timeline.Milestones {
+		key := milestoneKey(m.Name)
+		if _, exists := byName[key]; exists {
+			return nil, fmt.Errorf("duplicate milestone name: %s", m.Name)
+		}
+		byName[key] = m
+		if m.TargetDate.IsZero() {
+			analysis.Undated = append(analysis.Undated, m.Name)
+			continue
+		}
+		names = append(names, m.Name)
+	}
+
+	// Resolve dependencies to dated milestones
+	deps := map[string][]string{}
+	external := map[string][]string{}
+	for _, name := range names {
+		for _, dep := range byName[milestoneKey(name)].Dependencies {
+			target, ok := byName[milestoneKey(dep)]
+			switch {
+			case !ok:
+				external[name] = append(external[name], dep)
+			case !target.TargetDate.IsZero() && milestoneKey(dep) != milestoneKey(name):
+				deps[name] = append(deps[name], target.Name)
+				analysis.successors[target.Name] = append(analysis.successors[target.Name], name)
+			}
+		}
+	}
+
+	order, err := topologicalOrder(names, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	today := DateOf(now).Start()
+	finished := p.Status == StatusCompleted || p.Status == StatusArchived
+	due := func(name string) time.Time { return byName[milestoneKey(name)].TargetDate.End() }
+
+	for _, name := range order {
+		m := byName[milestoneKey(name)]
+		s := ScheduledMilestone{
+			Name:         m.Name,
+			TargetDate:   m.TargetDate,
+			Dependencies: deps[name],
+			External:     external[name],
+			Overdue:      !finished && !m.Done() && m.TargetDate.End().Before(today),
+		}
+		if start, ok := latestDue(deps[name], due); ok {
+			s.DurationDays = max(daysBetween(start, due(name)), 0)
+		}
+		for _, dep := range deps[name] {
+			if byName[milestoneKey(dep)].TargetDate.After(m.TargetDate) {
+				s.ScheduledBefore = append(s.ScheduledBefore, dep)
+			}
+		}
+		if d := due(name); d.After(analysis.Finish) {
+			analysis.Finish = d
+		}
+		analysis.index[milestoneKey(name)] = len(analysis.Milestones)
+		analysis.Milestones = append(analysis.Milestones, s)
+	}
+
+	deadline := analysis.Finish
+	if !analysis.LaunchDate.IsZero() {
+		deadline = analysis.LaunchDate.End()
+		if len(order) > 0 {
+			analysis.BufferDays = daysBetween(analysis.Finish, deadline)
+		}
+	}
+
+	// Latest finish dates, walking back from the deadline
+	latest := map[string]time.Time{}
+	for i := len(order) - 1; i >= 0; i-- {
+		name := order[i]
+		lf := deadline
+		for _, succ := range analysis.successors[name] {
+			s := analysis.Milestones[analysis.index[milestoneKey(succ)]]
+			if t := latest[succ].AddDate(0, 0, -s.DurationDays); t.Before(lf) {
+				lf = t
+			}
+		}
+		latest[name] = lf
+		analysis.Milestones[analysis.index[milestoneKey(name)]].SlackDays = daysBetween(due(name), lf)
+	}
+
+	analysis.CriticalPath = analysis.criticalPath()
+	for _, name := range analysis.CriticalPath {
+		analysis.Milestones[analysis.index[milestoneKey(name)]].Critical = true
+	}
+	return analysis, nil
+}
+
+// criticalPath follows the least-slack chain back from the last milestone
+func (a *TimelineAnalysis) criticalPath() []string {
+	better := func(best *ScheduledMilestone, m ScheduledMilestone) bool {
+		if best == nil || m.SlackDays != best.SlackDays {
+			return best == nil || m.SlackDays < best.SlackDays
+		}
+		return m.TargetDate.End().After(best.TargetDate.End())
+	}
+
+	var current *ScheduledMilestone
+	for i, m := range a.Milestones {
+		if len(a.successors[m.Name]) == 0 && better(current, m) {
+			current = &a.Milestones[i]
+		}
+	}
+
+	var path []string
+	for current != nil {
+		path = append([]string{current.Name}, path...)
+		var next *ScheduledMilestone
+		for _, dep := range current.Dependencies {
+			m := a.Milestones[a.index[milestoneKey(dep)]]
+			if better(next, m) {
+				next = &a.Milestones[a.index[milestoneKey(dep)]]
+			}
+		}
+		current = next
+	}
+	return path
+}
+
+// ProjectSlip projects the effect of moving a milestone by days. Dependents
+// move only once the slip exceeds the time they already allow after their
+// dependencies, and the launch moves once the last milestone passes it. A
+// milestone already due before its dependencies is reported only if the
+// slip moves it further, and a launch already overshot by a milestone slips
+// only by the days the slip adds.
+func (a *TimelineAnalysis) ProjectSlip(name string, days int) (*SlipProjection, error) {
+	slipped, ok := a.Milestone(name)
+	if !ok {
+		return nil, fmt.Errorf("milestone '%s' not found or has no target date", name)
+	}
+
+	projection := &SlipProjection{Milestone: slipped.Name, Days: days}
+	baseline := a.projectDates(slipped.Name, 0)
+	dates := a.projectDates(slipped.Name, days)
+
+	var finish, baselineFinish time.Time
+	for _, m := range a.Milestones {
+		if !dates[m.Name].Equal(baseline[m.Name]) {
+			projection.Moved = append(projection.Moved, MilestoneMove{Name: m.Name, From: baseline[m.Name], To: dates[m.Name]})
+		}
+		if dates[m.Name].After(finish) {
+			finish = dates[m.Name]
+		}
+		if baseline[m.Name].After(baselineFinish) {
+			baselineFinish = baseline[m.Name]
+		}
+	}
+
+	projection.Launch = a.Finish
+	if !a.LaunchDate.IsZero() {
+		projection.Launch = a.LaunchDate.End()
+	}
+	projection.ProjectedLaunch = projection.Launch
+	if finish.After(projection.Launch) {
+		projection.ProjectedLaunch = finish
+	}
+	// A milestone already due after the launch is not a slip of this one
+	if baselineFinish.Before(projection.Launch) {
+		baselineFinish = projection.Launch
+	}
+	projection.LaunchSlipDays = max(daysBetween(baselineFinish, projection.ProjectedLaunch), 0)
+	return projection, nil
+}
+
+// projectDates returns the date of each milestone when the named one moves by
+// days, pushing back dependents that would no longer follow their
+// dependencies
+func (a *TimelineAnalysis) projectDates(name string, days int) map[string]time.Time {
+	dates := map[string]time.Time{}
+	for _, m := range a.Milestones {
+		dates[m.Name] = m.TargetDate.End()
+		if m.Name == name {
+			dates[m.Name] = dates[m.Name].AddDate(0, 0, days)
+			continue
+		}
+		if start, ok := latestDue(m.Dependencies, func(n string) time.Time { return dates[n] }); ok {
+			if needed := start.AddDate(0, 0, m.DurationDays); needed.After(dates[m.Name]) {
+				dates[m.Name] = needed
+			}
+		}
+	}
+	return dates
+}
+
+// topologicalOrder sorts names so that dependencies come first, keeping the
+// original order where possible. It fails on a dependency cycle.
+func topologicalOrder(names []string, deps map[string][]string) ([]string, error) {
+	done := map[string]bool{}
+	var order []string
+	for len(order) < len(names) {
+		progressed := false
+		for _, name := range names {
+			if done[name] {
+				continue
+			}
+			ready := true
+			for _, dep := range deps[name] {
+				ready = ready && done[dep]
+			}
+			if ready {
+				done[name] = true
+				order = append(order, name)
+				progressed = true
+			}
+		}
+		if !progressed {
+			var cycle []string
+			for _, name := range names {
+				if !done[name] {
+					cycle = append(cycle, name)
+				}
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("milestone dependency cycle between: %s", strings.Join(cycle, ", "))
+		}
+	}
+	return order, nil
+}
+
+func latestDue(names []string, due func(string) time.Time) (time.Time, bool) {
+	var latest time.Time
+	for _, name := range names {
+		if d := due(name); d.After(latest) {
+			latest = d
+		}
+	}
+	return latest, len(names) > 0
+}
+
+func milestoneKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
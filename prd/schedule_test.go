@@ -0,0 +1,188 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func scheduleFixture() *PRD {
+	return &PRD{
+		Status: StatusInDevelopment,
+		Timeline: &Timeline{
+			LaunchDate: MustParseDate("2025-06-30"),
+			Milestones: []Milestone{
+				{Name: "Design", TargetDate: MustParseDate("2025-03-01")},
+				{Name: "Backend", TargetDate: MustParseDate("2025-04-15"), Dependencies: []string{"Design"}},
+				{Name: "Docs", TargetDate: MustParseDate("2025-04-01"), Dependencies: []string{"design"}},
+				{Name: "Beta", TargetDate: MustParseDate("2025-06-01"), Dependencies: []string{"Backend", "Docs", "PRD-002"}},
+				{Name: "Marketing"},
+			},
+		},
+	}
+}
+
+func TestAnalyzeTimeline(t *testing.T) {
+	now := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	analysis, err := scheduleFixture().AnalyzeTimeline(now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := strings.Join(analysis.CriticalPath, ","); got != "Design,Backend,Beta" {
+		t.Errorf("Unexpected critical path: %s", got)
+	}
+	if analysis.BufferDays != 29 {
+		t.Errorf("Expected 29 days of buffer before launch, got %d", analysis.BufferDays)
+	}
+	if strings.Join(analysis.Undated, ",") != "Marketing" {
+		t.Errorf("Expected Marketing to be undated, got %v", analysis.Undated)
+	}
+
+	tests := []struct {
+		name     string
+		slack    int
+		critical bool
+		overdue  bool
+	}{
+		{"Design", 29, true, true},
+		{"Backend", 29, true, false},
+		{"Docs", 43, false, true},
+		{"Beta", 29, true, false},
+	}
+	for _, tt := range tests {
+		m, ok := analysis.Milestone(tt.name)
+		if !ok {
+			t.Fatalf("Milestone %s not scheduled", tt.name)
+		}
+		if m.SlackDays != tt.slack || m.Critical != tt.critical || m.Overdue != tt.overdue {
+			t.Errorf("%s: slack=%d critical=%v overdue=%v, expected %d %v %v",
+				tt.name, m.SlackDays, m.Critical, m.Overdue, tt.slack, tt.critical, tt.overdue)
+		}
+	}
+
+	beta, _ := analysis.Milestone("beta")
+	if strings.Join(beta.External, ",") != "PRD-002" || beta.DurationDays != 47 {
+		t.Errorf("Unexpected Beta schedule: %+v", beta)
+	}
+}
+
+func TestAnalyzeTimelineScheduledBefore(t *testing.T) {
+	p := scheduleFixture()
+	p.Timeline.Milestones[1].TargetDate = MustParseDate("2025-02")
+
+	analysis, err := p.AnalyzeTimeline(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	backend, _ := analysis.Milestone("Backend")
+	if strings.Join(backend.ScheduledBefore, ",") != "Design" {
+		t.Errorf("Expected Backend to be flagged as before Design, got %v", backend.ScheduledBefore)
+	}
+
+	p.Timeline.Milestones[0].Dependencies = []string{"Beta"}
+	if _, err := p.AnalyzeTimeline(time.Now()); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Expected cycle error, got %v", err)
+	}
+}
+
+func TestProjectSlip(t *testing.T) {
+	analysis, err := scheduleFixture().AnalyzeTimeline(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		milestone string
+		days      int
+		moved     string
+		slip      int
+	}{
+		{"Docs", 14, "Docs", 0},
+		{"Docs", 21, "Docs,Beta", 0},
+		{"Backend", 20, "Backend,Beta", 0},
+		{"Design", 45, "Design,Backend,Docs,Beta", 16},
+	}
+	for _, tt := range tests {
+		projection, err := analysis.ProjectSlip(tt.milestone, tt.days)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var moved []string
+		for _, m := range projection.Moved {
+			moved = append(moved, m.Name)
+		}
+		if strings.Join(moved, ",") != tt.moved || projection.LaunchSlipDays != tt.slip {
+			t.Errorf("%s +%d: moved %v with launch slip %d, expected %s and %d",
+				tt.milestone, tt.days, moved, projection.LaunchSlipDays, tt.moved, tt.slip)
+		}
+	}
+
+	// Review is already due before Backend: only slips of Backend move it
+	p := scheduleFixture()
+	p.Timeline.Milestones = append(p.Timeline.Milestones, Milestone{Name: "Review", TargetDate: MustParseDate("2025-04-10"), Dependencies: []string{"Backend"}})
+	conflicted, err := p.AnalyzeTimeline(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, tt := range []struct {
+		milestone string
+		days      int
+		moved     string
+	}{
+		{"Design", 0, ""},
+		{"Docs", 14, "Docs"},
+		{"Backend", 5, "Backend,Beta,Review"},
+	} {
+		projection, err := conflicted.ProjectSlip(tt.milestone, tt.days)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var moved []string
+		for _, m := range projection.Moved {
+			moved = append(moved, m.Name)
+		}
+		if strings.Join(moved, ",") != tt.moved {
+			t.Errorf("%s +%d: moved %v, expected %s", tt.milestone, tt.days, moved, tt.moved)
+		}
+	}
+
+	// Review moves from where Backend already pushes it, not its target date
+	projection, err := conflicted.ProjectSlip("Backend", 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if review := projection.Moved[2]; review.From.Format("2006-01-02") != "2025-04-15" || review.To.Format("2006-01-02") != "2025-04-20" {
+		t.Errorf("Unexpected move of Review: %s → %s", review.From.Format("2006-01-02"), review.To.Format("2006-01-02"))
+	}
+
+	// Audit is already due after launch: only slips past it move the launch
+	p = scheduleFixture()
+	p.Timeline.Milestones = append(p.Timeline.Milestones, Milestone{Name: "Audit", TargetDate: MustParseDate("2025-07-31")})
+	overshot, err := p.AnalyzeTimeline(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, tt := range []struct {
+		milestone string
+		days      int
+		slip      int
+	}{
+		{"Docs", 0, 0},
+		{"Design", 45, 0},
+		{"Audit", 10, 10},
+		{"Design", 75, 15},
+	} {
+		projection, err := overshot.ProjectSlip(tt.milestone, tt.days)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if projection.LaunchSlipDays != tt.slip {
+			t.Errorf("%s +%d: launch slip %d, expected %d", tt.milestone, tt.days, projection.LaunchSlipDays, tt.slip)
+		}
+	}
+
+	if _, err := analysis.ProjectSlip("Marketing", 7); err == nil {
+		t.Error("Expected error for an undated milestone")
+	}
+}
@@ -0,0 +1,551 @@
+package prd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// searchIndexVersion changes whenever the index format or tokenizer does,
+// so older indexes are rebuilt rather than misread
+const searchIndexVersion = 1
+
+const (
+	// snippetLength is the approximate length of a search snippet in runes
+	snippetLength = 160
+	// maxSearchMatches is the number of matching fields kept per result
+	maxSearchMatches = 3
+)
+
+// searchStopWords are left out of the index and ignored in queries
+var searchStopWords = map[string]bool{
+	"an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "this": true,
+	"to": true, "was": true, "with": true,
+}
+
+// SearchIndex is an on-disk inverted index over the text fields of the PRDs
+// in a directory tree. Update brings it up to date, re-reading only files
+// whose modification time or size changed and re-indexing only those whose
+// content hash changed.
+type SearchIndex struct {
+	Version int `json:"version"`
+	// Documents are keyed by path relative to the indexed directory
+	Documents map[string]*IndexedPRD `json:"documents"`
+	// Terms maps each term to the fields that contain it
+	Terms map[string][]Posting `json:"terms"`
+
+	path  string
+	dirty bool
+}
+
+// IndexedPRD is a file in a search index
+type IndexedPRD struct {
+	ID      string    `json:"id,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	Status  Status    `json:"status,omitempty"`
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Hash    string    `json:"hash"`
+	// Ignored is set for JSON files that are not PRDs
+	Ignored bool `json:"ignored,omitempty"`
+	// Error is why a file that looks like a PRD could not be loaded
+	Error  string         `json:"error,omitempty"`
+	Fields []IndexedField `json:"fields,omitempty"`
+}
+
+// IndexedField is a text value in a PRD, located by the same paths as
+// query matches
+type IndexedField struct {
+	Path string `json:"path"`
+	// ID is the id of the nearest enclosing element that has one
+	ID   string `json:"id,omitempty"`
+	Text string `json:"text"`
+}
+
+// Posting records how often a term occurs in one field of a document
+type Posting struct {
+	Doc   string `json:"d"`
+	Field int    `json:"f"`
+	Count int    `json:"n"`
+}
+
+// IndexUpdate reports what SearchIndex.Update changed
+type IndexUpdate struct {
+	Added     int              `json:"added"`
+	Updated   int              `json:"updated"`
+	Removed   int              `json:"removed"`
+	Unchanged int              `json:"unchanged"`
+	Errors    []PortfolioError `json:"errors,omitempty"`
+}
+
+// SearchResult is a PRD that matched a search, with its best matching fields
+type SearchResult struct {
+	Path    string        `json:"path"`
+	ID      string        `json:"id"`
+	Title   string        `json:"title"`
+	Status  Status        `json:"status"`
+	Score   float64       `json:"score"`
+	Matches []SearchMatch `json:"matches"`
+}
+
+// SearchMatch is a field that matched a search
+type SearchMatch struct {
+	Path    string `json:"path"`
+	ID      string `json:"id,omitempty"`
+	Snippet string `json:"snippet"`
+	// Highlights are the byte ranges of matching words in Snippet
+	Highlights [][2]int `json:"highlights"`
+	score      float64
+}
+
+// DefaultSearchIndexPath returns where the search index of a directory is
+// kept
+func DefaultSearchIndexPath(root string) string {
+	return filepath.Join(root, ".prd", "index.json")
+}
+
+// OpenSearchIndex reads the search index at path. A missing index, or one
+// written by another version, opens empty and is rebuilt by Update.
+func OpenSearchIndex(path string) (*SearchIndex, error) {
+	ix := &SearchIndex{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, ix); err != nil {
+			return nil, fmt.Errorf("failed to parse search index %s: %w", path, err)
+		}
+	}
+	if ix.Version != searchIndexVersion || ix.Documents == nil {
+		ix.Reset()
+	}
+	return ix, nil
+}
+
+// Reset empties the index so the next Update rebuilds it
+func (ix *SearchIndex) Reset() {
+	ix.Version = searchIndexVersion
+	ix.Documents = map[string]*IndexedPRD{}
+	ix.Terms = map[string][]Posting{}
+	ix.dirty = true
+}
+
+// Save writes the index if Update changed it
+func (ix *SearchIndex) Save() error {
+	if !ix.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0750); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+	tmp := ix.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := os.Rename(tmp, ix.path); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	ix.dirty = false
+	return nil
+}
+
+// Update indexes new and changed PRDs under root and drops deleted ones
+func (ix *SearchIndex) Update(root string, opts PortfolioOptions) (IndexUpdate, error) {
+	var update IndexUpdate
+	seen := map[string]bool{}
+	err := walkPRDFiles(root, opts, func(rel, path string) {
+		seen[rel] = true
+		old := ix.Documents[rel]
+
+		info, err := os.Stat(path)
+		if err == nil && old != nil && old.ModTime.Equal(info.ModTime()) && old.Size == info.Size() {
+			update.Unchanged++
+			return
+		}
+		var data []byte
+		if err == nil {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			ix.set(rel, &IndexedPRD{Error: err.Error()})
+			update.Updated++
+			return
+		}
+
+		sum := sha256.Sum256(data)
+		doc := &IndexedPRD{ModTime: info.ModTime(), Size: info.Size(), Hash: hex.EncodeToString(sum[:])}
+		if old != nil && old.Hash == doc.Hash {
+			old.ModTime, old.Size = doc.ModTime, doc.Size
+			ix.dirty = true
+			update.Unchanged++
+			return
+		}
+
+		p, err := parsePRDFile(data)
+		switch {
+		case err != nil:
+			doc.Error = err.Error()
+		case p == nil:
+			doc.Ignored = true
+		default:
+			doc.ID, doc.Title, doc.Status = p.ID, p.Title, p.Status
+			if doc.Fields, err = prdTextFields(p); err != nil {
+				doc.Error = err.Error()
+			}
+		}
+		ix.set(rel, doc)
+		if old == nil {
+			update.Added++
+		} else {
+			update.Updated++
+		}
+	})
+	if err != nil {
+		return update, err
+	}
+
+	for rel := range ix.Documents {
+		if !seen[rel] {
+			ix.set(rel, nil)
+			update.Removed++
+		}
+	}
+	for rel, doc := range ix.Documents {
+		if doc.Error != "" {
+			update.Errors = append(update.Errors, PortfolioError{Path: rel, Error: doc.Error})
+		}
+	}
+	sort.Slice(update.Errors, func(i, j int) bool { return update.Errors[i].Path < update.Errors[j].Path })
+	return update, nil
+}
+
+// set replaces the document at rel, removing it if doc is nil
+func (ix *SearchIndex) set(rel string, doc *IndexedPRD) {
+	ix.dirty = true
+	if old := ix.Documents[rel]; old != nil {
+		for term := range documentTerms(old) {
+			postings := ix.Terms[term][:0]
+			for _, p := range ix.Terms[term] {
+				if p.Doc != rel {
+					postings = append(postings, p)
+				}
+			}
+			if len(postings) == 0 {
+				delete(ix.Terms, term)
+			} else {
+				ix.Terms[term] = postings
+			}
+		}
+		delete(ix.Documents, rel)
+	}
+	if doc == nil {
+		return
+	}
+
+	ix.Documents[rel] = doc
+	for i, f := range doc.Fields {
+		counts := map[string]int{}
+		for _, t := range searchTokens(f.Text) {
+			counts[t.term]++
+		}
+		for term, n := range counts {
+			ix.Terms[term] = append(ix.Terms[term], Posting{Doc: rel, Field: i, Count: n})
+		}
+	}
+}
+
+// Search returns the PRDs containing every word of the query, best first.
+// Query words of three or more letters also match longer words they begin,
+// so "auth" finds "authentication", at a lower score than an exact match.
+func (ix *SearchIndex) Search(query string, limit int) []SearchResult {
+	var words []string
+	for _, t := range searchTokens(query) {
+		if !slices.Contains(words, t.term) {
+			words = append(words, t.term)
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	type fieldKey struct {
+		doc   string
+		field int
+	}
+	// A document scores each word by its best field, so long documents do
+	// not outrank short ones by repeating a word
+	fieldScores := map[fieldKey]float64{}
+	var docScores map[string]float64
+	total := float64(len(ix.Documents))
+	for _, word := range words {
+		wordFields := map[fieldKey]float64{}
+		for term, postings := range ix.Terms {
+			weight := 1.0
+			if term != word {
+				if utf8.RuneCountInString(word) < 3 || !strings.HasPrefix(term, word) {
+					continue
+				}
+				weight = 0.5
+			}
+			termDocs := map[string]bool{}
+			for _, p := range postings {
+				termDocs[p.Doc] = true
+			}
+			idf := math.Log(1 + total/float64(len(termDocs)))
+			for _, p := range postings {
+				tf := float64(p.Count) / (float64(p.Count) + 1.2)
+				field := ix.Documents[p.Doc].Fields[p.Field]
+				wordFields[fieldKey{p.Doc, p.Field}] += weight * tf * idf * searchFieldWeight(field.Path)
+			}
+		}
+
+		best := map[string]float64{}
+		for key, score := range wordFields {
+			fieldScores[key] += score
+			best[key.doc] = max(best[key.doc], score)
+		}
+		if docScores == nil {
+			docScores = best
+			continue
+		}
+		for doc := range docScores {
+			if score, ok := best[doc]; ok {
+				docScores[doc] += score
+			} else {
+				delete(docScores, doc)
+			}
+		}
+	}
+
+	results := map[string]*SearchResult{}
+	for key, score := range fieldScores {
+		docScore, ok := docScores[key.doc]
+		if !ok {
+			continue
+		}
+		doc := ix.Documents[key.doc]
+		r := results[key.doc]
+		if r == nil {
+			r = &SearchResult{Path: key.doc, ID: doc.ID, Title: doc.Title, Status: doc.Status, Score: math.Round(docScore*1000) / 1000}
+			results[key.doc] = r
+		}
+		field := doc.Fields[key.field]
+		snippet, highlights := searchSnippet(field.Text, words)
+		r.Matches = append(r.Matches, SearchMatch{Path: field.Path, ID: field.ID, Snippet: snippet, Highlights: highlights, score: score})
+	}
+
+	ranked := make([]SearchResult, 0, len(results))
+	for _, r := range results {
+		sort.Slice(r.Matches, func(i, j int) bool {
+			if r.Matches[i].score != r.Matches[j].score {
+				return r.Matches[i].score > r.Matches[j].score
+			}
+			return r.Matches[i].Path < r.Matches[j].Path
+		})
+		if len(r.Matches) > maxSearchMatches {
+			r.Matches = r.Matches[:maxSearchMatches]
+		}
+		ranked = append(ranked, *r)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Path < ranked[j].Path
+	})
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// searchFieldWeight favours matches in titles and IDs
+func searchFieldWeight(path string) float64 {
+	switch path {
+	case "title", "id":
+		return 3
+	}
+	if strings.HasSuffix(path, ".title") || strings.HasSuffix(path, ".name") {
+		return 1.5
+	}
+	return 1
+}
+
+// documentTerms returns the distinct terms of a document's fields
+func documentTerms(doc *IndexedPRD) map[string]bool {
+	terms := map[string]bool{}
+	for _, f := range doc.Fields {
+		for _, t := range searchTokens(f.Text) {
+			terms[t.term] = true
+		}
+	}
+	return terms
+}
+
+// prdTextFields returns the text values of a PRD with their paths
+func prdTextFields(p *PRD) ([]IndexedField, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PRD: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PRD: %w", err)
+	}
+
+	var fields []IndexedField
+	var walk func(v any, path, id string)
+	walk = func(v any, path, id string) {
+		switch v := v.(type) {
+		case string:
+			if strings.TrimSpace(v) != "" {
+				fields = append(fields, IndexedField{Path: path, ID: id, Text: v})
+			}
+		case []any:
+			for i, e := range v {
+				elementID := id
+				if m, ok := e.(map[string]any); ok {
+					if s, ok := m["id"].(string); ok && s != "" {
+						elementID = s
+					}
+				}
+				walk(e, fmt.Sprintf("%s[%d]", path, i), elementID)
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				walk(v[k], joinQueryPath(path, k), id)
+			}
+		}
+	}
+	walk(doc, "", "")
+	return fields, nil
+}
+
+// searchToken is an indexed word and its byte range in the source text
+type searchToken struct {
+	term       string
+	start, end int
+}
+
+// searchTokens splits text into lower-case words of two or more letters or
+// digits, leaving out stop words
+func searchTokens(text string) []searchToken {
+	var tokens []searchToken
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		term := strings.ToLower(text[start:end])
+		if utf8.RuneCountInString(term) >= 2 && !searchStopWords[term] {
+			tokens = append(tokens, searchToken{term: term, start: start, end: end})
+		}
+		start = -1
+	}
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+		} else {
+			flush(i)
+		}
+	}
+	flush(len(text))
+	return tokens
+}
+
+// searchSnippet returns the part of text around the first word matching the
+// query, with the byte ranges of the matching words in it
+func searchSnippet(text string, words []string) (string, [][2]int) {
+	text = strings.Join(strings.Fields(text), " ")
+	var hits []searchToken
+	for _, t := range searchTokens(text) {
+		for _, w := range words {
+			if t.term == w || utf8.RuneCountInString(w) >= 3 && strings.HasPrefix(t.term, w) {
+				hits = append(hits, t)
+				break
+			}
+		}
+	}
+
+	start, end := 0, len(text)
+	if utf8.RuneCountInString(text) > snippetLength {
+		if len(hits) > 0 {
+			start = max(hits[0].start-snippetLength/4, 0)
+		}
+		start = snapToWord(text, start, false)
+		end = start
+		for n := 0; end < len(text) && n < snippetLength; n++ {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+		}
+		end = snapToWord(text, end, true)
+	}
+
+	var b strings.Builder
+	prefix := 0
+	if start > 0 {
+		b.WriteString("…")
+		prefix = len("…")
+	}
+	b.WriteString(text[start:end])
+	if end < len(text) {
+		b.WriteString("…")
+	}
+
+	var highlights [][2]int
+	for _, h := range hits {
+		if h.start >= start && h.end <= end {
+			highlights = append(highlights, [2]int{h.start - start + prefix, h.end - start + prefix})
+		}
+	}
+	return b.String(), highlights
+}
+
+// snapToWord moves a snippet boundary to the nearest space, backwards for
+// a start and forwards for an end, so words are not cut in half
+func snapToWord(text string, i int, forward bool) int {
+	if i <= 0 || i >= len(text) {
+		return i
+	}
+	if forward {
+		if j := strings.IndexByte(text[i:], ' '); j >= 0 && j < 20 {
+			return i + j
+		}
+		for i < len(text) && !utf8.RuneStart(text[i]) {
+			i++
+		}
+		return i
+	}
+	if j := strings.LastIndexByte(text[:i], ' '); j >= 0 && i-j < 20 {
+		return j + 1
+	}
+	for i > 0 && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	return i
+}
@@ -0,0 +1,127 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func searchPaths(results []SearchResult) string {
+	var paths []string
+	for _, r := range results {
+		paths = append(paths, r.Path)
+	}
+	return strings.Join(paths, ",")
+}
+
+func TestSearchIndex(t *testing.T) {
+	root := writeFixture(t, portfolioFiles)
+	example, err := os.ReadFile("example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "mobile.json"), example, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	indexPath := DefaultSearchIndexPath(root)
+	ix, err := OpenSearchIndex(indexPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	update, err := ix.Update(root, DefaultPortfolioOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if update.Added != 9 || len(update.Errors) != 2 || update.Errors[0].Path != "broken.json" {
+		t.Errorf("Unexpected update: %+v", update)
+	}
+	if err := ix.Save(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"checkout", "payments/checkout.json"},
+		{"REFUNDS", "payments/refunds.json"},
+		{"biometric fingerprint", "mobile.json"},
+		{"biometric checkout", ""},
+		{"auth", "auth.json,mobile.json"},
+		{"the and of", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := searchPaths(ix.Search(tt.query, 10)); got != tt.expected {
+				t.Errorf("Got %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+
+	results := ix.Search("fingerprint", 10)
+	if len(results) != 1 || len(results[0].Matches) != maxSearchMatches {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	m := results[0].Matches[0]
+	if m.ID != "FR-001" || m.Path != "requirements.functional[0].description" || len(m.Highlights) == 0 {
+		t.Errorf("Expected a match inside an element, got %+v", m)
+	}
+	if h := m.Highlights[0]; !strings.EqualFold(m.Snippet[h[0]:h[1]], "fingerprint") {
+		t.Errorf("Highlight %v covers %q in %q", h, m.Snippet[h[0]:h[1]], m.Snippet)
+	}
+
+	// Reopen, then change one file and delete another
+	ix, err = OpenSearchIndex(indexPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkout := filepath.Join(root, "payments", "checkout.json")
+	if err := os.WriteFile(checkout, []byte(`{"id":"PRD-001","title":"One-click purchase","status":"draft","priority":"high","owner":{"name":"Raj Patel","email":"raj@example.com"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(checkout, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(root, "auth.json")); err != nil {
+		t.Fatal(err)
+	}
+	update, err = ix.Update(root, DefaultPortfolioOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if update.Added != 0 || update.Updated != 1 || update.Removed != 1 || update.Unchanged != 7 {
+		t.Errorf("Unexpected incremental update: %+v", update)
+	}
+	if got := searchPaths(ix.Search("checkout", 10)); got != "" {
+		t.Errorf("Expected stale terms to be dropped, got %q", got)
+	}
+	if got := searchPaths(ix.Search("purchase", 10)); got != "payments/checkout.json" {
+		t.Errorf("Expected the updated file to be searchable, got %q", got)
+	}
+	if _, ok := ix.Terms["jane"]; !ok {
+		t.Error("Expected terms still used by other PRDs to be kept")
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	long := strings.Repeat("filler words here ", 20) + "supports single sign-on via SAML " + strings.Repeat("trailing text ", 20)
+	snippet, highlights := searchSnippet(long, []string{"saml", "sign"})
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") || len(snippet) > 250 {
+		t.Errorf("Unexpected snippet: %q", snippet)
+	}
+	var words []string
+	for _, h := range highlights {
+		words = append(words, snippet[h[0]:h[1]])
+	}
+	if strings.Join(words, ",") != "sign,SAML" {
+		t.Errorf("Unexpected highlights %v in %q", words, snippet)
+	}
+
+	if snippet, _ := searchSnippet("Short\n  text", []string{"text"}); snippet != "Short text" {
+		t.Errorf("Expected whitespace collapsed, got %q", snippet)
+	}
+}
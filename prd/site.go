@@ -0,0 +1,259 @@
+package prd
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SiteOptions configures static site generation
+type SiteOptions struct {
+	// Title is shown on the index page. Defaults to "Product Requirements".
+	Title string
+	// GeneratedAt, when set, adds a footer with the generation time
+	GeneratedAt *time.Time
+}
+
+// siteIndex is the data passed to the index template
+type siteIndex struct {
+	Title       string
+	CSS         template.CSS
+	GeneratedAt string
+	Entries     []siteEntry
+}
+
+// siteEntry is one PRD on the index page and in the search index
+type siteEntry struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Owner  string `json:"owner"`
+	URL    string `json:"url"`
+	Text   string `json:"text"`
+}
+
+var siteIndexTemplate = template.Must(template.New("index").Parse(siteIndexHTML))
+
+// BuildSite writes a self-contained static HTML site for the given PRDs into
+// dir: an index page with client-side search and one page per PRD. PRDs that
+// reference each other's IDs in requirement or milestone dependencies are
+// cross-linked.
+func BuildSite(dir string, docs []*PRD, opts SiteOptions) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create site directory %s: %w", dir, err)
+	}
+
+	sorted := make([]*PRD, len(docs))
+	copy(sorted, docs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	byID := map[string]*PRD{}
+	byFilename := map[string]string{}
+	for _, doc := range sorted {
+		if _, exists := byID[doc.ID]; exists {
+			return fmt.Errorf("duplicate PRD ID: %s", doc.ID)
+		}
+		byID[doc.ID] = doc
+
+		// Compared case-insensitively for case-insensitive file systems
+		filename := strings.ToLower(SitePageFilename(doc.ID))
+		if other, exists := byFilename[filename]; exists {
+			return fmt.Errorf("PRD IDs %s and %s have the same page file name %s", other, doc.ID, SitePageFilename(doc.ID))
+		}
+		byFilename[filename] = doc.ID
+	}
+
+	dependsOn := map[string][]string{}
+	usedBy := map[string][]string{}
+	for _, doc := range sorted {
+		for _, ref := range referencedPRDs(doc, byID) {
+			dependsOn[doc.ID] = append(dependsOn[doc.ID], ref)
+			usedBy[ref] = append(usedBy[ref], doc.ID)
+		}
+	}
+
+	links := func(ids []string) []HTMLLink {
+		var result []HTMLLink
+		for _, id := range ids {
+			result = append(result, HTMLLink{
+				Title: fmt.Sprintf("%s: %s", id, byID[id].Title),
+				URL:   SitePageFilename(id),
+			})
+		}
+		return result
+	}
+
+	index := siteIndex{
+		Title: opts.Title,
+		CSS:   template.CSS(htmlCSS),
+	}
+	if index.Title == "" {
+		index.Title = "Product Requirements"
+	}
+	if opts.GeneratedAt != nil {
+		index.GeneratedAt = opts.GeneratedAt.Format("January 2, 2006 at 3:04 PM")
+	}
+
+	for _, doc := range sorted {
+		page := newHTMLPage(doc, HTMLOptions{GeneratedAt: opts.GeneratedAt})
+		page.InSite = true
+		page.IndexURL = "index.html"
+		page.DependsOn = links(dependsOn[doc.ID])
+		page.UsedBy = links(usedBy[doc.ID])
+
+		filename := filepath.Join(dir, SitePageFilename(doc.ID))
+		if err := writeHTMLFile(filename, page); err != nil {
+			return err
+		}
+
+		index.Entries = append(index.Entries, siteEntry{
+			ID:     doc.ID,
+			Title:  doc.Title,
+			Status: string(doc.Status),
+			Owner:  doc.Owner.Name,
+			URL:    SitePageFilename(doc.ID),
+			Text:   strings.ToLower(searchText(doc)),
+		})
+	}
+
+	f, err := os.Create(filepath.Join(dir, "index.html"))
+	if err != nil {
+		return fmt.Errorf("failed to create index page: %w", err)
+	}
+	if err := siteIndexTemplate.Execute(f, index); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to render index page: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write index page: %w", err)
+	}
+	return nil
+}
+
+// SitePageFilename returns the file name used for a PRD page in a static
+// site. Characters other than letters, digits, "-", "_" and "." become "_",
+// so distinct IDs such as PRD/1 and PRD_1 can share a file name.
+func SitePageFilename(id string) string {
+	var b strings.Builder
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+			b.WriteRune(c)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String() + ".html"
+}
+
+func writeHTMLFile(filename string, page htmlPage) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", filename, err)
+	}
+	if err := writeHTMLPage(f, page); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filename, err)
+	}
+	return nil
+}
+
+// referencedPRDs returns the IDs of other known PRDs named in the
+// requirement or milestone dependencies of p, either bare ("PRD-002") or
+// with an element suffix ("PRD-002#FR-001").
+func referencedPRDs(p *PRD, known map[string]*PRD) []string {
+	seen := map[string]bool{}
+	var refs []string
+	for _, dep := range p.dependencies() {
+		ref, _ := ParseReference(dep.target)
+		id := ref.PRD
+		if id == p.ID || seen[id] || known[id] == nil {
+			continue
+		}
+		seen[id] = true
+		refs = append(refs, id)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// searchText collects the searchable text of a PRD
+func searchText(p *PRD) string {
+	parts := []string{
+		p.ID, p.Title, string(p.Status), string(p.Priority), p.Owner.Name, p.Owner.Team,
+		p.Overview.ProblemStatement, p.Overview.SolutionSummary, p.Overview.TargetAudience,
+	}
+	parts = append(parts, p.Objectives.BusinessGoals...)
+	for _, story := range p.UserStories {
+		parts = append(parts, story.ID, story.Story)
+	}
+	for _, req := range p.Requirements.Functional {
+		parts = append(parts, req.ID, req.Description)
+	}
+	for _, req := range p.Requirements.NonFunctional {
+		parts = append(parts, req.ID, req.Description)
+	}
+	return strings.Join(parts, " ")
+}
+
+const siteIndexHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>{{.CSS}}</style>
+</head>
+<body>
+    <div class="header">
+        <h1>{{.Title}}</h1>
+        <div class="metadata"><span class="badge">{{len .Entries}} PRDs</span></div>
+    </div>
+
+    <section class="section">
+        <input type="search" id="search" placeholder="Search PRDs..." autofocus>
+        <table>
+            <thead>
+                <tr><th>ID</th><th>Title</th><th>Status</th><th>Owner</th></tr>
+            </thead>
+            <tbody id="results">
+                {{- range $i, $e := .Entries}}
+                <tr data-index="{{$i}}"><td><a href="{{$e.URL}}">{{$e.ID}}</a></td><td><a href="{{$e.URL}}">{{$e.Title}}</a></td><td>{{$e.Status}}</td><td>{{$e.Owner}}</td></tr>
+                {{- end}}
+            </tbody>
+        </table>
+        <p id="no-results" hidden>No matching PRDs.</p>
+    </section>
+{{- if .GeneratedAt}}
+
+    <footer>
+        <p>Generated on {{.GeneratedAt}}</p>
+    </footer>
+{{- end}}
+
+    <script>
+        var entries = {{.Entries}};
+        var input = document.getElementById("search");
+        var rows = document.querySelectorAll("#results tr");
+        input.addEventListener("input", function () {
+            var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+            var shown = 0;
+            rows.forEach(function (row) {
+                var text = entries[row.getAttribute("data-index")].text;
+                var match = terms.every(function (t) { return text.indexOf(t) !== -1; });
+                row.hidden = !match;
+                if (match) { shown++; }
+            });
+            document.getElementById("no-results").hidden = shown > 0;
+        });
+    </script>
+</body>
+</html>
+`
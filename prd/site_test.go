@@ -0,0 +1,98 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildSite(t *testing.T) {
+	base, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	dependent := *base
+	dependent.ID = "PRD-002"
+	dependent.Title = "Dependent Feature"
+	dependent.Requirements = Requirements{
+		Functional: []FunctionalRequirement{
+			{ID: "FR-001", Description: "Reuse login", Dependencies: []string{"PRD-001#FR-001"}},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := BuildSite(dir, []*PRD{&dependent, base}, SiteOptions{}); err != nil {
+		t.Fatalf("Failed to build site: %v", err)
+	}
+
+	for _, name := range []string{"index.html", "PRD-001.html", "PRD-002.html"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to exist: %v", name, err)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "PRD-001.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(page), `<a href="PRD-002.html">PRD-002: Dependent Feature</a>`) {
+		t.Error("Expected PRD-001 page to link to dependent PRD-002")
+	}
+
+	if err := BuildSite(dir, []*PRD{base, base}, SiteOptions{}); err == nil {
+		t.Error("Expected error for duplicate PRD IDs")
+	}
+
+	for _, ids := range [][2]string{{"PRD/1", "PRD_1"}, {"prd-001", "PRD-001"}} {
+		a, b := *base, *base
+		a.ID, b.ID = ids[0], ids[1]
+		if err := BuildSite(t.TempDir(), []*PRD{&a, &b}, SiteOptions{}); err == nil || !strings.Contains(err.Error(), "have the same page file name") {
+			t.Errorf("Expected a file name collision between %s and %s, got %v", ids[0], ids[1], err)
+		}
+	}
+}
+
+func TestBuildSiteIndex(t *testing.T) {
+	p := &PRD{
+		ID:     "PRD-001",
+		Title:  `Login <script>alert("x")</script>`,
+		Status: StatusApproved,
+		Owner:  Owner{Name: "Ana & Raj"},
+		Overview: Overview{
+			ProblemStatement: "Users abandon </script> sign-up",
+		},
+	}
+	generated := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	dir := t.TempDir()
+	if err := BuildSite(dir, []*PRD{p}, SiteOptions{Title: "Payments PRDs", GeneratedAt: &generated}); err != nil {
+		t.Fatalf("Failed to build site: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := string(data)
+
+	for _, want := range []string{
+		"<title>Payments PRDs</title>",
+		`<span class="badge">1 PRDs</span>`,
+		`<a href="PRD-001.html">Login &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</a>`,
+		"<td>Ana &amp; Raj</td>",
+		"Generated on March 1, 2025 at 9:30 AM",
+		// The search entries are JSON, with markup escaped for the script
+		`"id":"PRD-001"`,
+		`"url":"PRD-001.html"`,
+		`users abandon \u003c/script\u003e sign-up`,
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("Expected index to contain %q, got:\n%s", want, index)
+		}
+	}
+	if strings.Count(index, "</script>") != 1 {
+		t.Errorf("Expected user text not to close the search script:\n%s", index)
+	}
+}
@@ -0,0 +1,464 @@
+package prd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultTracePattern matches user story and requirement IDs such as US-001,
+// FR-003 and NFR-002, also inside identifiers such as TestFR003 and
+// Test_FR_003. When a pattern has a group, the first group is the ID.
+var DefaultTracePattern = regexp.MustCompile(`(?:^|[^A-Z])((?:US|FR|NFR)[-_]?\d+)`)
+
+var (
+	// traceIDParts splits an ID written in an identifier, such as FR_003
+	traceIDParts = regexp.MustCompile(`^([A-Z]+)[-_]?(\d+)$`)
+	// traceQualifier matches a PRD ID and "#" just before an ID, as in
+	// PRD-042#FR-001
+	traceQualifier = regexp.MustCompile(`([A-Za-z][\w-]*)\s*#\s*$`)
+)
+
+// TraceOptions configures Trace
+type TraceOptions struct {
+	// Pattern matches ID references. Defaults to DefaultTracePattern.
+	Pattern *regexp.Regexp
+	// SkipDirs are directory names not descended into
+	SkipDirs []string
+	// SkipFiles are paths not scanned, typically the PRD file itself
+	SkipFiles []string
+	// MaxFileSize skips larger files. Defaults to 1 MiB.
+	MaxFileSize int64
+	// ExternalIDs are defined by other PRDs, so references to them are
+	// not orphans
+	ExternalIDs []string
+}
+
+// DefaultTraceOptions returns the default trace options
+func DefaultTraceOptions() TraceOptions {
+	return TraceOptions{
+		Pattern:     DefaultTracePattern,
+		SkipDirs:    []string{".git", ".hg", ".svn", "node_modules", "vendor", "dist", "build"},
+		MaxFileSize: 1 << 20,
+	}
+}
+
+// TraceReference is an ID found in a source file
+type TraceReference struct {
+	ID   string `json:"id"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Test is true when the reference is in a test or feature file
+	Test bool `json:"test"`
+	// Name is the enclosing test function or Gherkin scenario, if known
+	Name string `json:"name,omitempty"`
+	// PRD is the PRD the reference names, as in PRD-042#FR-001
+	PRD string `json:"prd,omitempty"`
+}
+
+// TraceRow links one requirement to its stories, tests and files
+type TraceRow struct {
+	Requirement string   `json:"requirement"`
+	Description string   `json:"description"`
+	Priority    MoSCoW   `json:"priority,omitempty"`
+	Stories     []string `json:"stories,omitempty"`
+	Tests       []string `json:"tests,omitempty"`
+	Files       []string `json:"files,omitempty"`
+}
+
+// Tested reports whether any test references the requirement or its stories
+func (r TraceRow) Tested() bool {
+	return len(r.Tests) > 0
+}
+
+// TraceMatrix is a requirements traceability matrix
+type TraceMatrix struct {
+	PRDID   string           `json:"prd_id"`
+	Rows    []TraceRow       `json:"rows"`
+	Orphans []TraceReference `json:"orphans,omitempty"`
+}
+
+// UntestedMustHaves returns must-have requirements without tests
+func (m *TraceMatrix) UntestedMustHaves() []TraceRow {
+	var rows []TraceRow
+	for _, row := range m.Rows {
+		if row.Priority == MustHave && !row.Tested() {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// Trace scans a source tree for references to the PRD's user story and
+// requirement IDs and builds a traceability matrix. A story is linked to a
+// requirement when the requirement lists the story as a dependency or the
+// story's acceptance criteria mention the requirement. Tests referencing a
+// linked story count towards the requirement.
+func Trace(root string, p *PRD, opts TraceOptions) (*TraceMatrix, error) {
+	if opts.Pattern == nil {
+		opts.Pattern = DefaultTracePattern
+	}
+	if opts.MaxFileSize == 0 {
+		opts.MaxFileSize = 1 << 20
+	}
+
+	refs, err := scanTraceReferences(root, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p.buildTraceMatrix(refs, opts.ExternalIDs), nil
+}
+
+func (p *PRD) buildTraceMatrix(refs []TraceReference, externalIDs []string) *TraceMatrix {
+	// References qualified with another PRD's ID belong to that PRD
+	var own []TraceReference
+	for _, ref := range refs {
+		if ref.PRD == "" || ref.PRD == p.ID {
+			own = append(own, ref)
+		}
+	}
+	refs = own
+
+	byID := map[string][]TraceReference{}
+	for _, ref := range refs {
+		byID[ref.ID] = append(byID[ref.ID], ref)
+	}
+
+	known := map[string]bool{}
+	for _, story := range p.UserStories {
+		known[story.ID] = true
+	}
+
+	matrix := &TraceMatrix{PRDID: p.ID}
+	addRow := func(id, desc string, priority MoSCoW, deps []string) {
+		known[id] = true
+		row := TraceRow{Requirement: id, Description: desc, Priority: priority}
+		for _, story := range p.UserStories {
+			if containsString(deps, story.ID) || storyMentions(story, id) {
+				row.Stories = append(row.Stories, story.ID)
+			}
+		}
+
+		tests, files := map[string]bool{}, map[string]bool{}
+		for _, id := range append([]string{id}, row.Stories...) {
+			for _, ref := range byID[id] {
+				files[ref.File] = true
+				if ref.Test {
+					name := ref.File
+					if ref.Name != "" {
+						name += ":" + ref.Name
+					}
+					tests[name] = true
+				}
+			}
+		}
+		row.Tests, row.Files = sortedKeys(tests), sortedKeys(files)
+		matrix.Rows = append(matrix.Rows, row)
+	}
+
+	for _, req := range p.Requirements.Functional {
+		addRow(req.ID, req.Description, req.Priority, req.Dependencies)
+	}
+	for _, req := range p.Requirements.NonFunctional {
+		addRow(req.ID, req.Description, "", nil)
+	}
+
+	for _, ref := range refs {
+		if !known[ref.ID] && (ref.PRD != "" || !containsString(externalIDs, ref.ID)) {
+			matrix.Orphans = append(matrix.Orphans, ref)
+		}
+	}
+
+	return matrix
+}
+
+func storyMentions(story UserStory, id string) bool {
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(id) + `\b`)
+	for _, criteria := range story.AcceptanceCriteria {
+		if pattern.MatchString(criteria) {
+			return true
+		}
+	}
+	return pattern.MatchString(story.Story)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var (
+	goTestFunc      = regexp.MustCompile(`^func\s+((?:Test|Benchmark|Example|Fuzz)\w*)\s*\(`)
+	gherkinScenario = regexp.MustCompile(`^\s*Scenario(?: Outline)?:\s*(.+)$`)
+	genericTestName = regexp.MustCompile(`^\s*(?:def\s+(test\w*)|(?:it|test|describe)\(\s*['"` + "`" + `]([^'"` + "`" + `]+))`)
+)
+
+func scanTraceReferences(root string, opts TraceOptions) ([]TraceReference, error) {
+	skip := map[string]bool{}
+	for _, d := range opts.SkipDirs {
+		skip[d] = true
+	}
+	skipFiles := map[string]bool{}
+	for _, f := range opts.SkipFiles {
+		if abs, err := filepath.Abs(f); err == nil {
+			skipFiles[abs] = true
+		}
+	}
+
+	var refs []TraceReference
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && skip[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if abs, err := filepath.Abs(path); err == nil && skipFiles[abs] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > opts.MaxFileSize {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+		fileRefs, err := scanTraceFile(path, filepath.ToSlash(rel), opts.Pattern)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, fileRefs...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return refs, nil
+}
+
+func scanTraceFile(path, rel string, pattern *regexp.Regexp) ([]TraceReference, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	// Skip binary files
+	if bytes.IndexByte(data[:min(len(data), 512)], 0) >= 0 {
+		return nil, nil
+	}
+
+	isTest := isTestFile(rel)
+	isFeature := strings.HasSuffix(rel, ".feature")
+
+	var refs []TraceReference
+	name, nameIndent := "", 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		// A test ends at the next line indented no deeper than its start.
+		// A closing brace or parenthesis still belongs to it.
+		closing := false
+		if trimmed := strings.TrimSpace(text); name != "" && trimmed != "" && len(text)-len(strings.TrimLeft(text, " \t")) <= nameIndent {
+			if closing = strings.IndexAny(trimmed[:1], "})]") == 0; !closing {
+				name = ""
+			}
+		}
+		if isTest {
+			indent := len(text) - len(strings.TrimLeft(text, " \t"))
+			if m := goTestFunc.FindStringSubmatch(text); m != nil {
+				name, nameIndent = m[1], indent
+			} else if m := genericTestName.FindStringSubmatch(text); m != nil {
+				name, nameIndent = m[1]+m[2], indent
+			} else if m := gherkinScenario.FindStringSubmatch(text); isFeature && m != nil {
+				name, nameIndent = strings.TrimSpace(m[1]), indent
+			}
+		}
+
+		seen := map[string]bool{}
+		for _, m := range pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[0], m[1]
+			if len(m) > 2 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			id := normalizeTraceID(text[start:end])
+			qualifier := ""
+			if q := traceQualifier.FindStringSubmatch(text[:start]); q != nil {
+				qualifier = q[1]
+			}
+			if seen[qualifier+"#"+id] {
+				continue
+			}
+			seen[qualifier+"#"+id] = true
+			refs = append(refs, TraceReference{ID: id, File: rel, Line: line, Test: isTest, Name: name, PRD: qualifier})
+		}
+		if closing {
+			name = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	// Gherkin tags precede the scenario they apply to
+	if isFeature {
+		attachGherkinTags(refs, data)
+	}
+	return refs, nil
+}
+
+// normalizeTraceID writes an ID found in an identifier, such as FR003 or
+// FR_003, in its usual form FR-003
+func normalizeTraceID(id string) string {
+	if m := traceIDParts.FindStringSubmatch(id); m != nil {
+		return m[1] + "-" + m[2]
+	}
+	return id
+}
+
+// attachGherkinTags names references found on tag lines after the scenario
+// that follows them
+func attachGherkinTags(refs []TraceReference, data []byte) {
+	lines := strings.Split(string(data), "\n")
+	for i := range refs {
+		if refs[i].Line > len(lines) || !strings.HasPrefix(strings.TrimSpace(lines[refs[i].Line-1]), "@") {
+			continue
+		}
+		for _, next := range lines[refs[i].Line:] {
+			if m := gherkinScenario.FindStringSubmatch(next); m != nil {
+				refs[i].Name = strings.TrimSpace(m[1])
+				break
+			}
+			if trimmed := strings.TrimSpace(next); trimmed != "" && !strings.HasPrefix(trimmed, "@") {
+				break
+			}
+		}
+	}
+}
+
+func isTestFile(rel string) bool {
+	base := filepath.Base(rel)
+	switch {
+	case strings.HasSuffix(base, "_test.go"),
+		strings.HasSuffix(base, ".feature"),
+		strings.HasPrefix(base, "test_") && strings.HasSuffix(base, ".py"),
+		strings.Contains(base, ".test."),
+		strings.Contains(base, ".spec."):
+		return true
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(rel)), "/") {
+		if dir == "test" || dir == "tests" || dir == "__tests__" {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteTraceCSV writes the matrix as CSV, one row per requirement, followed
+// by orphan references
+func WriteTraceCSV(w io.Writer, m *TraceMatrix) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"Requirement", "Description", "Priority", "Stories", "Tests", "Files", "Tested"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, row := range m.Rows {
+		if err := cw.Write([]string{
+			row.Requirement,
+			row.Description,
+			string(row.Priority),
+			strings.Join(row.Stories, " "),
+			strings.Join(row.Tests, " "),
+			strings.Join(row.Files, " "),
+			fmt.Sprintf("%t", row.Tested()),
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	for _, ref := range m.Orphans {
+		if err := cw.Write([]string{ref.ID, "orphan reference", "", "", "", fmt.Sprintf("%s:%d", ref.File, ref.Line), ""}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTraceHTML writes the matrix as a standalone HTML report
+func WriteTraceHTML(w io.Writer, m *TraceMatrix) error {
+	data := struct {
+		*TraceMatrix
+		CSS       template.CSS
+		Untested  []TraceRow
+		TestedCnt int
+	}{TraceMatrix: m, CSS: template.CSS(htmlCSS), Untested: m.UntestedMustHaves()}
+	for _, row := range m.Rows {
+		if row.Tested() {
+			data.TestedCnt++
+		}
+	}
+
+	if err := traceTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render trace report: %w", err)
+	}
+	return nil
+}
+
+var traceTemplate = template.Must(template.New("trace").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>Traceability Matrix - {{.PRDID}}</title>
+<style>{{.CSS}}
+.untested { background: #fdecea; }
+</style>
+</head>
+<body>
+<h1>Traceability Matrix: {{.PRDID}}</h1>
+<p>{{.TestedCnt}} of {{len .Rows}} requirements have tests.</p>
+{{if .Untested}}<h2>Untested Must-Have Requirements</h2>
+<ul>{{range .Untested}}<li><strong>{{.Requirement}}</strong>: {{.Description}}</li>{{end}}</ul>
+{{end}}<h2>Matrix</h2>
+<table>
+<tr><th>Requirement</th><th>Description</th><th>Priority</th><th>Stories</th><th>Tests</th><th>Files</th></tr>
+{{range .Rows}}<tr{{if not .Tested}} class="untested"{{end}}><td>{{.Requirement}}</td><td>{{.Description}}</td><td>{{.Priority}}</td><td>{{join .Stories ", "}}</td><td>{{range .Tests}}{{.}}<br>{{end}}</td><td>{{range .Files}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+{{if .Orphans}}<h2>Orphan References</h2>
+<table>
+<tr><th>ID</th><th>Location</th></tr>
+{{range .Orphans}}<tr><td>{{.ID}}</td><td>{{.File}}:{{.Line}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
@@ -0,0 +1,171 @@
+package prd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+	// FR-002 is delivered through US-002
+	p.Requirements.Functional[1].Dependencies = []string{"US-002"}
+
+	root := writeFixture(t, map[string]string{
+		"auth/biometric.go":       "package auth\n\n// Enable implements FR-001\nfunc Enable() {}\n",
+		"auth/biometric_test.go":  "package auth\n\nfunc TestEnableBiometric(t *testing.T) {\n\t// FR-001\n}\n",
+		"features/US-002.feature": "Feature: Google sign in\n\n  @US-002 @priority-should_have\n  Scenario: Profile is created\n    Then a profile exists\n",
+		"auth/legacy.go":          "package auth\n\n// See FR-099 for details\n",
+		"node_modules/x/index.js": "// FR-003\n",
+		"logo.png":                "\x89PNG\x00FR-003",
+	})
+
+	m, err := Trace(root, p, DefaultTraceOptions())
+	if err != nil {
+		t.Fatalf("Trace failed: %v", err)
+	}
+
+	rows := map[string]TraceRow{}
+	for _, row := range m.Rows {
+		rows[row.Requirement] = row
+	}
+
+	fr1 := rows["FR-001"]
+	if !reflect.DeepEqual(fr1.Tests, []string{"auth/biometric_test.go:TestEnableBiometric"}) {
+		t.Errorf("FR-001 tests = %v", fr1.Tests)
+	}
+	if !reflect.DeepEqual(fr1.Files, []string{"auth/biometric.go", "auth/biometric_test.go"}) {
+		t.Errorf("FR-001 files = %v", fr1.Files)
+	}
+
+	fr2 := rows["FR-002"]
+	if !reflect.DeepEqual(fr2.Stories, []string{"US-002"}) {
+		t.Errorf("FR-002 stories = %v", fr2.Stories)
+	}
+	if !reflect.DeepEqual(fr2.Tests, []string{"features/US-002.feature:Profile is created"}) {
+		t.Errorf("FR-002 tests = %v", fr2.Tests)
+	}
+
+	if fr3 := rows["FR-003"]; fr3.Tested() || len(fr3.Files) != 0 {
+		t.Errorf("Expected FR-003 untraced (skipped dirs and binary files), got %+v", fr3)
+	}
+
+	var untested []string
+	for _, row := range m.UntestedMustHaves() {
+		untested = append(untested, row.Requirement)
+	}
+	for _, row := range m.Rows {
+		if row.Priority == "must_have" && row.Requirement != "FR-001" && !containsString(untested, row.Requirement) {
+			t.Errorf("Expected %s reported as untested must-have", row.Requirement)
+		}
+	}
+	if containsString(untested, "FR-001") {
+		t.Error("FR-001 is tested and should not be reported")
+	}
+
+	if len(m.Orphans) != 1 || m.Orphans[0].ID != "FR-099" || m.Orphans[0].Line != 3 {
+		t.Errorf("Expected FR-099 orphan at line 3, got %+v", m.Orphans)
+	}
+}
+
+func TestTraceIdentifiersAndScopes(t *testing.T) {
+	p, err := LoadFromFile("example.json")
+	if err != nil {
+		t.Fatalf("Failed to load example PRD: %v", err)
+	}
+
+	root := writeFixture(t, map[string]string{
+		"auth/login_test.go": "package auth\n\n" +
+			"func TestFR001(t *testing.T) {}\n\n" +
+			"func Test_FR_002_Profile(t *testing.T) {\n}\n\n" +
+			"// Helper for NFR-001, outside any test\n" +
+			"func helper() {}\n",
+		"auth/login.go": "package auth\n\n" +
+			"// Implements PRD-042#FR-001 and PRD-001#FR-003\n" +
+			"// Also FR-050 from the payments PRD and FR-099\n",
+	})
+
+	opts := DefaultTraceOptions()
+	opts.ExternalIDs = []string{"FR-050"}
+	m, err := Trace(root, p, opts)
+	if err != nil {
+		t.Fatalf("Trace failed: %v", err)
+	}
+
+	rows := map[string]TraceRow{}
+	for _, row := range m.Rows {
+		rows[row.Requirement] = row
+	}
+	if got := rows["FR-001"].Tests; !reflect.DeepEqual(got, []string{"auth/login_test.go:TestFR001"}) {
+		t.Errorf("FR-001 tests = %v", got)
+	}
+	if got := rows["FR-002"].Tests; !reflect.DeepEqual(got, []string{"auth/login_test.go:Test_FR_002_Profile"}) {
+		t.Errorf("FR-002 tests = %v", got)
+	}
+	if got := rows["NFR-001"].Tests; !reflect.DeepEqual(got, []string{"auth/login_test.go"}) {
+		t.Errorf("Expected NFR-001 outside a test function, got %v", got)
+	}
+	// Qualified with this PRD's ID counts; with another PRD's ID it does not
+	if got := rows["FR-003"].Files; !reflect.DeepEqual(got, []string{"auth/login.go"}) {
+		t.Errorf("FR-003 files = %v", got)
+	}
+	if got := rows["FR-001"].Files; !reflect.DeepEqual(got, []string{"auth/login_test.go"}) {
+		t.Errorf("Expected PRD-042#FR-001 not to count for FR-001, got %v", got)
+	}
+	if len(m.Orphans) != 1 || m.Orphans[0].ID != "FR-099" {
+		t.Errorf("Expected only FR-099 as an orphan, got %+v", m.Orphans)
+	}
+}
+
+func TestNormalizeTraceID(t *testing.T) {
+	tests := map[string]string{"FR-003": "FR-003", "FR003": "FR-003", "FR_003": "FR-003", "NFR_12": "NFR-12", "JIRA-7": "JIRA-7"}
+	for input, expected := range tests {
+		if got := normalizeTraceID(input); got != expected {
+			t.Errorf("normalizeTraceID(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestWriteTraceReports(t *testing.T) {
+	m := &TraceMatrix{
+		PRDID: "PRD-001",
+		Rows: []TraceRow{
+			{Requirement: "FR-001", Description: "Login <fast>", Priority: "must_have", Tests: []string{"a_test.go:TestA"}, Files: []string{"a_test.go"}},
+			{Requirement: "FR-002", Description: "Logout", Priority: "must_have"},
+		},
+		Orphans: []TraceReference{{ID: "FR-009", File: "b.go", Line: 7}},
+	}
+
+	var csvBuf bytes.Buffer
+	if err := WriteTraceCSV(&csvBuf, m); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 || records[1][6] != "true" || records[2][6] != "false" || records[3][5] != "b.go:7" {
+		t.Errorf("Unexpected CSV records: %v", records)
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := WriteTraceHTML(&htmlBuf, m); err != nil {
+		t.Fatal(err)
+	}
+	out := htmlBuf.String()
+	for _, s := range []string{
+		"1 of 2 requirements have tests",
+		"Login &lt;fast&gt;",
+		`<tr class="untested"><td>FR-002</td>`,
+		"<td>b.go:7</td>",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected HTML report to contain %q", s)
+		}
+	}
+}
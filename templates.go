@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -51,25 +53,58 @@ func listTemplates() error {
 		fmt.Printf("• %s - %s\n", color.YellowString(name), description)
 	}
 
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	for _, dir := range cfg.TemplatePaths() {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, file := range files {
+			name := strings.TrimSuffix(filepath.Base(file), ".json")
+			fmt.Printf("• %s - Workspace template (%s)\n", color.YellowString(name), file)
+		}
+	}
+
 	fmt.Println("\nUsage: prd-manager template create <template-name> <filename>")
 	return nil
 }
 
 func showTemplate(templateName string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	template, err := loadTemplate(cfg, templateName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", color.CyanString("Template"), templateName)
+	displayPRDPretty(template, "")
+	return nil
+}
+
+// loadTemplate returns the named template, looking in the workspace template
+// directories before the built-in templates
+func loadTemplate(cfg *prd.Config, name string) (*prd.PRD, error) {
+	if path := cfg.FindTemplate(name); path != "" {
+		template, err := prd.LoadFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load template %s: %w", path, err)
+		}
+		return template, nil
+	}
+
 	templates := map[string]*prd.PRD{
 		"basic":   createBasicTemplate(),
 		"feature": createFeatureTemplate(),
 		"epic":    createEpicTemplate(),
 	}
-
-	template, exists := templates[templateName]
+	template, exists := templates[name]
 	if !exists {
-		return fmt.Errorf("template '%s' not found", templateName)
+		return nil, fmt.Errorf("template '%s' not found. Available: basic, feature, epic", name)
 	}
-
-	fmt.Printf("%s: %s\n", color.CyanString("Template"), templateName)
-	displayPRDPretty(template, "")
-	return nil
+	return template, nil
 }
 
 func createBasicTemplate() *prd.PRD {
@@ -77,7 +112,7 @@ func createBasicTemplate() *prd.PRD {
 		ID:          "PRD-BASIC-TEMPLATE",
 		Title:       "[TEMPLATE] Basic Product Feature",
 		Version:     "1.0.0",
-		CreatedDate: time.Now().Format("2006-01-02"),
+		CreatedDate: prd.DateOf(time.Now()),
 		Owner: prd.Owner{
 			Name:  "[OWNER_NAME]",
 			Email: "[OWNER_EMAIL]",
@@ -132,7 +167,7 @@ func createFeatureTemplate() *prd.PRD {
 		ID:          "PRD-FEATURE-TEMPLATE",
 		Title:       "[TEMPLATE] New Product Feature",
 		Version:     "1.0.0",
-		CreatedDate: time.Now().Format("2006-01-02"),
+		CreatedDate: prd.DateOf(time.Now()),
 		Owner: prd.Owner{
 			Name:  "[PRODUCT_MANAGER_NAME]",
 			Email: "[PM_EMAIL]",
@@ -255,22 +290,22 @@ func createFeatureTemplate() *prd.PRD {
 				{
 					Name:        "Design & Planning Complete",
 					Description: "UI/UX designs approved, technical design finalized",
-					TargetDate:  "[DATE]",
+					TargetDate:  monthsFromNow(1),
 				},
 				{
 					Name:         "MVP Development Complete",
 					Description:  "Core functionality implemented and tested",
-					TargetDate:   "[DATE]",
+					TargetDate:   monthsFromNow(2),
 					Dependencies: []string{"Design & Planning Complete"},
 				},
 				{
 					Name:         "Beta Release",
 					Description:  "Feature available to beta users for testing",
-					TargetDate:   "[DATE]",
+					TargetDate:   monthsFromNow(3),
 					Dependencies: []string{"MVP Development Complete"},
 				},
 			},
-			LaunchDate: "[LAUNCH_DATE]",
+			LaunchDate: monthsFromNow(4),
 		},
 		RisksAndAssumptions: &prd.RisksAndAssumptions{
 			Risks: []prd.Risk{
@@ -287,10 +322,10 @@ func createFeatureTemplate() *prd.PRD {
 					MitigationStrategy: "Conduct user research, A/B test different approaches",
 				},
 			},
-			Assumptions: []string{
-				"Users are familiar with similar features in other products",
-				"Current infrastructure can support the additional load",
-				"Third-party integrations will remain stable",
+			Assumptions: []prd.Assumption{
+				{Statement: "Users are familiar with similar features in other products", Confidence: prd.ConfidenceMedium},
+				{Statement: "Current infrastructure can support the additional load", Confidence: prd.ConfidenceMedium},
+				{Statement: "Third-party integrations will remain stable", Confidence: prd.ConfidenceMedium},
 			},
 		},
 		OutOfScope: []string{
@@ -305,7 +340,7 @@ func createEpicTemplate() *prd.PRD {
 		ID:          "PRD-EPIC-TEMPLATE",
 		Title:       "[TEMPLATE] Major Product Initiative",
 		Version:     "1.0.0",
-		CreatedDate: time.Now().Format("2006-01-02"),
+		CreatedDate: prd.DateOf(time.Now()),
 		Owner: prd.Owner{
 			Name:  "[SENIOR_PM_NAME]",
 			Email: "[SENIOR_PM_EMAIL]",
@@ -368,18 +403,18 @@ func createEpicTemplate() *prd.PRD {
 			OKRs: []prd.OKR{
 				{
 					Objective: "Establish market-leading [PRODUCT_CAPABILITY]",
-					KeyResults: []string{
-						"Launch [CAPABILITY] to 100% of customers",
-						"Achieve [METRIC] improvement in [MEASUREMENT]",
-						"Secure [NUMBER] strategic customer wins",
+					KeyResults: []prd.KeyResult{
+						{Description: "Launch [CAPABILITY] to 100% of customers"},
+						{Description: "Achieve [METRIC] improvement in [MEASUREMENT]"},
+						{Description: "Secure [NUMBER] strategic customer wins"},
 					},
 				},
 				{
 					Objective: "Drive significant revenue growth",
-					KeyResults: []string{
-						"Generate $[AMOUNT] in new revenue",
-						"Increase average contract value by [PERCENTAGE]%",
-						"Improve customer retention to [PERCENTAGE]%",
+					KeyResults: []prd.KeyResult{
+						{Description: "Generate $[AMOUNT] in new revenue"},
+						{Description: "Increase average contract value by [PERCENTAGE]%"},
+						{Description: "Improve customer retention to [PERCENTAGE]%"},
 					},
 				},
 			},
@@ -484,22 +519,22 @@ func createEpicTemplate() *prd.PRD {
 				{
 					Name:        "Phase 1: Foundation",
 					Description: "Core platform infrastructure and basic functionality",
-					TargetDate:  "[Q1_DATE]",
+					TargetDate:  quartersFromNow(1),
 				},
 				{
 					Name:         "Phase 2: Advanced Features",
 					Description:  "Analytics, integrations, and enterprise features",
-					TargetDate:   "[Q2_DATE]",
+					TargetDate:   quartersFromNow(2),
 					Dependencies: []string{"Phase 1: Foundation"},
 				},
 				{
 					Name:         "Phase 3: Scale & Polish",
 					Description:  "Performance optimization, mobile app, and market launch",
-					TargetDate:   "[Q3_DATE]",
+					TargetDate:   quartersFromNow(3),
 					Dependencies: []string{"Phase 2: Advanced Features"},
 				},
 			},
-			LaunchDate: "[GENERAL_AVAILABILITY_DATE]",
+			LaunchDate: quartersFromNow(3),
 		},
 		RisksAndAssumptions: &prd.RisksAndAssumptions{
 			Risks: []prd.Risk{
@@ -522,12 +557,12 @@ func createEpicTemplate() *prd.PRD {
 					MitigationStrategy: "Accelerate unique features, build patent portfolio",
 				},
 			},
-			Assumptions: []string{
-				"Market demand will remain stable throughout development",
-				"Key talent will be available for hiring",
-				"Technology choices will remain viable for 3+ years",
-				"Regulatory environment will not significantly change",
-				"Customer budget allocation for [CATEGORY] will increase",
+			Assumptions: []prd.Assumption{
+				{Statement: "Market demand will remain stable throughout development", Confidence: prd.ConfidenceMedium},
+				{Statement: "Key talent will be available for hiring", Confidence: prd.ConfidenceMedium},
+				{Statement: "Technology choices will remain viable for 3+ years", Confidence: prd.ConfidenceMedium},
+				{Statement: "Regulatory environment will not significantly change", Confidence: prd.ConfidenceMedium},
+				{Statement: "Customer budget allocation for [CATEGORY] will increase", Confidence: prd.ConfidenceMedium},
 			},
 		},
 		OutOfScope: []string{
@@ -555,3 +590,15 @@ func createEpicTemplate() *prd.PRD {
 		},
 	}
 }
+
+// monthsFromNow returns a placeholder month n months after the current one
+func monthsFromNow(n int) prd.Date {
+	now := time.Now()
+	return prd.MonthOf(time.Date(now.Year(), now.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC))
+}
+
+// quartersFromNow returns a placeholder quarter n quarters after the current one
+func quartersFromNow(n int) prd.Date {
+	now := time.Now()
+	return prd.QuarterOf(time.Date(now.Year(), now.Month()+time.Month(3*n), 1, 0, 0, 0, 0, time.UTC))
+}
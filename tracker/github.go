@@ -0,0 +1,219 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultGitHubBaseURL is the public GitHub REST API endpoint
+const DefaultGitHubBaseURL = "https://api.github.com"
+
+// GitHub is a Tracker backed by the GitHub Issues REST API
+type GitHub struct {
+	BaseURL string
+	Owner   string
+	Repo    string
+	Token   string
+	// InProgressLabels mark open issues as in progress
+	InProgressLabels []string
+	HTTPClient       *http.Client
+}
+
+// NewGitHub returns a GitHub Issues tracker for owner/repo
+func NewGitHub(owner, repo, token string) *GitHub {
+	return &GitHub{
+		BaseURL:          DefaultGitHubBaseURL,
+		Owner:            owner,
+		Repo:             repo,
+		Token:            token,
+		InProgressLabels: []string{"in progress", "in-progress"},
+		HTTPClient:       http.DefaultClient,
+	}
+}
+
+// NewGitHubFromRepo returns a GitHub Issues tracker for an "owner/repo" string
+func NewGitHubFromRepo(repo, token string) (*GitHub, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" {
+		return nil, fmt.Errorf("invalid GitHub repository '%s': expected owner/name", repo)
+	}
+	return NewGitHub(owner, name, token), nil
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+type githubIssue struct {
+	Number      int           `json:"number"`
+	HTMLURL     string        `json:"html_url"`
+	Title       string        `json:"title"`
+	Body        string        `json:"body"`
+	State       string        `json:"state"`
+	Labels      []githubLabel `json:"labels"`
+	PullRequest *struct{}     `json:"pull_request,omitempty"`
+}
+
+type githubIssueRequest struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// CreateIssue creates a GitHub issue
+func (g *GitHub) CreateIssue(ctx context.Context, issue Issue) (*Issue, error) {
+	var created githubIssue
+	req := githubIssueRequest{Title: issue.Title, Body: issue.Body, Labels: issue.Labels}
+	if err := g.do(ctx, http.MethodPost, g.repoPath("issues"), req, &created); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	return g.toIssue(created), nil
+}
+
+// UpdateIssue updates the title and body of a GitHub issue, and replaces its
+// labels when Labels is not empty
+func (g *GitHub) UpdateIssue(ctx context.Context, issue Issue) (*Issue, error) {
+	var updated githubIssue
+	req := githubIssueRequest{Title: issue.Title, Body: issue.Body, Labels: issue.Labels}
+	if err := g.do(ctx, http.MethodPatch, g.repoPath("issues", issue.Key), req, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update issue %s: %w", issue.Key, err)
+	}
+	return g.toIssue(updated), nil
+}
+
+// GetIssue returns the GitHub issue with the given number
+func (g *GitHub) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	var found githubIssue
+	if err := g.do(ctx, http.MethodGet, g.repoPath("issues", key), nil, &found); err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", key, err)
+	}
+	return g.toIssue(found), nil
+}
+
+// QueryIssues lists GitHub issues, excluding pull requests
+func (g *GitHub) QueryIssues(ctx context.Context, query Query) ([]Issue, error) {
+	params := url.Values{}
+	params.Set("state", "all")
+	if query.State != "" {
+		params.Set("state", query.State)
+	}
+	if len(query.Labels) > 0 {
+		params.Set("labels", strings.Join(query.Labels, ","))
+	}
+	params.Set("per_page", "100")
+
+	var issues []Issue
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		var found []githubIssue
+		if err := g.do(ctx, http.MethodGet, g.repoPath("issues")+"?"+params.Encode(), nil, &found); err != nil {
+			return nil, fmt.Errorf("failed to query issues: %w", err)
+		}
+		for _, gi := range found {
+			if gi.PullRequest != nil {
+				continue
+			}
+			issues = append(issues, *g.toIssue(gi))
+		}
+		if len(found) < 100 {
+			break
+		}
+	}
+	return issues, nil
+}
+
+// MapStatus maps a GitHub state and labels onto a delivery status
+func (g *GitHub) MapStatus(state string, labels []string) Status {
+	if state == "closed" {
+		return StatusDone
+	}
+	for _, label := range labels {
+		for _, inProgress := range g.InProgressLabels {
+			if strings.EqualFold(label, inProgress) {
+				return StatusInProgress
+			}
+		}
+	}
+	return StatusTodo
+}
+
+func (g *GitHub) toIssue(gi githubIssue) *Issue {
+	labels := make([]string, len(gi.Labels))
+	for i, l := range gi.Labels {
+		labels[i] = l.Name
+	}
+	return &Issue{
+		Key:    strconv.Itoa(gi.Number),
+		URL:    gi.HTMLURL,
+		Title:  gi.Title,
+		Body:   gi.Body,
+		Labels: labels,
+		State:  gi.State,
+		Status: g.MapStatus(gi.State, labels),
+	}
+}
+
+func (g *GitHub) repoPath(parts ...string) string {
+	segments := []string{"repos", url.PathEscape(g.Owner), url.PathEscape(g.Repo)}
+	for _, p := range parts {
+		segments = append(segments, url.PathEscape(p))
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func (g *GitHub) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(g.BaseURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+
+	client := g.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("GitHub API returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode GitHub response: %w", err)
+	}
+	return nil
+}
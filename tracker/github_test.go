@@ -0,0 +1,277 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grokify/product-artifacts/prd"
+)
+
+// fakeGitHub is a minimal in-memory stand-in for the GitHub Issues API
+type fakeGitHub struct {
+	mu     sync.Mutex
+	issues map[int]*githubIssue
+	next   int
+	// failCreate makes the nth create request fail
+	failCreate int
+	creates    int
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *GitHub) {
+	fake := &fakeGitHub{issues: map[int]*githubIssue{}, next: 1}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	gh := NewGitHub("acme", "product", "test-token")
+	gh.BaseURL = server.URL
+	gh.HTTPClient = server.Client()
+	return fake, gh
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/repos/acme/product/issues")
+	switch {
+	case path == "" && r.Method == http.MethodPost:
+		f.creates++
+		if f.creates == f.failCreate {
+			http.Error(w, `{"message":"Server Error"}`, http.StatusInternalServerError)
+			return
+		}
+		var req githubIssueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		issue := &githubIssue{
+			Number:  f.next,
+			HTMLURL: "https://github.com/acme/product/issues/" + strconv.Itoa(f.next),
+			Title:   req.Title,
+			Body:    req.Body,
+			State:   "open",
+		}
+		for _, l := range req.Labels {
+			issue.Labels = append(issue.Labels, githubLabel{Name: l})
+		}
+		f.issues[f.next] = issue
+		f.next++
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(issue)
+
+	case path == "" && r.Method == http.MethodGet:
+		var list []*githubIssue
+		for n := 1; n < f.next; n++ {
+			if issue, ok := f.issues[n]; ok {
+				list = append(list, issue)
+			}
+		}
+		list = append(list, &githubIssue{Number: 999, Title: "A pull request", PullRequest: &struct{}{}})
+		_ = json.NewEncoder(w).Encode(list)
+
+	case strings.HasPrefix(path, "/"):
+		n, _ := strconv.Atoi(strings.TrimPrefix(path, "/"))
+		issue, ok := f.issues[n]
+		if !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var req githubIssueRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			issue.Title, issue.Body = req.Title, req.Body
+			if req.Labels != nil {
+				issue.Labels = nil
+				for _, l := range req.Labels {
+					issue.Labels = append(issue.Labels, githubLabel{Name: l})
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(issue)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func testPRD() *prd.PRD {
+	return &prd.PRD{
+		ID:    "PRD-SYNC-001",
+		Title: "Sync Test",
+		UserStories: []prd.UserStory{
+			{ID: "US-001", Story: "As a user, I want SSO", AcceptanceCriteria: []string{"Given SAML, when I log in, then I am signed in"}},
+		},
+		Requirements: prd.Requirements{
+			Functional: []prd.FunctionalRequirement{
+				{ID: "FR-001", Description: "Support SAML", Priority: "must_have"},
+				{ID: "FR-002", Description: "Support OIDC", Priority: "should_have"},
+			},
+		},
+	}
+}
+
+func TestSyncCreatesAndPullsStatus(t *testing.T) {
+	fake, gh := newFakeGitHub(t)
+	p := testPRD()
+	ctx := context.Background()
+
+	result, err := Sync(ctx, gh, p, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Created() != 3 {
+		t.Fatalf("Expected 3 issues created, got %d", result.Created())
+	}
+	if p.UserStories[0].Tracker == nil || p.UserStories[0].Tracker.Key != "1" {
+		t.Fatalf("Expected story linked to issue 1, got %+v", p.UserStories[0].Tracker)
+	}
+	if got := fake.issues[1].Labels[0].Name; got != "PRD-SYNC-001" {
+		t.Errorf("Expected PRD ID label, got %s", got)
+	}
+	if !strings.Contains(fake.issues[1].Body, "- [ ] Given SAML") {
+		t.Errorf("Expected acceptance criteria checklist in body, got %q", fake.issues[1].Body)
+	}
+
+	// Close one issue and mark another in progress, then sync again
+	fake.issues[2].State = "closed"
+	fake.issues[3].Labels = append(fake.issues[3].Labels, githubLabel{Name: "In Progress"})
+
+	result, err = Sync(ctx, gh, p, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	if result.Created() != 0 {
+		t.Errorf("Expected no new issues, got %d", result.Created())
+	}
+	if got := p.Requirements.Functional[0].Tracker.Status; got != string(StatusDone) {
+		t.Errorf("Expected FR-001 done, got %s", got)
+	}
+	if got := p.Requirements.Functional[1].Tracker.Status; got != string(StatusInProgress) {
+		t.Errorf("Expected FR-002 in progress, got %s", got)
+	}
+	if done, total := result.Progress(); done != 1 || total != 3 {
+		t.Errorf("Expected progress 1/3, got %d/%d", done, total)
+	}
+}
+
+func TestSyncKeepsLinksOnFailure(t *testing.T) {
+	fake, gh := newFakeGitHub(t)
+	fake.failCreate = 2
+	p := testPRD()
+	ctx := context.Background()
+
+	result, err := Sync(ctx, gh, p, SyncOptions{})
+	if err == nil || !strings.Contains(err.Error(), "FR-001") {
+		t.Fatalf("Expected FR-001 to fail, got %v", err)
+	}
+	if result.Created() != 1 || p.UserStories[0].Tracker == nil || p.UserStories[0].Tracker.Key != "1" {
+		t.Fatalf("Expected the story's issue to stay linked, got %+v", p.UserStories[0].Tracker)
+	}
+	if p.Requirements.Functional[0].Tracker != nil {
+		t.Errorf("Expected FR-001 unlinked, got %+v", p.Requirements.Functional[0].Tracker)
+	}
+
+	// Retrying creates only the issues that are still missing
+	result, err = Sync(ctx, gh, p, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Retry failed: %v", err)
+	}
+	if result.Created() != 2 || len(fake.issues) != 3 {
+		t.Errorf("Expected 2 more issues and 3 in total, got %d and %d", result.Created(), len(fake.issues))
+	}
+}
+
+func TestSyncUpdateKeepsLabels(t *testing.T) {
+	fake, gh := newFakeGitHub(t)
+	p := testPRD()
+	ctx := context.Background()
+
+	if _, err := Sync(ctx, gh, p, SyncOptions{}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	fake.issues[2].Labels = append(fake.issues[2].Labels, githubLabel{Name: "In Progress"})
+	p.Requirements.Functional[0].Description = "Support SAML 2.0"
+
+	result, err := Sync(ctx, gh, p, SyncOptions{UpdateExisting: true, Labels: []string{"sso"}})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	var labels []string
+	for _, l := range fake.issues[2].Labels {
+		labels = append(labels, l.Name)
+	}
+	if strings.Join(labels, ",") != "PRD-SYNC-001,In Progress,sso" {
+		t.Errorf("Expected existing labels kept, got %v", labels)
+	}
+	if !strings.Contains(fake.issues[2].Title, "SAML 2.0") || result.Items[1].Status != StatusInProgress {
+		t.Errorf("Unexpected update result: %q %+v", fake.issues[2].Title, result.Items[1])
+	}
+}
+
+func TestSyncDryRunAndMissingIssue(t *testing.T) {
+	fake, gh := newFakeGitHub(t)
+	p := testPRD()
+	p.Requirements.Functional[0].Tracker = &prd.TrackerLink{Key: "42", Status: "in_progress"}
+
+	result, err := Sync(context.Background(), gh, p, SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(fake.issues) != 0 {
+		t.Errorf("Expected no issues created in dry run, got %d", len(fake.issues))
+	}
+	if p.UserStories[0].Tracker != nil {
+		t.Error("Expected PRD unchanged in dry run")
+	}
+
+	var missing *SyncItem
+	for i := range result.Items {
+		if result.Items[i].ID == "FR-001" {
+			missing = &result.Items[i]
+		}
+	}
+	if missing == nil || !missing.Missing {
+		t.Errorf("Expected FR-001 reported missing, got %+v", missing)
+	}
+}
+
+func TestGitHubQueryAndErrors(t *testing.T) {
+	_, gh := newFakeGitHub(t)
+	ctx := context.Background()
+
+	if _, err := gh.CreateIssue(ctx, Issue{Title: "One"}); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := gh.QueryIssues(ctx, Query{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Errorf("Expected pull requests to be excluded, got %d issues", len(issues))
+	}
+
+	if _, err := gh.GetIssue(ctx, "404"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	gh.Token = "wrong"
+	if _, err := gh.GetIssue(ctx, "1"); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected 401 error, got %v", err)
+	}
+}
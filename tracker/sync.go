@@ -0,0 +1,206 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grokify/product-artifacts/prd"
+)
+
+// SyncOptions configures Sync
+type SyncOptions struct {
+	// DryRun reports what would be created without calling the tracker
+	DryRun bool
+	// UpdateExisting pushes title and body changes to linked issues and adds
+	// missing labels, keeping the labels already on them
+	UpdateExisting bool
+	// Labels are added to created issues in addition to the PRD ID
+	Labels []string
+	// Now is used for SyncedAt timestamps. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// SyncItem reports the sync outcome for one user story or requirement
+type SyncItem struct {
+	ID      string
+	Kind    string
+	Key     string
+	URL     string
+	Status  Status
+	Created bool
+	Updated bool
+	Missing bool
+}
+
+// SyncResult summarizes a sync run
+type SyncResult struct {
+	Items []SyncItem
+}
+
+// Created returns the number of issues created
+func (r SyncResult) Created() int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Created {
+			n++
+		}
+	}
+	return n
+}
+
+// Progress returns the number of items that are done and the total
+func (r SyncResult) Progress() (done, total int) {
+	for _, item := range r.Items {
+		total++
+		if item.Status == StatusDone {
+			done++
+		}
+	}
+	return done, total
+}
+
+// Sync creates issues for user stories and functional requirements that are
+// not yet linked, stores the issue key back on the PRD element, and pulls
+// the current status of linked issues. The PRD is modified in place, and each
+// link is stored as soon as its issue is created, so after an error the PRD
+// and the partial result still record the issues created so far.
+func Sync(ctx context.Context, t Tracker, p *prd.PRD, opts SyncOptions) (SyncResult, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	labels := append([]string{p.ID}, opts.Labels...)
+
+	var result SyncResult
+	sync := func(id, kind string, link **prd.TrackerLink, issue Issue) error {
+		item := SyncItem{ID: id, Kind: kind}
+		issue.Labels = labels
+
+		switch {
+		case *link == nil && opts.DryRun:
+			item.Created = true
+			item.Status = StatusTodo
+
+		case *link == nil:
+			created, err := t.CreateIssue(ctx, issue)
+			if err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+			item.Created = true
+			item.Key, item.URL, item.Status = created.Key, created.URL, created.Status
+
+		default:
+			item.Key = (*link).Key
+			var current *Issue
+			var err error
+			current, err = t.GetIssue(ctx, item.Key)
+			if err == nil && opts.UpdateExisting && !opts.DryRun {
+				// Keep labels added in the tracker, such as workflow states
+				issue.Key = item.Key
+				issue.Labels = mergeLabels(current.Labels, labels)
+				current, err = t.UpdateIssue(ctx, issue)
+				item.Updated = err == nil
+			}
+			if errors.Is(err, ErrNotFound) {
+				item.Missing = true
+				item.URL, item.Status = (*link).URL, Status((*link).Status)
+				result.Items = append(result.Items, item)
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+			item.URL, item.Status = current.URL, current.Status
+		}
+
+		result.Items = append(result.Items, item)
+		if !opts.DryRun {
+			synced := now()
+			*link = &prd.TrackerLink{Key: item.Key, URL: item.URL, Status: string(item.Status), SyncedAt: &synced}
+		}
+		return nil
+	}
+
+	for i := range p.UserStories {
+		story := &p.UserStories[i]
+		issue := Issue{
+			Title: summary(story.ID, story.Story),
+			Body:  storyBody(p, story),
+		}
+		if err := sync(story.ID, "story", &story.Tracker, issue); err != nil {
+			return result, err
+		}
+	}
+
+	for i := range p.Requirements.Functional {
+		req := &p.Requirements.Functional[i]
+		issue := Issue{
+			Title: summary(req.ID, req.Description),
+			Body:  requirementBody(p, req),
+		}
+		if err := sync(req.ID, "requirement", &req.Tracker, issue); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// mergeLabels returns the existing labels followed by the wanted ones they
+// lack, ignoring case
+func mergeLabels(existing, wanted []string) []string {
+	merged := slices.Clone(existing)
+	for _, label := range wanted {
+		if !slices.ContainsFunc(merged, func(l string) bool { return strings.EqualFold(l, label) }) {
+			merged = append(merged, label)
+		}
+	}
+	return merged
+}
+
+func summary(id, text string) string {
+	s := id + ": " + strings.Join(strings.Fields(text), " ")
+	if r := []rune(s); len(r) > 120 {
+		return string(r[:117]) + "..."
+	}
+	return s
+}
+
+func storyBody(p *prd.PRD, story *prd.UserStory) string {
+	var b strings.Builder
+	b.WriteString(story.Story + "\n")
+	if len(story.AcceptanceCriteria) > 0 {
+		b.WriteString("\n### Acceptance Criteria\n\n")
+		for _, criteria := range story.AcceptanceCriteria {
+			b.WriteString("- [ ] " + criteria + "\n")
+		}
+	}
+	writeFooter(&b, p, string(story.Priority), story.EffortEstimate)
+	return b.String()
+}
+
+func requirementBody(p *prd.PRD, req *prd.FunctionalRequirement) string {
+	var b strings.Builder
+	b.WriteString(req.Description + "\n")
+	if len(req.Dependencies) > 0 {
+		b.WriteString("\n**Dependencies:** " + strings.Join(req.Dependencies, ", ") + "\n")
+	}
+	writeFooter(&b, p, string(req.Priority), "")
+	return b.String()
+}
+
+func writeFooter(b *strings.Builder, p *prd.PRD, priority, estimate string) {
+	b.WriteString("\n---\n")
+	fmt.Fprintf(b, "PRD: %s (%s)", p.ID, p.Title)
+	if priority != "" {
+		fmt.Fprintf(b, " · Priority: %s", priority)
+	}
+	if estimate != "" {
+		fmt.Fprintf(b, " · Estimate: %s", estimate)
+	}
+	b.WriteString("\n")
+}
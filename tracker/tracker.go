@@ -0,0 +1,56 @@
+// Package tracker synchronizes PRD user stories and functional requirements
+// with external issue trackers.
+package tracker
+
+import (
+	"context"
+	"errors"
+)
+
+// Status is the tracker-neutral delivery status of an issue
+type Status string
+
+const (
+	StatusTodo       Status = "todo"
+	StatusInProgress Status = "in_progress"
+	StatusDone       Status = "done"
+)
+
+// ErrNotFound is returned when an issue does not exist in the tracker
+var ErrNotFound = errors.New("issue not found")
+
+// Issue is a tracker-neutral issue
+type Issue struct {
+	// Key identifies the issue in the tracker (e.g. "42" or "PROJ-42")
+	Key    string
+	URL    string
+	Title  string
+	Body   string
+	Labels []string
+	// State is the tracker's raw state (e.g. "open", "closed")
+	State string
+	// Status is State mapped onto a delivery status
+	Status Status
+}
+
+// Query filters issues returned by QueryIssues
+type Query struct {
+	Labels []string
+	// State filters by raw tracker state. Empty means all states.
+	State string
+}
+
+// Tracker is implemented by issue tracker adapters
+type Tracker interface {
+	// CreateIssue creates an issue and returns it with Key and URL set
+	CreateIssue(ctx context.Context, issue Issue) (*Issue, error)
+	// UpdateIssue updates the title and body of an existing issue, and
+	// replaces its labels when Labels is not empty
+	UpdateIssue(ctx context.Context, issue Issue) (*Issue, error)
+	// GetIssue returns the issue with the given key or ErrNotFound
+	GetIssue(ctx context.Context, key string) (*Issue, error)
+	// QueryIssues returns issues matching the query
+	QueryIssues(ctx context.Context, query Query) ([]Issue, error)
+	// MapStatus maps a raw tracker state and labels onto a delivery status
+	MapStatus(state string, labels []string) Status
+}